@@ -1,11 +1,14 @@
 // UFS is a userspace server which exports a filesystem over 9p2000.
 //
 // By default, it will export / over a TCP on port 5640 under the username
-// of "harvey".
+// of "harvey". The -addr flag also takes Plan 9 dial strings, such as
+// tcp!*!564 or unix!/tmp/ufs.
 package main
 
 import (
+	"bytes"
 	"flag"
+	"io/ioutil"
 	"log"
 	"net"
 
@@ -14,20 +17,35 @@ import (
 )
 
 var (
-	ntype = flag.String("ntype", "tcp4", "Default network type")
-	naddr = flag.String("addr", ":5640", "Network address")
+	ntype  = flag.String("ntype", "tcp4", "Default network type")
+	naddr  = flag.String("addr", ":5640", "Network address, or a dial string such as tcp!*!5640 or unix!/tmp/ufs")
+	secret = flag.String("secret", "", "File holding a secret clients must know to attach")
 )
 
 func main() {
 	flag.Parse()
 
-	ln, err := net.Listen(*ntype, *naddr)
+	network, addr, err := protocol.ParseDialString(*naddr, *ntype)
+	if err != nil {
+		log.Fatal(err)
+	}
+	ln, err := net.Listen(network, addr)
 	if err != nil {
 		log.Fatalf("Listen failed: %v", err)
 	}
 
+	var auth protocol.Authenticator
+	if *secret != "" {
+		b, err := ioutil.ReadFile(*secret)
+		if err != nil {
+			log.Fatalf("Reading secret: %v", err)
+		}
+		auth = protocol.NewSharedSecret(bytes.TrimSpace(b))
+	}
+
 	s, err := ufs.NewUFS(func(s *protocol.Server) error {
 		s.Trace = nil // log.Printf
+		s.Auth = auth
 		return nil
 	})
 
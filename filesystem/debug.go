@@ -15,6 +15,11 @@ type debugFileServer struct {
 	*FileServer
 }
 
+func (e *debugFileServer) NewSession() (protocol.NineServer, error) {
+	log.Printf(">>> new session\n")
+	return &debugFileServer{e.FileServer.newSession()}, nil
+}
+
 func (e *debugFileServer) Rversion(msize protocol.MaxSize, version string) (protocol.MaxSize, string, error) {
 	log.Printf(">>> Tversion %v %v\n", msize, version)
 	msize, version, err := e.FileServer.Rversion(msize, version)
@@ -140,6 +145,17 @@ func (e *debugFileServer) Rread(fid protocol.FID, o protocol.Offset, c protocol.
 	return b, err
 }
 
+func (e *debugFileServer) RreadInto(fid protocol.FID, o protocol.Offset, b []byte) (int, error) {
+	log.Printf(">>> Tread fid %v, off %v, count %v\n", fid, o, len(b))
+	n, err := e.FileServer.RreadInto(fid, o, b)
+	if err == nil {
+		log.Printf("<<< Rread %v\n", n)
+	} else {
+		log.Printf("<<< Error %v\n", err)
+	}
+	return n, err
+}
+
 func (e *debugFileServer) Rwrite(fid protocol.FID, o protocol.Offset, b []byte) (protocol.Count, error) {
 	log.Printf(">>> Twrite fid %v, off %v, count %v\n", fid, o, len(b))
 	c, err := e.FileServer.Rwrite(fid, o, b)
@@ -150,3 +166,14 @@ func (e *debugFileServer) Rwrite(fid protocol.FID, o protocol.Offset, b []byte)
 	}
 	return c, err
 }
+
+func (e *debugFileServer) RwriteFrom(fid protocol.FID, o protocol.Offset, b []byte) (protocol.Count, error) {
+	log.Printf(">>> Twrite fid %v, off %v, count %v\n", fid, o, len(b))
+	c, err := e.FileServer.RwriteFrom(fid, o, b)
+	if err == nil {
+		log.Printf("<<< Rwrite %v\n", c)
+	} else {
+		log.Printf("<<< Error %v\n", err)
+	}
+	return c, err
+}
@@ -0,0 +1,593 @@
+// Copyright 2018 The Ninep Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ufs
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"github.com/Harvey-OS/ninep/protocol"
+)
+
+// 9P2000.L is Linux's dialect of 9P, and lives very close to the Linux system
+// calls, so we only support it on Linux.
+
+const (
+	// Open file description locks. Unlike traditional POSIX locks, which
+	// belong to a process, these belong to an open file, so locks taken
+	// through different fids conflict with each other as they should, even
+	// though all of them are held by this one server process.
+	fOFDGetlk  = 36
+	fOFDSetlk  = 37
+	utimeNow   = (1 << 30) - 1
+	utimeOmit  = (1 << 30) - 2
+	dtUnknown  = 0
+	dtFifo     = 1
+	dtChr      = 2
+	dtDir      = 4
+	dtBlk      = 6
+	dtReg      = 8
+	dtLnk      = 10
+	dtSock     = 12
+	allowFlags = syscall.O_ACCMODE | syscall.O_TRUNC | syscall.O_APPEND | syscall.O_NONBLOCK |
+		syscall.O_DSYNC | syscall.O_SYNC | syscall.O_DIRECTORY | syscall.O_NOFOLLOW | syscall.O_NOATIME
+
+	// For utimensat, which syscall has no call for with flags.
+	atFdcwd           = -100
+	atSymlinkNofollow = 0x100
+)
+
+// lflags converts 9P2000.L open flags, which are those of Linux on x86, to
+// those of this machine. Flags we can't honor, or which only make sense in
+// the client, such as O_CREAT, are dropped.
+func lflags(l uint32) int {
+	var f int
+	for _, m := range []struct {
+		l uint32
+		f int
+	}{
+		{protocol.DotlWronly, syscall.O_WRONLY},
+		{protocol.DotlRdwr, syscall.O_RDWR},
+		{protocol.DotlExcl, syscall.O_EXCL},
+		{protocol.DotlTrunc, syscall.O_TRUNC},
+		{protocol.DotlAppend, syscall.O_APPEND},
+		{protocol.DotlNonblock, syscall.O_NONBLOCK},
+		{protocol.DotlDsync, syscall.O_DSYNC},
+		{protocol.DotlDirectory, syscall.O_DIRECTORY},
+		{protocol.DotlNofollow, syscall.O_NOFOLLOW},
+		{protocol.DotlNoatime, syscall.O_NOATIME},
+		{protocol.DotlSync, syscall.O_SYNC},
+	} {
+		if l&m.l == m.l {
+			f |= m.f
+		}
+	}
+	if l&3 == protocol.DotlNoaccess {
+		f &^= syscall.O_ACCMODE
+	}
+	return f & (allowFlags | syscall.O_EXCL)
+}
+
+// lname checks that name is a single path element, which is all 9P2000.L
+// allows, and returns its full name in the directory dir, which must not
+// be a symbolic link.
+func lname(dir string, name string) (string, error) {
+	return child(dir, name)
+}
+
+// lutimes sets the times of the file n, and not of what it links to.
+func lutimes(n string, ts []syscall.Timespec) error {
+	p, err := syscall.BytePtrFromString(n)
+	if err != nil {
+		return err
+	}
+	dirfd := atFdcwd
+	_, _, errno := syscall.Syscall6(syscall.SYS_UTIMENSAT, uintptr(dirfd), uintptr(unsafe.Pointer(p)),
+		uintptr(unsafe.Pointer(&ts[0])), atSymlinkNofollow, 0, 0)
+	if errno != 0 {
+		return &os.PathError{Op: "utimes", Path: n, Err: errno}
+	}
+	return nil
+}
+
+// lqid returns the QID of the file at n.
+func lqid(n string) (protocol.QID, error) {
+	st, err := os.Lstat(n)
+	if err != nil {
+		return protocol.QID{}, err
+	}
+	return fileInfoToQID(st), nil
+}
+
+// lchown gives a newly created file the group the client asked for. We
+// can only do that when running as root; otherwise it belongs to us.
+func lchown(n string, gid uint32) error {
+	if os.Geteuid() != 0 || gid == protocol.NOUID {
+		return nil
+	}
+	return os.Lchown(n, -1, int(gid))
+}
+
+func direntType(m os.FileMode) uint8 {
+	switch {
+	case m.IsDir():
+		return dtDir
+	case m.IsRegular():
+		return dtReg
+	case m&os.ModeSymlink != 0:
+		return dtLnk
+	case m&os.ModeNamedPipe != 0:
+		return dtFifo
+	case m&os.ModeSocket != 0:
+		return dtSock
+	case m&os.ModeCharDevice != 0:
+		return dtChr
+	case m&os.ModeDevice != 0:
+		return dtBlk
+	}
+	return dtUnknown
+}
+
+func (e *FileServer) Rstatfs(fid protocol.FID) (protocol.StatFS, error) {
+	f, err := e.getFile(fid)
+	if err != nil {
+		return protocol.StatFS{}, err
+	}
+	n := f.name()
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(n, &st); err != nil {
+		return protocol.StatFS{}, &os.PathError{Op: "statfs", Path: n, Err: err}
+	}
+	return protocol.StatFS{
+		Type:    uint32(st.Type),
+		BSize:   uint32(st.Bsize),
+		Blocks:  st.Blocks,
+		BFree:   st.Bfree,
+		BAvail:  st.Bavail,
+		Files:   st.Files,
+		FFree:   st.Ffree,
+		FSID:    uint64(uint32(st.Fsid.X__val[0])) | uint64(uint32(st.Fsid.X__val[1]))<<32,
+		NameLen: uint32(st.Namelen),
+	}, nil
+}
+
+func (e *FileServer) Rlopen(fid protocol.FID, flags uint32) (protocol.QID, protocol.MaxSize, error) {
+	f, err := e.getFile(fid)
+	if err != nil {
+		return protocol.QID{}, 0, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file != nil {
+		return protocol.QID{}, 0, fmt.Errorf("FID already open")
+	}
+	if err := nofollow(f.fullName); err != nil {
+		return protocol.QID{}, 0, err
+	}
+	f.file, err = os.OpenFile(f.fullName, lflags(flags)&^syscall.O_EXCL|syscall.O_NOFOLLOW, 0)
+	if err != nil {
+		return protocol.QID{}, 0, err
+	}
+	return f.QID, e.IOunit, nil
+}
+
+// Rlcreate creates and opens a file in the directory fid, which then
+// refers to the new file.
+func (e *FileServer) Rlcreate(fid protocol.FID, name string, flags uint32, mode uint32, gid uint32) (protocol.QID, protocol.MaxSize, error) {
+	f, err := e.getFile(fid)
+	if err != nil {
+		return protocol.QID{}, 0, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file != nil {
+		return protocol.QID{}, 0, fmt.Errorf("FID already open")
+	}
+	n, err := lname(f.fullName, name)
+	if err != nil {
+		return protocol.QID{}, 0, err
+	}
+	fd, err := syscall.Open(n, lflags(flags)|syscall.O_CREAT|syscall.O_NOFOLLOW|syscall.O_CLOEXEC, mode&07777)
+	if err != nil {
+		return protocol.QID{}, 0, &os.PathError{Op: "create", Path: n, Err: err}
+	}
+	of := os.NewFile(uintptr(fd), n)
+	if err := lchown(n, gid); err != nil {
+		of.Close()
+		return protocol.QID{}, 0, err
+	}
+	q, err := lqid(n)
+	if err != nil {
+		of.Close()
+		return protocol.QID{}, 0, err
+	}
+	f.fullName = n
+	f.QID = q
+	f.file = of
+	return q, e.IOunit, nil
+}
+
+func (e *FileServer) Rsymlink(fid protocol.FID, name string, target string, gid uint32) (protocol.QID, error) {
+	f, err := e.getFile(fid)
+	if err != nil {
+		return protocol.QID{}, err
+	}
+	n, err := lname(f.name(), name)
+	if err != nil {
+		return protocol.QID{}, err
+	}
+	if err := os.Symlink(target, n); err != nil {
+		return protocol.QID{}, err
+	}
+	if err := lchown(n, gid); err != nil {
+		return protocol.QID{}, err
+	}
+	return lqid(n)
+}
+
+func (e *FileServer) Rmknod(dfid protocol.FID, name string, mode uint32, major uint32, minor uint32, gid uint32) (protocol.QID, error) {
+	d, err := e.getFile(dfid)
+	if err != nil {
+		return protocol.QID{}, err
+	}
+	n, err := lname(d.name(), name)
+	if err != nil {
+		return protocol.QID{}, err
+	}
+	dev := uint64(major&0xfff)<<8 | uint64(major&^0xfff)<<32 | uint64(minor&0xff) | uint64(minor&^0xff)<<12
+	if err := syscall.Mknod(n, mode, int(dev)); err != nil {
+		return protocol.QID{}, &os.PathError{Op: "mknod", Path: n, Err: err}
+	}
+	if err := lchown(n, gid); err != nil {
+		return protocol.QID{}, err
+	}
+	return lqid(n)
+}
+
+// Rrename moves the file fid into the directory dfid, as name.
+func (e *FileServer) Rrename(fid protocol.FID, dfid protocol.FID, name string) error {
+	f, err := e.getFile(fid)
+	if err != nil {
+		return err
+	}
+	d, err := e.getFile(dfid)
+	if err != nil {
+		return err
+	}
+	n, err := lname(d.name(), name)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := os.Rename(f.fullName, n); err != nil {
+		return err
+	}
+	f.fullName = n
+	return nil
+}
+
+func (e *FileServer) Rreadlink(fid protocol.FID) (string, error) {
+	f, err := e.getFile(fid)
+	if err != nil {
+		return "", err
+	}
+	return os.Readlink(f.name())
+}
+
+func (e *FileServer) Rgetattr(fid protocol.FID, mask uint64) (protocol.Attr, error) {
+	f, err := e.getFile(fid)
+	if err != nil {
+		return protocol.Attr{}, err
+	}
+	fi, err := os.Lstat(f.name())
+	if err != nil {
+		return protocol.Attr{}, err
+	}
+	st := fi.Sys().(*syscall.Stat_t)
+	// Stat gives us everything in the basic set whether asked for or not,
+	// so we return it all.
+	return protocol.Attr{
+		Valid:     protocol.GetattrBasic,
+		QID:       fileInfoToQID(fi),
+		Mode:      st.Mode,
+		UID:       st.Uid,
+		GID:       st.Gid,
+		NLink:     uint64(st.Nlink),
+		RDev:      uint64(st.Rdev),
+		Size:      uint64(st.Size),
+		BlkSize:   uint64(st.Blksize),
+		Blocks:    uint64(st.Blocks),
+		ATimeSec:  uint64(st.Atim.Sec),
+		ATimeNSec: uint64(st.Atim.Nsec),
+		MTimeSec:  uint64(st.Mtim.Sec),
+		MTimeNSec: uint64(st.Mtim.Nsec),
+		CTimeSec:  uint64(st.Ctim.Sec),
+		CTimeNSec: uint64(st.Ctim.Nsec),
+	}, nil
+}
+
+func (e *FileServer) Rsetattr(fid protocol.FID, s protocol.SetAttr) error {
+	f, err := e.getFile(fid)
+	if err != nil {
+		return err
+	}
+	// Linux can't change the mode of a symbolic link, and we mustn't
+	// change that of what it links to.
+	n := f.name()
+	if s.Valid&protocol.SetattrMode != 0 {
+		if err := nofollow(n); err != nil {
+			return err
+		}
+		if err := syscall.Chmod(n, s.Mode&07777); err != nil {
+			return &os.PathError{Op: "chmod", Path: n, Err: err}
+		}
+	}
+	if s.Valid&(protocol.SetattrUID|protocol.SetattrGID) != 0 {
+		uid, gid := -1, -1
+		if s.Valid&protocol.SetattrUID != 0 {
+			uid = int(s.UID)
+		}
+		if s.Valid&protocol.SetattrGID != 0 {
+			gid = int(s.GID)
+		}
+		if err := os.Lchown(n, uid, gid); err != nil {
+			return err
+		}
+	}
+	if s.Valid&protocol.SetattrSize != 0 {
+		if err := truncate(n, int64(s.Size)); err != nil {
+			return err
+		}
+	}
+	if s.Valid&(protocol.SetattrAtime|protocol.SetattrMtime) != 0 {
+		ts := []syscall.Timespec{{Nsec: utimeOmit}, {Nsec: utimeOmit}}
+		if s.Valid&protocol.SetattrAtime != 0 {
+			ts[0].Nsec = utimeNow
+			if s.Valid&protocol.SetattrAtimeSet != 0 {
+				ts[0] = syscall.NsecToTimespec(time.Unix(int64(s.ATimeSec), int64(s.ATimeNSec)).UnixNano())
+			}
+		}
+		if s.Valid&protocol.SetattrMtime != 0 {
+			ts[1].Nsec = utimeNow
+			if s.Valid&protocol.SetattrMtimeSet != 0 {
+				ts[1] = syscall.NsecToTimespec(time.Unix(int64(s.MTimeSec), int64(s.MTimeNSec)).UnixNano())
+			}
+		}
+		if err := lutimes(n, ts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// We don't do extended attributes.
+func (e *FileServer) Rxattrwalk(fid protocol.FID, newfid protocol.FID, name string) (uint64, error) {
+	return 0, syscall.EOPNOTSUPP
+}
+
+func (e *FileServer) Rxattrcreate(fid protocol.FID, name string, size uint64, flags uint32) error {
+	return syscall.EOPNOTSUPP
+}
+
+// Rreaddir returns as many directory entries as fit in c bytes. The offset
+// of an entry is its index in the listing, which we take when the fid is
+// read from the start, as after a rewinddir, and keep, so that reading on
+// doesn't list the directory again; the offset in each entry is that of
+// the one after it. If c is too small for the entry at o, that is EINVAL,
+// not the end of the directory.
+func (e *FileServer) Rreaddir(fid protocol.FID, o protocol.Offset, c protocol.Count) ([]byte, error) {
+	f, err := e.getFile(fid)
+	if err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		return nil, fmt.Errorf("FID not open")
+	}
+	if f.dirents == nil || o == 0 {
+		f.dirents = nil
+		if err := resetDir(f); err != nil {
+			return nil, err
+		}
+		fis, err := f.file.Readdir(-1)
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		for _, n := range []string{".", ".."} {
+			fi, err := os.Lstat(path.Join(f.fullName, n))
+			if err != nil {
+				return nil, err
+			}
+			f.dirents = append(f.dirents, protocol.Dirent{QID: fileInfoToQID(fi), Type: dtDir, Name: n})
+		}
+		for _, fi := range fis {
+			f.dirents = append(f.dirents, protocol.Dirent{QID: fileInfoToQID(fi), Type: direntType(fi.Mode()), Name: fi.Name()})
+		}
+		for i := range f.dirents {
+			f.dirents[i].Offset = uint64(i + 1)
+		}
+	}
+	var b bytes.Buffer
+	for i := int(o); i >= 0 && i < len(f.dirents); i++ {
+		if b.Len()+protocol.DirentLen(f.dirents[i]) > int(c) {
+			break
+		}
+		protocol.MarshalDirent(&b, f.dirents[i])
+	}
+	if b.Len() == 0 && o < protocol.Offset(len(f.dirents)) {
+		return nil, syscall.EINVAL
+	}
+	return b.Bytes(), nil
+}
+
+func (e *FileServer) Rfsync(fid protocol.FID, datasync uint32) error {
+	f, err := e.getFile(fid)
+	if err != nil {
+		return err
+	}
+	of := f.opened()
+	if of == nil {
+		return syscall.EBADF
+	}
+	if datasync != 0 {
+		return syscall.Fdatasync(int(of.Fd()))
+	}
+	return of.Sync()
+}
+
+func lockType(t uint8) (int16, error) {
+	switch t {
+	case protocol.LockTypeRdlck:
+		return syscall.F_RDLCK, nil
+	case protocol.LockTypeWrlck:
+		return syscall.F_WRLCK, nil
+	case protocol.LockTypeUnlck:
+		return syscall.F_UNLCK, nil
+	}
+	return 0, syscall.EINVAL
+}
+
+// Rlock never blocks. The client polls instead when we say the lock is
+// blocked.
+func (e *FileServer) Rlock(fid protocol.FID, typ uint8, flags uint32, start uint64, length uint64, procid uint32, clientid string) (uint8, error) {
+	f, err := e.getFile(fid)
+	if err != nil {
+		return protocol.LockError, err
+	}
+	of := f.opened()
+	if of == nil {
+		return protocol.LockError, syscall.EBADF
+	}
+	t, err := lockType(typ)
+	if err != nil {
+		return protocol.LockError, err
+	}
+	l := syscall.Flock_t{Type: t, Whence: io.SeekStart, Start: int64(start), Len: int64(length)}
+	switch err := syscall.FcntlFlock(of.Fd(), fOFDSetlk, &l); err {
+	case nil:
+		return protocol.LockSuccess, nil
+	case syscall.EAGAIN, syscall.EACCES:
+		return protocol.LockBlocked, nil
+	default:
+		return protocol.LockError, err
+	}
+}
+
+func (e *FileServer) Rgetlock(fid protocol.FID, typ uint8, start uint64, length uint64, procid uint32, clientid string) (uint8, uint64, uint64, uint32, string, error) {
+	f, err := e.getFile(fid)
+	if err != nil {
+		return 0, 0, 0, 0, "", err
+	}
+	of := f.opened()
+	if of == nil {
+		return 0, 0, 0, 0, "", syscall.EBADF
+	}
+	t, err := lockType(typ)
+	if err != nil {
+		return 0, 0, 0, 0, "", err
+	}
+	l := syscall.Flock_t{Type: t, Whence: io.SeekStart, Start: int64(start), Len: int64(length)}
+	if err := syscall.FcntlFlock(of.Fd(), fOFDGetlk, &l); err != nil {
+		return 0, 0, 0, 0, "", err
+	}
+	switch l.Type {
+	case syscall.F_RDLCK:
+		typ = protocol.LockTypeRdlck
+	case syscall.F_WRLCK:
+		typ = protocol.LockTypeWrlck
+	default:
+		typ = protocol.LockTypeUnlck
+	}
+	// The owner of an OFD lock is the open file, not a process, so
+	// F_OFD_GETLK sets l.Pid to -1, and we have no client id to give.
+	var pid uint32
+	if l.Pid > 0 {
+		pid = uint32(l.Pid)
+	}
+	return typ, uint64(l.Start), uint64(l.Len), pid, "", nil
+}
+
+// Rlink makes name in the directory dfid a hard link to fid.
+func (e *FileServer) Rlink(dfid protocol.FID, fid protocol.FID, name string) error {
+	d, err := e.getFile(dfid)
+	if err != nil {
+		return err
+	}
+	f, err := e.getFile(fid)
+	if err != nil {
+		return err
+	}
+	n, err := lname(d.name(), name)
+	if err != nil {
+		return err
+	}
+	return os.Link(f.name(), n)
+}
+
+func (e *FileServer) Rmkdir(dfid protocol.FID, name string, mode uint32, gid uint32) (protocol.QID, error) {
+	d, err := e.getFile(dfid)
+	if err != nil {
+		return protocol.QID{}, err
+	}
+	n, err := lname(d.name(), name)
+	if err != nil {
+		return protocol.QID{}, err
+	}
+	if err := syscall.Mkdir(n, mode&07777); err != nil {
+		return protocol.QID{}, &os.PathError{Op: "mkdir", Path: n, Err: err}
+	}
+	if err := lchown(n, gid); err != nil {
+		return protocol.QID{}, err
+	}
+	return lqid(n)
+}
+
+func (e *FileServer) Rrenameat(olddfid protocol.FID, oldname string, newdfid protocol.FID, newname string) error {
+	od, err := e.getFile(olddfid)
+	if err != nil {
+		return err
+	}
+	nd, err := e.getFile(newdfid)
+	if err != nil {
+		return err
+	}
+	o, err := lname(od.name(), oldname)
+	if err != nil {
+		return err
+	}
+	n, err := lname(nd.name(), newname)
+	if err != nil {
+		return err
+	}
+	return os.Rename(o, n)
+}
+
+func (e *FileServer) Runlinkat(dfid protocol.FID, name string, flags uint32) error {
+	d, err := e.getFile(dfid)
+	if err != nil {
+		return err
+	}
+	n, err := lname(d.name(), name)
+	if err != nil {
+		return err
+	}
+	if flags&protocol.AtRemoveDir != 0 {
+		err = syscall.Rmdir(n)
+	} else {
+		err = syscall.Unlink(n)
+	}
+	if err != nil {
+		return &os.PathError{Op: "unlinkat", Path: n, Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,312 @@
+package ufs
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net"
+	"os"
+	"path"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/Harvey-OS/ninep/protocol"
+)
+
+func TestMountDotl(t *testing.T) {
+	tmpdir, err := ioutil.TempDir(os.TempDir(), "hi.dir")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer os.RemoveAll(tmpdir)
+
+	p, p2 := net.Pipe()
+
+	c, err := protocol.NewClient(func(c *protocol.Client) error {
+		c.FromNet, c.ToNet = p, p
+		c.Msize = 8192
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	n, err := NewUFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n.Accept(p2); err != nil {
+		t.Fatalf("Accept: want nil, got %v", err)
+	}
+
+	if _, v, err := c.CallTversion(8000, "9P2000.L"); err != nil || v != "9P2000.L" {
+		t.Fatalf("CallTversion: want 9P2000.L, nil, got %v, %v", v, err)
+	}
+	if _, err := c.CallTattachDotu(0, protocol.NOFID, "/", "", protocol.NOUID); err != nil {
+		t.Fatalf("CallTattachDotu: want nil, got %v", err)
+	}
+	if _, err := c.CallTwalk(0, 1, strings.Split(tmpdir, "/")); err != nil {
+		t.Fatalf("CallTwalk(0,1,%v): want nil, got %v", tmpdir, err)
+	}
+
+	// Tstat is not part of 9P2000.L.
+	if _, err := c.CallTstat(1); err != syscall.ENOSYS {
+		t.Errorf("CallTstat(1): want ENOSYS, got %v", err)
+	}
+
+	if _, err := c.CallTmkdir(1, "d", 0755, protocol.NOUID); err != nil {
+		t.Fatalf("CallTmkdir(1, \"d\", 0755, NOUID): want nil, got %v", err)
+	}
+	if _, err := c.CallTmkdir(1, "../d", 0755, protocol.NOUID); err != syscall.EINVAL {
+		t.Errorf("CallTmkdir(1, \"../d\", 0755, NOUID): want EINVAL, got %v", err)
+	}
+
+	if _, err := c.CallTwalk(1, 2, nil); err != nil {
+		t.Fatalf("CallTwalk(1,2,nil): want nil, got %v", err)
+	}
+	if _, _, err := c.CallTlcreate(2, "f", protocol.DotlRdwr, 0644, protocol.NOUID); err != nil {
+		t.Fatalf("CallTlcreate(2, \"f\", DotlRdwr, 0644, NOUID): want nil, got %v", err)
+	}
+	if _, _, err := c.CallTlcreate(1, "f", protocol.DotlRdwr|protocol.DotlExcl, 0644, protocol.NOUID); err != syscall.EEXIST {
+		t.Errorf("CallTlcreate of existing file with DotlExcl: want EEXIST, got %v", err)
+	}
+	if n, err := c.CallTwrite(2, 0, []byte("hello")); err != nil || n != 5 {
+		t.Fatalf("CallTwrite(2, 0, \"hello\"): want 5, nil, got %v, %v", n, err)
+	}
+	if err := c.CallTfsync(2, 0); err != nil {
+		t.Errorf("CallTfsync(2, 0): want nil, got %v", err)
+	}
+
+	a, err := c.CallTgetattr(2, protocol.GetattrBasic)
+	if err != nil {
+		t.Fatalf("CallTgetattr(2, GetattrBasic): want nil, got %v", err)
+	}
+	if a.Size != 5 || a.Mode&0777 != 0644 || a.Mode&syscall.S_IFMT != syscall.S_IFREG || a.UID != uint32(os.Getuid()) {
+		t.Errorf("CallTgetattr(2, GetattrBasic): want size 5, mode 0100644, uid %v, got %v, %#o, %v", os.Getuid(), a.Size, a.Mode, a.UID)
+	}
+
+	if err := c.CallTsetattr(2, protocol.SetAttr{Valid: protocol.SetattrSize | protocol.SetattrMtime | protocol.SetattrMtimeSet, Size: 2, MTimeSec: 1000000000, MTimeNSec: 5}); err != nil {
+		t.Fatalf("CallTsetattr(2, ...): want nil, got %v", err)
+	}
+	a, err = c.CallTgetattr(2, protocol.GetattrBasic)
+	if err != nil {
+		t.Fatalf("CallTgetattr(2, GetattrBasic): want nil, got %v", err)
+	}
+	if a.Size != 2 || a.MTimeSec != 1000000000 || a.MTimeNSec != 5 {
+		t.Errorf("CallTgetattr after setattr: want size 2, mtime 1000000000.5, got %v, %v.%v", a.Size, a.MTimeSec, a.MTimeNSec)
+	}
+
+	// Locks taken through different fids conflict.
+	if _, err := c.CallTwalk(1, 3, []string{"f"}); err != nil {
+		t.Fatalf("CallTwalk(1,3,f): want nil, got %v", err)
+	}
+	if _, _, err := c.CallTlopen(3, protocol.DotlRdwr); err != nil {
+		t.Fatalf("CallTlopen(3, DotlRdwr): want nil, got %v", err)
+	}
+	if s, err := c.CallTlock(2, protocol.LockTypeWrlck, 0, 0, 0, 1, "me"); err != nil || s != protocol.LockSuccess {
+		t.Errorf("CallTlock(2, Wrlck): want LockSuccess, nil, got %v, %v", s, err)
+	}
+	if s, err := c.CallTlock(3, protocol.LockTypeWrlck, 0, 0, 0, 2, "me"); err != nil || s != protocol.LockBlocked {
+		t.Errorf("CallTlock(3, Wrlck): want LockBlocked, nil, got %v, %v", s, err)
+	}
+	// The conflicting lock is fid 2's, so it isn't reported as ours.
+	if lt, _, _, pid, cid, err := c.CallTgetlock(3, protocol.LockTypeWrlck, 0, 0, 2, "me"); err != nil || lt != protocol.LockTypeWrlck || pid == 2 || cid != "" {
+		t.Errorf("CallTgetlock(3, Wrlck): want LockTypeWrlck, not procid 2, no client id, nil, got %v, %v, %q, %v", lt, pid, cid, err)
+	}
+	if s, err := c.CallTlock(2, protocol.LockTypeUnlck, 0, 0, 0, 1, "me"); err != nil || s != protocol.LockSuccess {
+		t.Errorf("CallTlock(2, Unlck): want LockSuccess, nil, got %v, %v", s, err)
+	}
+	if lt, _, _, _, _, err := c.CallTgetlock(3, protocol.LockTypeWrlck, 0, 0, 2, "me"); err != nil || lt != protocol.LockTypeUnlck {
+		t.Errorf("CallTgetlock(3, Wrlck) after unlock: want LockTypeUnlck, nil, got %v, %v", lt, err)
+	}
+
+	if _, err := c.CallTsymlink(1, "l", "f", protocol.NOUID); err != nil {
+		t.Fatalf("CallTsymlink(1, \"l\", \"f\", NOUID): want nil, got %v", err)
+	}
+	if _, err := c.CallTwalk(1, 4, []string{"l"}); err != nil {
+		t.Fatalf("CallTwalk(1,4,l): want nil, got %v", err)
+	}
+	if l, err := c.CallTreadlink(4); err != nil || l != "f" {
+		t.Errorf("CallTreadlink(4): want f, nil, got %v, %v", l, err)
+	}
+	if err := c.CallTrenameat(1, "l", 1, "m"); err != nil {
+		t.Fatalf("CallTrenameat(1, \"l\", 1, \"m\"): want nil, got %v", err)
+	}
+
+	if _, err := c.CallTwalk(1, 5, nil); err != nil {
+		t.Fatalf("CallTwalk(1,5,nil): want nil, got %v", err)
+	}
+	if _, _, err := c.CallTlopen(5, protocol.DotlRdonly|protocol.DotlDirectory); err != nil {
+		t.Fatalf("CallTlopen(5, DotlDirectory): want nil, got %v", err)
+	}
+	// Read the directory a little at a time, to check that the offsets
+	// work.
+	var names []string
+	var o protocol.Offset
+	for {
+		b, err := c.CallTreaddir(5, o, 32)
+		if err != nil {
+			t.Fatalf("CallTreaddir(5, %v, 32): want nil, got %v", o, err)
+		}
+		if len(b) == 0 {
+			break
+		}
+		for bb := bytes.NewBuffer(b); bb.Len() > 0; {
+			d, err := protocol.UnmarshalDirent(bb)
+			if err != nil {
+				t.Fatalf("UnmarshalDirent: want nil, got %v", err)
+			}
+			names = append(names, d.Name)
+			o = protocol.Offset(d.Offset)
+		}
+	}
+	want := map[string]bool{".": true, "..": true, "d": true, "f": true, "m": true}
+	if len(names) != len(want) {
+		t.Errorf("CallTreaddir: want %v entries, got %v", len(want), names)
+	}
+	for _, n := range names {
+		if !want[n] {
+			t.Errorf("CallTreaddir: unexpected entry %q in %v", n, names)
+		}
+	}
+
+	// The listing is taken again for a read from the start, so a file
+	// made since is in it.
+	if err := ioutil.WriteFile(path.Join(tmpdir, "g"), nil, 0644); err != nil {
+		t.Fatalf("%v", err)
+	}
+	b, err := c.CallTreaddir(5, 0, 4096)
+	if err != nil {
+		t.Fatalf("CallTreaddir(5, 0, 4096): want nil, got %v", err)
+	}
+	want["g"] = true
+	names = nil
+	for bb := bytes.NewBuffer(b); bb.Len() > 0; {
+		d, err := protocol.UnmarshalDirent(bb)
+		if err != nil {
+			t.Fatalf("UnmarshalDirent: want nil, got %v", err)
+		}
+		names = append(names, d.Name)
+	}
+	if len(names) != len(want) {
+		t.Errorf("CallTreaddir(5, 0, 4096) after creating g: want %v entries, got %v", len(want), names)
+	}
+	for _, n := range names {
+		if !want[n] {
+			t.Errorf("CallTreaddir(5, 0, 4096) after creating g: unexpected entry %q in %v", n, names)
+		}
+	}
+	if b, err := c.CallTreaddir(5, protocol.Offset(len(want)), 32); err != nil || len(b) != 0 {
+		t.Errorf("CallTreaddir(5, %v, 32) at the end: want no data, nil, got %v, %v", len(want), b, err)
+	}
+	// A count too small for an entry is not the end of the directory.
+	if _, err := c.CallTreaddir(5, 0, 1); err != syscall.EINVAL {
+		t.Errorf("CallTreaddir(5, 0, 1): want EINVAL, got %v", err)
+	}
+
+	if err := c.CallTunlinkat(1, "d", 0); err == nil {
+		t.Errorf("CallTunlinkat(1, \"d\", 0): want err, got nil")
+	}
+	if err := c.CallTunlinkat(1, "d", protocol.AtRemoveDir); err != nil {
+		t.Errorf("CallTunlinkat(1, \"d\", AtRemoveDir): want nil, got %v", err)
+	}
+	if err := c.CallTunlinkat(1, "m", 0); err != nil {
+		t.Errorf("CallTunlinkat(1, \"m\", 0): want nil, got %v", err)
+	}
+	if _, err := os.Lstat(path.Join(tmpdir, "m")); !os.IsNotExist(err) {
+		t.Errorf("Lstat of unlinked m: want IsNotExist, got %v", err)
+	}
+
+	if s, err := c.CallTstatfs(1); err != nil || s.BSize == 0 {
+		t.Errorf("CallTstatfs(1): want non-zero bsize, nil, got %v, %v", s, err)
+	}
+	if _, err := c.CallTgetattr(22, protocol.GetattrBasic); err == nil {
+		t.Errorf("CallTgetattr(22): want err, got nil")
+	}
+}
+
+// TestSymlinkEscape checks that clients can't get out of the root through
+// symbolic links they make.
+func TestSymlinkEscape(t *testing.T) {
+	tmpdir, err := ioutil.TempDir(os.TempDir(), "hi.dir")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer os.RemoveAll(tmpdir)
+	out := path.Join(tmpdir, "out")
+	if err := ioutil.WriteFile(out, []byte("secret"), 0644); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if err := os.Mkdir(path.Join(tmpdir, "root"), 0755); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	for _, v := range []string{protocol.Version9P2000u, protocol.Version9P2000L} {
+		e := &FileServer{rootPath: path.Join(tmpdir, "root"), files: make(map[protocol.FID]*file)}
+		if _, _, err := e.Rversion(8192, v); err != nil {
+			t.Fatalf("%v: Rversion: want nil, got %v", v, err)
+		}
+		if _, err := e.Rattach(0, protocol.NOFID, "", ""); err != nil {
+			t.Fatalf("%v: Rattach: want nil, got %v", v, err)
+		}
+		if _, err := e.Rwalk(0, 1, nil); err != nil {
+			t.Fatalf("%v: Rwalk to clone: want nil, got %v", v, err)
+		}
+		if v == protocol.Version9P2000L {
+			_, err = e.Rsymlink(1, "l", out, protocol.NOUID)
+		} else {
+			_, _, err = e.RcreateDotu(1, "l", protocol.DMSYMLINK|0777, 0, out)
+		}
+		if err != nil {
+			t.Fatalf("%v: symlink to %v: want nil, got %v", v, out, err)
+		}
+		if _, err := e.Rwalk(0, 2, []string{"l"}); err != nil {
+			t.Fatalf("%v: Rwalk to l: want nil, got %v", v, err)
+		}
+
+		if v == protocol.Version9P2000L {
+			_, _, err = e.Rlopen(2, protocol.DotlRdwr)
+		} else {
+			_, _, err = e.Ropen(2, protocol.ORDWR)
+		}
+		if err == nil {
+			t.Errorf("%v: open of l: want err, got nil", v)
+		}
+
+		if v == protocol.Version9P2000L {
+			err = e.Rsetattr(2, protocol.SetAttr{Valid: protocol.SetattrSize | protocol.SetattrMode, Size: 0, Mode: 0777})
+		} else {
+			d := protocol.NullDir()
+			d.Length = 0
+			var b bytes.Buffer
+			protocol.MarshaldirDotu(&b, d)
+			err = e.Rwstat(2, b.Bytes())
+		}
+		if err == nil {
+			t.Errorf("%v: truncate of l: want err, got nil", v)
+		}
+
+		// Nor can they walk through it.
+		if err := os.Symlink(tmpdir, path.Join(tmpdir, "root", "d")); err != nil {
+			t.Fatalf("%v", err)
+		}
+		if q, err := e.Rwalk(0, 3, []string{"d", "out"}); err == nil && len(q) == 2 {
+			t.Errorf("%v: Rwalk through d: want a partial walk, got %v", v, q)
+		}
+		if _, err := e.Rattach(4, protocol.NOFID, "", "d/out"); err == nil {
+			t.Errorf("%v: Rattach through d: want err, got nil", v)
+		}
+		os.Remove(path.Join(tmpdir, "root", "d"))
+		os.Remove(path.Join(tmpdir, "root", "l"))
+		e.Close()
+	}
+
+	b, err := ioutil.ReadFile(out)
+	if err != nil || string(b) != "secret" {
+		t.Errorf("ReadFile(%v): want secret, nil, got %q, %v", out, b, err)
+	}
+	st, err := os.Stat(out)
+	if err != nil || st.Mode()&0777 != 0644 {
+		t.Errorf("Stat(%v): want mode 0644, got %v, %v", out, st.Mode(), err)
+	}
+}
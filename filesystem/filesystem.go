@@ -9,35 +9,82 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"iter"
 	"log"
 	"os"
 	"path"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/Harvey-OS/ninep/protocol"
 )
 
 type file struct {
+	// mu guards below. The Server runs requests concurrently, and
+	// several of them may be for the same fid.
+	mu sync.Mutex
 	protocol.QID
 	fullName string
+	// root is the root of the tree the fid was attached to, which
+	// walks don't go above.
+	root string
 	file     *os.File
-	// We can't know how big a serialized dentry is until we serialize it.
-	// At that point it might be too big. We save it here if that happens,
-	// and on the next directory read we start with that.
-	oflow []byte
+	// dirs packs the directory's entries into reads.
+	dirs *protocol.DirReader
+	// dirents is the directory listing for 9P2000.L readdir, read
+	// when the fid is read from the start.
+	dirents []protocol.Dirent
 }
 
+// name returns the name of f on the host.
+func (f *file) name() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.fullName
+}
+
+// opened returns the host file f has open, if any.
+func (f *file) opened() *os.File {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file
+}
+
+// close closes what f has open.
+func (f *file) close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.dirs != nil {
+		f.dirs.Close()
+	}
+	// What do we do if we can't close it?
+	// All I can think of is to log it.
+	if f.file != nil {
+		if err := f.file.Close(); err != nil {
+			log.Printf("Close of %v failed: %v", f.fullName, err)
+		}
+	}
+}
+
+// dirBatch is how many entries are read from a host directory at a time.
+const dirBatch = 256
+
 type FileServer struct {
 	root      *file
 	rootPath  string
 	Versioned bool
 	IOunit    protocol.MaxSize
+	// auth is set when the protocol.Server authenticates users.
+	auth bool
 
 	// mu guards below
-	mu    sync.Mutex
-	files map[protocol.FID]*file
+	mu      sync.Mutex
+	files   map[protocol.FID]*file
+	version string
 }
 
 var (
@@ -49,7 +96,7 @@ func stat(s string) (*protocol.Dir, protocol.QID, error) {
 	var q protocol.QID
 	st, err := os.Lstat(s)
 	if err != nil {
-		return nil, q, fmt.Errorf("does not exist")
+		return nil, q, err
 	}
 	d, err := dirTo9p2000Dir(st)
 	if err != nil {
@@ -59,43 +106,232 @@ func stat(s string) (*protocol.Dir, protocol.QID, error) {
 	return d, q, nil
 }
 
+// nofollow returns ELOOP if n is a symbolic link. Clients can make
+// symbolic links to anything, so we never follow one: that could take
+// them out of the root.
+func nofollow(n string) error {
+	st, err := os.Lstat(n)
+	if err != nil {
+		return err
+	}
+	if st.Mode()&os.ModeSymlink != 0 {
+		return syscall.ELOOP
+	}
+	return nil
+}
+
+// lookup returns the name on the host of name in the directory dir. name
+// may have several elements, but it can't go above dir, or through a
+// symbolic link.
+func lookup(dir string, name string) (string, error) {
+	n := dir
+	for _, el := range strings.Split(path.Join("/", name), "/") {
+		if el == "" {
+			continue
+		}
+		if n != dir {
+			if err := nofollow(n); err != nil {
+				return "", err
+			}
+		}
+		n = path.Join(n, el)
+	}
+	return n, nil
+}
+
+// walk returns the name on the host of the file name in the directory dir.
+// name must be a single path element; an empty one is dir. The parent of
+// root is root, so that ".." doesn't take a client out of the tree it
+// attached to.
+func walk(dir string, name string, root string) (string, error) {
+	switch {
+	case strings.Contains(name, "/"):
+		return "", syscall.ENOENT
+	case name == "..":
+		if dir == root {
+			return dir, nil
+		}
+		return path.Dir(dir), nil
+	}
+	return path.Join(dir, name), nil
+}
+
+// child checks that name is a single path element, other than "." and
+// "..", and returns its full name in the directory dir, which must not be
+// a symbolic link.
+func child(dir string, name string) (string, error) {
+	if name == "" || name == "." || name == ".." || strings.Contains(name, "/") {
+		return "", syscall.EINVAL
+	}
+	if err := nofollow(dir); err != nil {
+		return "", err
+	}
+	return path.Join(dir, name), nil
+}
+
+// truncate truncates the file n, and not what it links to.
+func truncate(n string, size int64) error {
+	if err := nofollow(n); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(n, os.O_WRONLY|oNofollow|oNonblock, 0)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return f.Truncate(size)
+}
+
 func (e *FileServer) Rversion(msize protocol.MaxSize, version string) (protocol.MaxSize, string, error) {
-	if version != "9P2000" {
-		return 0, "", fmt.Errorf("%v not supported; only 9P2000", version)
+	switch version {
+	case protocol.Version9P2000, protocol.Version9P2000u:
+	case protocol.Version9P2000L:
+		// We only have 9P2000.L on some systems.
+		if _, ok := interface{}(e).(protocol.NineServerL); ok {
+			break
+		}
+		fallthrough
+	default:
+		return 0, "", fmt.Errorf("%v not supported", version)
 	}
+	e.mu.Lock()
+	e.version = version
+	e.mu.Unlock()
 	e.Versioned = true
 	return msize, version, nil
 }
 
+// NewSession returns a FileServer for a new connection. It serves the
+// same tree, but has its own fids, so that clients cannot use, or
+// clobber, each other's.
+func (e *FileServer) NewSession() (protocol.NineServer, error) {
+	return e.newSession(), nil
+}
+
+func (e *FileServer) newSession() *FileServer {
+	return &FileServer{
+		rootPath: e.rootPath,
+		IOunit:   e.IOunit,
+		auth:     e.auth,
+		files:    make(map[protocol.FID]*file),
+	}
+}
+
+// Close clunks all the fids when the connection goes away.
+func (e *FileServer) Close() error {
+	e.mu.Lock()
+	files := e.files
+	e.files = make(map[protocol.FID]*file)
+	e.mu.Unlock()
+	for _, f := range files {
+		f.close()
+	}
+	return nil
+}
+
+func (e *FileServer) isDotu() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.version == protocol.Version9P2000u
+}
+
+// dir returns the Dir for fi, which is found at fullName, for the
+// negotiated protocol.
+func (e *FileServer) dir(fullName string, fi os.FileInfo) (*protocol.Dir, error) {
+	if e.isDotu() {
+		return dirTo9p2000uDir(fullName, fi)
+	}
+	return dirTo9p2000Dir(fi)
+}
+
+// marshalDir marshals the Dir for fi, which is found at fullName, into b,
+// in the format of the negotiated protocol.
+func (e *FileServer) marshalDir(b *bytes.Buffer, fullName string, fi os.FileInfo) error {
+	d, err := e.dir(fullName, fi)
+	if err != nil {
+		return err
+	}
+	if e.isDotu() {
+		protocol.MarshaldirDotu(b, *d)
+	} else {
+		protocol.Marshaldir(b, *d)
+	}
+	return nil
+}
+
+// dirList lists the directory f, from the start, reading the host
+// directory in batches. It is used with f.mu held.
+func (e *FileServer) dirList(f *file) func() iter.Seq2[protocol.Dir, error] {
+	return func() iter.Seq2[protocol.Dir, error] {
+		return func(yield func(protocol.Dir, error) bool) {
+			if err := resetDir(f); err != nil {
+				yield(protocol.Dir{}, err)
+				return
+			}
+			for {
+				fis, err := f.file.Readdir(dirBatch)
+				for _, fi := range fis {
+					d, err := e.dir(path.Join(f.fullName, fi.Name()), fi)
+					if err != nil {
+						yield(protocol.Dir{}, err)
+						return
+					}
+					if !yield(*d, nil) {
+						return
+					}
+				}
+				if err == io.EOF || len(fis) == 0 {
+					return
+				}
+				if err != nil {
+					yield(protocol.Dir{}, err)
+					return
+				}
+			}
+		}
+	}
+}
+
 func (e *FileServer) getFile(fid protocol.FID) (*file, error) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 	f, ok := e.files[fid]
 	if !ok {
-		return nil, fmt.Errorf("does not exist")
+		return nil, syscall.EBADF
 	}
 
 	return f, nil
 }
 
 func (e *FileServer) Rattach(fid protocol.FID, afid protocol.FID, uname string, aname string) (protocol.QID, error) {
-	if afid != protocol.NOFID {
+	// If the server authenticates, it has checked the afid.
+	if afid != protocol.NOFID && !e.auth {
 		return protocol.QID{}, fmt.Errorf("We don't do auth attach")
 	}
 	// There should be no .. or other such junk in the Aname. Clean it up anyway.
-	aname = path.Join("/", aname)
-	aname = path.Join(e.rootPath, aname)
+	aname, err := lookup(e.rootPath, aname)
+	if err != nil {
+		return protocol.QID{}, err
+	}
 	st, err := os.Stat(aname)
 	if err != nil {
 		return protocol.QID{}, err
 	}
-	r := &file{fullName: aname}
+	r := &file{fullName: aname, root: aname}
 	r.QID = fileInfoToQID(st)
+	e.mu.Lock()
+	defer e.mu.Unlock()
 	e.files[fid] = r
 	e.root = r
 	return r.QID, nil
 }
 
+// RattachDotu is the 9P2000.u attach. We serve everyone as the same user,
+// so the numeric uname is not used.
+func (e *FileServer) RattachDotu(fid protocol.FID, afid protocol.FID, uname string, aname string, nuname uint32) (protocol.QID, error) {
+	return e.Rattach(fid, afid, uname, aname)
+}
+
 func (e *FileServer) Rflush(o protocol.Tag) error {
 	return nil
 }
@@ -105,8 +341,11 @@ func (e *FileServer) Rwalk(fid protocol.FID, newfid protocol.FID, paths []string
 	f, ok := e.files[fid]
 	e.mu.Unlock()
 	if !ok {
-		return nil, fmt.Errorf("does not exist")
+		return nil, syscall.EBADF
 	}
+	f.mu.Lock()
+	nf := &file{QID: f.QID, fullName: f.fullName, root: f.root}
+	f.mu.Unlock()
 	if len(paths) == 0 {
 		e.mu.Lock()
 		defer e.mu.Unlock()
@@ -114,16 +353,29 @@ func (e *FileServer) Rwalk(fid protocol.FID, newfid protocol.FID, paths []string
 		if ok {
 			return nil, fmt.Errorf("FID in use: clone walk, fid %d newfid %d", fid, newfid)
 		}
-		nf := *f
-		e.files[newfid] = &nf
+		e.files[newfid] = nf
 		return []protocol.QID{}, nil
 	}
-	p := f.fullName
+	// We don't walk through symbolic links. The client reads them, and
+	// walks to where they lead itself, so that it stays in the root.
+	p := nf.fullName
+	if err := nofollow(p); err != nil {
+		return nil, err
+	}
 	q := make([]protocol.QID, len(paths))
 
 	var i int
 	for i = range paths {
-		p = path.Join(p, paths[i])
+		if i > 0 && q[i-1].Type&protocol.QTSYMLINK != 0 {
+			return q[:i], nil
+		}
+		var err error
+		if p, err = walk(p, paths[i], nf.root); err != nil {
+			if i == 0 {
+				return nil, err
+			}
+			return q[:i], nil
+		}
 		st, err := os.Lstat(p)
 		if err != nil {
 			// From the RFC: If the first element cannot be walked for any
@@ -140,7 +392,7 @@ func (e *FileServer) Rwalk(fid protocol.FID, newfid protocol.FID, paths []string
 			// to sum up: if any walks have succeeded, you return the QIDS for
 			// one more than the last successful walk
 			if i == 0 {
-				return nil, fmt.Errorf("file does not exist")
+				return nil, os.ErrNotExist
 			}
 			// we only get here if i is > 0 and less than nwname,
 			// so the i should be safe.
@@ -156,7 +408,7 @@ func (e *FileServer) Rwalk(fid protocol.FID, newfid protocol.FID, paths []string
 			return nil, fmt.Errorf("FID in use: walk to %v, fid %v, newfid %v", paths, fid, newfid)
 		}
 	}
-	e.files[newfid] = &file{fullName: p, QID: q[i]}
+	e.files[newfid] = &file{fullName: p, QID: q[i], root: nf.root}
 	return q, nil
 }
 
@@ -165,11 +417,19 @@ func (e *FileServer) Ropen(fid protocol.FID, mode protocol.Mode) (protocol.QID,
 	f, ok := e.files[fid]
 	e.mu.Unlock()
 	if !ok {
-		return protocol.QID{}, 0, fmt.Errorf("does not exist")
+		return protocol.QID{}, 0, syscall.EBADF
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file != nil {
+		return protocol.QID{}, 0, fmt.Errorf("FID already open")
 	}
 
+	if err := nofollow(f.fullName); err != nil {
+		return protocol.QID{}, 0, err
+	}
 	var err error
-	f.file, err = os.OpenFile(f.fullName, modeToUnixFlags(mode), 0)
+	f.file, err = os.OpenFile(f.fullName, modeToUnixFlags(mode)|oNofollow, 0)
 	if err != nil {
 		return protocol.QID{}, 0, err
 	}
@@ -181,27 +441,34 @@ func (e *FileServer) Rcreate(fid protocol.FID, name string, perm protocol.Perm,
 	if err != nil {
 		return protocol.QID{}, 0, err
 	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
 	if f.file != nil {
 		return protocol.QID{}, 0, fmt.Errorf("FID already open")
 	}
-	n := path.Join(f.fullName, name)
+	n, err := child(f.fullName, name)
+	if err != nil {
+		return protocol.QID{}, 0, err
+	}
 	if perm&protocol.Perm(protocol.DMDIR) != 0 {
 		p := os.FileMode(int(perm) & 0777)
-		err := os.Mkdir(n, p)
+		if err := os.Mkdir(n, p); err != nil {
+			return protocol.QID{}, 0, err
+		}
 		_, q, err := stat(n)
 		if err != nil {
 			return protocol.QID{}, 0, err
 		}
-		f.file, err = os.Open(n)
+		f.file, err = os.OpenFile(n, os.O_RDONLY|oNofollow, 0)
 		if err != nil {
 			return protocol.QID{}, 0, err
 		}
 		f.fullName = n
 		f.QID = q
-		return q, 8000, err
+		return q, e.IOunit, nil
 	}
 
-	m := modeToUnixFlags(mode) | os.O_CREATE | os.O_TRUNC
+	m := modeToUnixFlags(mode) | os.O_CREATE | os.O_TRUNC | oNofollow
 	p := os.FileMode(perm) & 0777
 	of, err := os.OpenFile(n, m, p)
 	if err != nil {
@@ -214,8 +481,62 @@ func (e *FileServer) Rcreate(fid protocol.FID, name string, perm protocol.Perm,
 	f.fullName = n
 	f.QID = q
 	f.file = of
-	return q, 8000, err
+	return q, e.IOunit, nil
 }
+
+// RcreateDotu is the 9P2000.u create. It adds symbolic and hard links, whose
+// targets are given in the extension, to what Rcreate can do.
+func (e *FileServer) RcreateDotu(fid protocol.FID, name string, perm protocol.Perm, mode protocol.Mode, ext string) (protocol.QID, protocol.MaxSize, error) {
+	switch {
+	case perm&protocol.DMSYMLINK != 0, perm&protocol.DMLINK != 0:
+	case perm&(protocol.DMDEVICE|protocol.DMNAMEDPIPE|protocol.DMSOCKET) != 0:
+		return protocol.QID{}, 0, fmt.Errorf("Special files not supported")
+	default:
+		return e.Rcreate(fid, name, perm, mode)
+	}
+	f, err := e.getFile(fid)
+	if err != nil {
+		return protocol.QID{}, 0, err
+	}
+	// A hard link's extension is the fid of the file to link to. We
+	// find its name before locking f, which it may be.
+	var old string
+	if perm&protocol.DMLINK != 0 {
+		ofid, err := strconv.ParseUint(strings.TrimSpace(ext), 10, 32)
+		if err != nil {
+			return protocol.QID{}, 0, fmt.Errorf("Bad link fid %q", ext)
+		}
+		of, err := e.getFile(protocol.FID(ofid))
+		if err != nil {
+			return protocol.QID{}, 0, err
+		}
+		old = of.name()
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file != nil {
+		return protocol.QID{}, 0, fmt.Errorf("FID already open")
+	}
+	n, err := child(f.fullName, name)
+	if err != nil {
+		return protocol.QID{}, 0, err
+	}
+	if perm&protocol.DMSYMLINK != 0 {
+		if err := os.Symlink(ext, n); err != nil {
+			return protocol.QID{}, 0, err
+		}
+	} else if err := os.Link(old, n); err != nil {
+		return protocol.QID{}, 0, err
+	}
+	_, q, err := stat(n)
+	if err != nil {
+		return protocol.QID{}, 0, err
+	}
+	f.fullName = n
+	f.QID = q
+	return q, 0, nil
+}
+
 func (e *FileServer) Rclunk(fid protocol.FID) error {
 	_, err := e.clunk(fid)
 	return err
@@ -226,16 +547,15 @@ func (e *FileServer) Rstat(fid protocol.FID) ([]byte, error) {
 	if err != nil {
 		return []byte{}, err
 	}
-	st, err := os.Lstat(f.fullName)
+	n := f.name()
+	st, err := os.Lstat(n)
 	if err != nil {
-		return []byte{}, fmt.Errorf("ENOENT")
+		return []byte{}, err
 	}
-	d, err := dirTo9p2000Dir(st)
-	if err != nil {
+	var b bytes.Buffer
+	if err := e.marshalDir(&b, n, st); err != nil {
 		return []byte{}, nil
 	}
-	var b bytes.Buffer
-	protocol.Marshaldir(&b, *d)
 	return b.Bytes(), nil
 }
 func (e *FileServer) Rwstat(fid protocol.FID, b []byte) error {
@@ -244,13 +564,24 @@ func (e *FileServer) Rwstat(fid protocol.FID, b []byte) error {
 	if err != nil {
 		return err
 	}
-	dir, err := protocol.Unmarshaldir(bytes.NewBuffer(b))
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var dir protocol.Dir
+	dotu := e.isDotu()
+	if dotu {
+		dir, err = protocol.UnmarshaldirDotu(bytes.NewBuffer(b))
+	} else {
+		dir, err = protocol.Unmarshaldir(bytes.NewBuffer(b))
+	}
 	if err != nil {
 		return err
 	}
 	if dir.Mode != 0xFFFFFFFF {
 		changed = true
 		mode := dir.Mode & 0777
+		if err := nofollow(f.fullName); err != nil {
+			return err
+		}
 		if err := os.Chmod(f.fullName, os.FileMode(mode)); err != nil {
 			return err
 		}
@@ -258,34 +589,41 @@ func (e *FileServer) Rwstat(fid protocol.FID, b []byte) error {
 
 	// Try to find local uid, gid by name.
 	if dir.User != "" || dir.Group != "" {
-		return fmt.Errorf("Permission denied")
-		changed = true
+		return os.ErrPermission
 	}
 
-	/*
-		if uid != ninep.NOUID || gid != ninep.NOUID {
-			changed = true
-			e := os.Chown(fid.path, int(uid), int(gid))
-			if e != nil {
-				req.RespondError(toError(e))
-				return
-			}
+	// 9P2000.u gives us numeric ids, which we can use directly.
+	if dotu && (dir.UID != protocol.NOUID || dir.GID != protocol.NOUID) {
+		changed = true
+		uid, gid := -1, -1
+		if dir.UID != protocol.NOUID {
+			uid = int(dir.UID)
+		}
+		if dir.GID != protocol.NOUID {
+			gid = int(dir.GID)
+		}
+		if err := os.Lchown(f.fullName, uid, gid); err != nil {
+			return err
 		}
-	*/
+	}
 
 	if dir.Name != "" {
 		changed = true
-		// If we path.Join dir.Name to / before adding it to
-		// the fid path, that ensures nobody gets to walk out of the
-		// root of this server.
-		newname := path.Join(path.Dir(f.fullName), path.Join("/", dir.Name))
+		// lookup joins dir.Name to / before adding it to the fid
+		// path, and doesn't follow symbolic links, which ensures
+		// nobody gets to walk out of the root of this server.
+		from := path.Dir(f.fullName)
 
 		// absolute renaming. Ufs can do this, so let's support it.
 		// We'll allow an absolute path in the Name and, if it is,
 		// we will make it relative to root. This is a gigantic performance
 		// improvement in systems that allow it.
 		if filepath.IsAbs(dir.Name) {
-			newname = path.Join(e.rootPath, dir.Name)
+			from = e.rootPath
+		}
+		newname, err := lookup(from, dir.Name)
+		if err != nil {
+			return err
 		}
 
 		// If to exists, and to is a directory, we can't do the
@@ -293,7 +631,7 @@ func (e *FileServer) Rwstat(fid protocol.FID, b []byte) error {
 
 		st, err := os.Stat(newname)
 		if err == nil && st.IsDir() {
-			return fmt.Errorf("is a directory")
+			return syscall.EISDIR
 		}
 		if err := os.Rename(f.fullName, newname); err != nil {
 			return err
@@ -303,7 +641,7 @@ func (e *FileServer) Rwstat(fid protocol.FID, b []byte) error {
 
 	if dir.Length != 0xFFFFFFFFFFFFFFFF {
 		changed = true
-		if err := os.Truncate(f.fullName, int64(dir.Length)); err != nil {
+		if err := truncate(f.fullName, int64(dir.Length)); err != nil {
 			return err
 		}
 	}
@@ -312,6 +650,9 @@ func (e *FileServer) Rwstat(fid protocol.FID, b []byte) error {
 	// we must change both.
 	if dir.Mtime != ^uint32(0) || dir.Atime != ^uint32(0) {
 		changed = true
+		if err := nofollow(f.fullName); err != nil {
+			return err
+		}
 		mt, at := time.Unix(int64(dir.Mtime), 0), time.Unix(int64(dir.Atime), 0)
 		if cmt, cat := (dir.Mtime == ^uint32(0)), (dir.Atime == ^uint32(0)); cmt || cat {
 			st, err := os.Stat(f.fullName)
@@ -338,19 +679,13 @@ func (e *FileServer) Rwstat(fid protocol.FID, b []byte) error {
 
 func (e *FileServer) clunk(fid protocol.FID) (*file, error) {
 	e.mu.Lock()
-	defer e.mu.Unlock()
 	f, ok := e.files[fid]
-	if !ok {
-		return nil, fmt.Errorf("does not exist")
-	}
 	delete(e.files, fid)
-	// What do we do if we can't close it?
-	// All I can think of is to log it.
-	if f.file != nil {
-		if err := f.file.Close(); err != nil {
-			log.Printf("Close of %v failed: %v", f.fullName, err)
-		}
+	e.mu.Unlock()
+	if !ok {
+		return nil, syscall.EBADF
 	}
+	f.close()
 	return f, nil
 }
 
@@ -361,76 +696,65 @@ func (e *FileServer) Rremove(fid protocol.FID) error {
 	if err != nil {
 		return err
 	}
-	return os.Remove(f.fullName)
+	return os.Remove(f.name())
 }
 
 func (e *FileServer) Rread(fid protocol.FID, o protocol.Offset, c protocol.Count) ([]byte, error) {
-	f, err := e.getFile(fid)
+	b := make([]byte, c)
+	n, err := e.RreadInto(fid, o, b)
 	if err != nil {
 		return nil, err
 	}
-	if f.file == nil {
-		return nil, fmt.Errorf("FID not open")
+	return b[:n], nil
+}
+
+// RreadInto reads into b, which the Server makes the data of the Rread,
+// so a file is read with no copying.
+func (e *FileServer) RreadInto(fid protocol.FID, o protocol.Offset, b []byte) (int, error) {
+	f, err := e.getFile(fid)
+	if err != nil {
+		return 0, err
+	}
+	f.mu.Lock()
+	of := f.file
+	if of == nil {
+		f.mu.Unlock()
+		return 0, fmt.Errorf("FID not open")
 	}
 	if f.QID.Type&protocol.QTDIR != 0 {
-		if o == 0 {
-			f.oflow = nil
-			if err := resetDir(f); err != nil {
-				return nil, err
-			}
-		}
-
-		// We make the assumption that they can always fit at least one
-		// directory entry into a read. If that assumption does not hold
-		// so many things are broken that we can't fix them here.
-		// But we'll drop out of the loop below having returned nothing
-		// anyway.
-		b := bytes.NewBuffer(f.oflow)
-		f.oflow = nil
-		pos := 0
-
-		for {
-			if b.Len() > int(c) {
-				f.oflow = b.Bytes()[pos:]
-				return b.Bytes()[:pos], nil
-			}
-			pos += b.Len()
-			st, err := f.file.Readdir(1)
-			if err == io.EOF {
-				return b.Bytes(), nil
-			}
-			if err != nil {
-				return nil, err
-			}
-
-			d9p, err := dirTo9p2000Dir(st[0])
-			if err != nil {
-				return nil, err
-			}
-			protocol.Marshaldir(b, *d9p)
-			// We're not quite doing the array right.
-			// What does work is returning one thing so, for now, do that.
-			return b.Bytes(), nil
+		// Directory reads carry on from the last one, so they are
+		// done one at a time.
+		defer f.mu.Unlock()
+		if f.dirs == nil {
+			f.dirs = protocol.NewDirReader(e.dirList(f), e.isDotu())
 		}
-		return b.Bytes(), nil
+		d, err := f.dirs.Read(o, protocol.Count(len(b)))
+		return copy(b, d), err
 	}
+	f.mu.Unlock()
 
 	// N.B. even if they ask for 0 bytes on some file systems it is important to pass
 	// through a zero byte read (not Unix, of course).
-	b := make([]byte, c)
-	n, err := f.file.ReadAt(b, int64(o))
+	n, err := of.ReadAt(b, int64(o))
 	if err != nil && err != io.EOF {
-		return nil, err
+		return 0, err
 	}
-	return b[:n], nil
+	return n, nil
 }
 
 func (e *FileServer) Rwrite(fid protocol.FID, o protocol.Offset, b []byte) (protocol.Count, error) {
+	return e.RwriteFrom(fid, o, b)
+}
+
+// RwriteFrom writes b, which is in the frame the Twrite was read into, so
+// a file is written with no copying. b is not kept.
+func (e *FileServer) RwriteFrom(fid protocol.FID, o protocol.Offset, b []byte) (protocol.Count, error) {
 	f, err := e.getFile(fid)
 	if err != nil {
 		return -1, err
 	}
-	if f.file == nil {
+	of := f.opened()
+	if of == nil {
 		return -1, fmt.Errorf("FID not open")
 	}
 
@@ -438,7 +762,7 @@ func (e *FileServer) Rwrite(fid protocol.FID, o protocol.Offset, b []byte) (prot
 	// through a zero byte write (not Unix, of course). Also, let the underlying file system
 	// manage the error if the open mode was wrong. No need to duplicate the logic.
 
-	n, err := f.file.WriteAt(b, int64(o))
+	n, err := of.WriteAt(b, int64(o))
 	return protocol.Count(n), err
 }
 
@@ -458,5 +782,6 @@ func NewUFS(opts ...protocol.ServerOpt) (*protocol.Server, error) {
 		return nil, err
 	}
 	f.IOunit = 8192
+	f.auth = s.Auth != nil
 	return s, nil
 }
@@ -2,13 +2,21 @@ package ufs
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"io/ioutil"
 	"net"
 	"os"
 	"path"
+	"reflect"
 	"strings"
+	"sync"
+	"syscall"
 	"testing"
+	"testing/fstest"
+	"testing/iotest"
 
 	"github.com/Harvey-OS/ninep/protocol"
 )
@@ -25,6 +33,86 @@ func TestNew(t *testing.T) {
 	t.Logf("n is %v", n)
 }
 
+// TestSameFid makes several requests for one fid at once, as the Server
+// does when they come in together. Run it with -race.
+func TestSameFid(t *testing.T) {
+	tmpdir, err := ioutil.TempDir(os.TempDir(), "hi.dir")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer os.RemoveAll(tmpdir)
+	for i := 0; i < 50; i++ {
+		if err := ioutil.WriteFile(path.Join(tmpdir, fmt.Sprintf("file%03d", i)), []byte("hi"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	e := &FileServer{rootPath: "/", files: make(map[protocol.FID]*file)}
+	if _, _, err := e.Rversion(8192, protocol.Version9P2000); err != nil {
+		t.Fatalf("Rversion: want nil, got %v", err)
+	}
+	if _, err := e.Rattach(0, protocol.NOFID, "", tmpdir); err != nil {
+		t.Fatalf("Rattach: want nil, got %v", err)
+	}
+	if _, err := e.Rwalk(0, 1, nil); err != nil {
+		t.Fatalf("Rwalk to clone: want nil, got %v", err)
+	}
+	if _, err := e.Rwalk(0, 2, []string{"file000"}); err != nil {
+		t.Fatalf("Rwalk to file000: want nil, got %v", err)
+	}
+	for _, fid := range []protocol.FID{1, 2} {
+		if _, _, err := e.Ropen(fid, protocol.OREAD); err != nil {
+			t.Fatalf("Ropen(%v): want nil, got %v", fid, err)
+		}
+		defer e.Rclunk(fid)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				if _, err := e.Rread(1, 0, 1000); err != nil {
+					t.Errorf("Rread of the directory: want nil, got %v", err)
+				}
+			}
+		}()
+		go func(newfid protocol.FID) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if _, err := e.Rread(2, 0, 2); err != nil {
+					t.Errorf("Rread of the file: want nil, got %v", err)
+				}
+				if _, err := e.Rstat(2); err != nil {
+					t.Errorf("Rstat: want nil, got %v", err)
+				}
+				if _, err := e.Rwalk(2, newfid, nil); err != nil {
+					t.Errorf("Rwalk to clone: want nil, got %v", err)
+				}
+				if err := e.Rclunk(newfid); err != nil {
+					t.Errorf("Rclunk: want nil, got %v", err)
+				}
+			}
+		}(protocol.FID(10 + i))
+	}
+	var b bytes.Buffer
+	for j := 0; j < 100; j++ {
+		// Only the name changes.
+		d := protocol.Dir{Mode: ^uint32(0), Atime: ^uint32(0), Mtime: ^uint32(0), Length: ^uint64(0)}
+		d.Name = fmt.Sprintf("renamed%d", j)
+		b.Reset()
+		protocol.Marshaldir(&b, d)
+		if err := e.Rwstat(2, b.Bytes()); err != nil {
+			t.Errorf("Rwstat to %v: want nil, got %v", d.Name, err)
+		}
+	}
+	wg.Wait()
+	if n := e.files[2].fullName; n != path.Join(tmpdir, "renamed99") {
+		t.Errorf("Renamed file: want %v, got %v", path.Join(tmpdir, "renamed99"), n)
+	}
+}
+
 // a simple prototype file system.
 type makeit struct {
 	n string      // name
@@ -50,6 +138,110 @@ var tests = []makeit{
 	},
 }
 
+// TestWalkRoot checks that walks stay in the tree a client attached to.
+func TestWalkRoot(t *testing.T) {
+	tmpdir, err := ioutil.TempDir(os.TempDir(), "hi.dir")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer os.RemoveAll(tmpdir)
+	if err := ioutil.WriteFile(path.Join(tmpdir, "out"), []byte("secret"), 0644); err != nil {
+		t.Fatalf("%v", err)
+	}
+	r := path.Join(tmpdir, "root")
+	if err := os.MkdirAll(path.Join(r, "d"), 0755); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	e := &FileServer{rootPath: r, files: make(map[protocol.FID]*file)}
+	if _, _, err := e.Rversion(8192, protocol.Version9P2000); err != nil {
+		t.Fatalf("Rversion: want nil, got %v", err)
+	}
+	if _, err := e.Rattach(0, protocol.NOFID, "", ""); err != nil {
+		t.Fatalf("Rattach: want nil, got %v", err)
+	}
+
+	// The parent of the root is the root.
+	for _, tt := range []struct {
+		fid   protocol.FID
+		paths []string
+	}{
+		{1, []string{"..", "..", ".."}},
+		{2, []string{"d", "..", "..", ".."}},
+	} {
+		if _, err := e.Rwalk(0, tt.fid, tt.paths); err != nil {
+			t.Fatalf("Rwalk(0, %v, %q): want nil, got %v", tt.fid, tt.paths, err)
+		}
+		f, err := e.getFile(tt.fid)
+		if err != nil {
+			t.Fatalf("getFile(%v): want nil, got %v", tt.fid, err)
+		}
+		if n := f.name(); n != r {
+			t.Errorf("Rwalk(0, %v, %q): want %v, got %v", tt.fid, tt.paths, r, n)
+		}
+	}
+
+	// Nothing above it can be reached.
+	for _, tt := range []struct {
+		fid   protocol.FID
+		paths []string
+	}{
+		{3, []string{"..", "out"}},
+		{4, []string{"../out"}},
+		{5, []string{"d", "../../out"}},
+	} {
+		if q, err := e.Rwalk(0, tt.fid, tt.paths); err == nil && len(q) == len(tt.paths) {
+			t.Errorf("Rwalk(0, %v, %q): want err or a partial walk, got %v", tt.fid, tt.paths, q)
+		}
+		if _, err := e.getFile(tt.fid); err == nil {
+			t.Errorf("Rwalk(0, %v, %q): want no fid %v, got one", tt.fid, tt.paths, tt.fid)
+		}
+	}
+
+	// Nor created in.
+	if _, _, err := e.Rcreate(1, "../made", 0644, protocol.ORDWR); err == nil {
+		t.Errorf("Rcreate(1, \"../made\"): want err, got nil")
+	}
+	if _, err := os.Stat(path.Join(tmpdir, "made")); !os.IsNotExist(err) {
+		t.Errorf("Stat of made: want IsNotExist, got %v", err)
+	}
+}
+
+func TestCreate(t *testing.T) {
+	tmpdir, err := ioutil.TempDir(os.TempDir(), "hi.dir")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer os.RemoveAll(tmpdir)
+	if err := ioutil.WriteFile(path.Join(tmpdir, "f"), nil, 0644); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	e := &FileServer{rootPath: tmpdir, IOunit: 4096, files: make(map[protocol.FID]*file)}
+	if _, _, err := e.Rversion(8192, protocol.Version9P2000); err != nil {
+		t.Fatalf("Rversion: want nil, got %v", err)
+	}
+	if _, err := e.Rattach(0, protocol.NOFID, "", ""); err != nil {
+		t.Fatalf("Rattach: want nil, got %v", err)
+	}
+	for fid, perm := range map[protocol.FID]protocol.Perm{1: 0644, 2: protocol.DMDIR | 0755} {
+		if _, err := e.Rwalk(0, fid, nil); err != nil {
+			t.Fatalf("Rwalk(0, %v, nil): want nil, got %v", fid, err)
+		}
+		if _, iounit, err := e.Rcreate(fid, fmt.Sprint("new", fid), perm, protocol.OREAD); err != nil || iounit != e.IOunit {
+			t.Errorf("Rcreate(%v, perm %#o): want iounit %v, nil, got %v, %v", fid, perm, e.IOunit, iounit, err)
+		}
+	}
+
+	// A directory can't be made where there is a file.
+	if _, err := e.Rwalk(0, 3, nil); err != nil {
+		t.Fatalf("Rwalk(0, 3, nil): want nil, got %v", err)
+	}
+	if _, _, err := e.Rcreate(3, "f", protocol.DMDIR|0755, protocol.OREAD); !errors.Is(err, fs.ErrExist) {
+		t.Errorf("Rcreate(3, \"f\", DMDIR): want ErrExist, got %v", err)
+	}
+}
+
 func TestMount(t *testing.T) {
 	/* Create the simple file system. */
 	tmpdir, err := ioutil.TempDir(os.TempDir(), "hi.dir")
@@ -315,3 +507,612 @@ func TestMount(t *testing.T) {
 		t.Fatalf("After remove(%v); stat returns nil, not err", yyy)
 	}
 }
+
+func TestMountDotu(t *testing.T) {
+	tmpdir, err := ioutil.TempDir(os.TempDir(), "hi.dir")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer os.RemoveAll(tmpdir)
+
+	p, p2 := net.Pipe()
+
+	c, err := protocol.NewClient(func(c *protocol.Client) error {
+		c.FromNet, c.ToNet = p, p
+		c.Msize = 8192
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	n, err := NewUFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n.Accept(p2); err != nil {
+		t.Fatalf("Accept: want nil, got %v", err)
+	}
+
+	if _, v, err := c.CallTversion(8000, "9P2000.u"); err != nil || v != "9P2000.u" {
+		t.Fatalf("CallTversion: want 9P2000.u, nil, got %v, %v", v, err)
+	}
+	if _, err := c.CallTattachDotu(0, protocol.NOFID, "/", "", protocol.NOUID); err != nil {
+		t.Fatalf("CallTattachDotu: want nil, got %v", err)
+	}
+	if _, err := c.CallTwalk(0, 1, strings.Split(tmpdir, "/")); err != nil {
+		t.Fatalf("CallTwalk(0,1,%v): want nil, got %v", tmpdir, err)
+	}
+	if _, _, err := c.CallTcreateDotu(1, "link", protocol.DMSYMLINK|0777, 0, "target"); err != nil {
+		t.Fatalf("CallTcreateDotu(1, \"link\", DMSYMLINK, 0, \"target\"): want nil, got %v", err)
+	}
+	if l, err := os.Readlink(path.Join(tmpdir, "link")); err != nil || l != "target" {
+		t.Fatalf("Readlink: want target, nil, got %v, %v", l, err)
+	}
+
+	b, err := c.CallTstat(1)
+	if err != nil {
+		t.Fatalf("CallTstat(1): want nil, got %v", err)
+	}
+	d, err := protocol.UnmarshaldirDotu(bytes.NewBuffer(b))
+	if err != nil {
+		t.Fatalf("UnmarshaldirDotu: want nil, got %v", err)
+	}
+	if d.Mode&protocol.DMSYMLINK == 0 || d.Extension != "target" {
+		t.Errorf("Stat of symlink: want DMSYMLINK and extension target, got %#x and %q", d.Mode, d.Extension)
+	}
+	if d.UID != uint32(os.Getuid()) {
+		t.Errorf("Stat of symlink: want uid %v, got %v", os.Getuid(), d.UID)
+	}
+
+	if _, err := c.CallTstat(22); err == nil {
+		t.Fatalf("CallTstat(22): want err, got nil")
+	}
+}
+
+func TestSessions(t *testing.T) {
+	tmpdir, err := ioutil.TempDir(os.TempDir(), "hi.dir")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer os.RemoveAll(tmpdir)
+	if err := os.Mkdir(path.Join(tmpdir, "d"), 0755); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	n, err := NewUFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Each client uses the same fids, and speaks its own version.
+	var cs []*protocol.Client
+	for _, v := range []string{"9P2000", "9P2000.u"} {
+		p, p2 := net.Pipe()
+		c, err := protocol.NewClient(func(c *protocol.Client) error {
+			c.FromNet, c.ToNet = p, p
+			c.Msize = 8192
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		if err := n.Accept(p2); err != nil {
+			t.Fatalf("Accept: want nil, got %v", err)
+		}
+		if _, rv, err := c.CallTversion(8000, v); err != nil || rv != v {
+			t.Fatalf("CallTversion: want %v, nil, got %v, %v", v, rv, err)
+		}
+		if _, err := c.CallTattach(0, protocol.NOFID, "/", ""); err != nil {
+			t.Fatalf("CallTattach: want nil, got %v", err)
+		}
+		cs = append(cs, c)
+	}
+
+	if _, err := cs[0].CallTwalk(0, 1, strings.Split(tmpdir, "/")); err != nil {
+		t.Fatalf("CallTwalk(0,1,%v): want nil, got %v", tmpdir, err)
+	}
+	if _, err := cs[1].CallTwalk(0, 1, append(strings.Split(tmpdir, "/"), "d")); err != nil {
+		t.Fatalf("CallTwalk(0,1,%v/d): want nil, got %v", tmpdir, err)
+	}
+	if err := cs[1].CallTclunk(1); err != nil {
+		t.Fatalf("CallTclunk(1): want nil, got %v", err)
+	}
+
+	b, err := cs[0].CallTstat(1)
+	if err != nil {
+		t.Fatalf("CallTstat(1): want nil, got %v", err)
+	}
+	d, err := protocol.Unmarshaldir(bytes.NewBuffer(b))
+	if err != nil {
+		t.Fatalf("Unmarshaldir: want nil, got %v", err)
+	}
+	if d.Name != path.Base(tmpdir) {
+		t.Errorf("CallTstat(1): want %v, got %v", path.Base(tmpdir), d.Name)
+	}
+	if _, err := cs[1].CallTstat(1); err == nil {
+		t.Errorf("CallTstat(1) after clunk: want err, got nil")
+	}
+	b, err = cs[1].CallTstat(0)
+	if err != nil {
+		t.Fatalf("CallTstat(0): want nil, got %v", err)
+	}
+	if _, err := protocol.UnmarshaldirDotu(bytes.NewBuffer(b)); err != nil {
+		t.Errorf("UnmarshaldirDotu: want nil, got %v", err)
+	}
+}
+
+// newTestClient returns a client of a new ufs, which has negotiated
+// version with msize, and its attach to the root.
+func newTestClient(t *testing.T, msize protocol.MaxSize, version string) (*protocol.Client, *protocol.File) {
+	t.Helper()
+	p, p2 := net.Pipe()
+	c, err := protocol.NewClient(func(c *protocol.Client) error {
+		c.FromNet, c.ToNet = p, p
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	n, err := NewUFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n.Accept(p2); err != nil {
+		t.Fatalf("Accept: want nil, got %v", err)
+	}
+	if _, _, err := c.CallTversion(msize, version); err != nil {
+		t.Fatalf("%v: CallTversion: want nil, got %v", version, err)
+	}
+	root, err := c.Attach(protocol.NOFID, "/", "")
+	if err != nil {
+		t.Fatalf("%v: Attach: want nil, got %v", version, err)
+	}
+	return c, root
+}
+
+func TestFile(t *testing.T) {
+	tmpdir, err := ioutil.TempDir(os.TempDir(), "hi.dir")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer os.RemoveAll(tmpdir)
+	// Deeper than one Twalk can go.
+	deep := strings.Repeat("d/", protocol.MAXWELEM+2)
+	if err := os.MkdirAll(path.Join(tmpdir, deep), 0755); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	c, root := newTestClient(t, 1024, protocol.Version9P2000)
+	defer c.Close()
+	dir, err := root.Walk(tmpdir)
+	if err != nil {
+		t.Fatalf("Walk(%v): want nil, got %v", tmpdir, err)
+	}
+	if d, err := dir.Walk(deep); err != nil || d.QID().Type&protocol.QTDIR == 0 {
+		t.Errorf("Walk(%v): want a directory, nil, got %v, %v", deep, d, err)
+	} else {
+		d.Close()
+	}
+	if _, err := dir.Walk("d/nope"); !os.IsNotExist(err) {
+		t.Errorf("Walk(d/nope): want IsNotExist, got %v", err)
+	}
+
+	f, err := dir.Create("f", 0644, protocol.ORDWR)
+	if err != nil {
+		t.Fatalf("Create(f): want nil, got %v", err)
+	}
+	// Bigger than the msize, so it takes several messages.
+	data := bytes.Repeat([]byte("0123456789"), 500)
+	if n, err := f.Write(data); err != nil || n != len(data) {
+		t.Fatalf("Write: want %d, nil, got %d, %v", len(data), n, err)
+	}
+	if o, err := f.Seek(-10, io.SeekEnd); err != nil || o != int64(len(data)-10) {
+		t.Fatalf("Seek(-10, SeekEnd): want %d, nil, got %d, %v", len(data)-10, o, err)
+	}
+	b, err := ioutil.ReadAll(f)
+	if err != nil || string(b) != "0123456789" {
+		t.Errorf("ReadAll at end: want 0123456789, nil, got %q, %v", b, err)
+	}
+	b = make([]byte, len(data)-5)
+	if n, err := f.ReadAt(b, 5); err != nil || n != len(b) || !bytes.Equal(b, data[5:]) {
+		t.Errorf("ReadAt(%d, 5): want %d, nil, got %d, %v", len(b), len(b), n, err)
+	}
+	if n, err := f.ReadAt(b, 10); err != io.EOF || n != len(b)-5 {
+		t.Errorf("ReadAt past the end: want %d, EOF, got %d, %v", len(b)-5, n, err)
+	}
+
+	d, err := f.Stat()
+	if err != nil || d.Length != uint64(len(data)) || d.Name != "f" {
+		t.Errorf("Stat: want f with length %d, nil, got %v, %v", len(data), d, err)
+	}
+	// Only the mode changes.
+	nd := protocol.NullDir()
+	nd.Mode = 0600
+	if err := f.Wstat(nd); err != nil {
+		t.Errorf("Wstat: want nil, got %v", err)
+	}
+	if fi, err := os.Stat(path.Join(tmpdir, "f")); err != nil || fi.Size() != int64(len(data)) || fi.Mode() != 0600 {
+		t.Errorf("Stat after Wstat: want size %d, mode 0600, got %v, %v", len(data), fi, err)
+	}
+	if err := f.Close(); err != nil {
+		t.Errorf("Close: want nil, got %v", err)
+	}
+	if _, err := f.Stat(); err == nil {
+		t.Errorf("Stat after Close: want err, got nil")
+	}
+
+	f, err = dir.Open("f", protocol.OREAD)
+	if err != nil {
+		t.Fatalf("Open(f): want nil, got %v", err)
+	}
+	if b, err := ioutil.ReadAll(f); err != nil || !bytes.Equal(b, data) {
+		t.Errorf("ReadAll: want %d bytes, nil, got %d, %v", len(data), len(b), err)
+	}
+	if err := f.Remove(); err != nil {
+		t.Errorf("Remove: want nil, got %v", err)
+	}
+	if _, err := os.Stat(path.Join(tmpdir, "f")); !os.IsNotExist(err) {
+		t.Errorf("Stat of removed f: want IsNotExist, got %v", err)
+	}
+}
+
+func TestFS(t *testing.T) {
+	tmpdir, err := ioutil.TempDir(os.TempDir(), "hi.dir")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer os.RemoveAll(tmpdir)
+	files := map[string]string{
+		"a":         "hello",
+		"b/c":       "",
+		"b/d/e":     strings.Repeat("e", 3000),
+		"b/d/f.txt": "f",
+	}
+	for n, s := range files {
+		p := path.Join(tmpdir, n)
+		if err := os.MkdirAll(path.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(s), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < 20; i++ {
+		if err := ioutil.WriteFile(path.Join(tmpdir, "b", fmt.Sprintf("g%d", i)), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c, root := newTestClient(t, 1024, protocol.Version9P2000)
+	defer c.Close()
+	dir, err := root.Walk(tmpdir)
+	if err != nil {
+		t.Fatalf("Walk(%v): want nil, got %v", tmpdir, err)
+	}
+
+	fsys := protocol.NewFS(dir)
+	var want []string
+	for n := range files {
+		want = append(want, n)
+	}
+	if err := fstest.TestFS(fsys, want...); err != nil {
+		t.Error(err)
+	}
+	if b, err := fs.ReadFile(fsys, "b/d/e"); err != nil || string(b) != files["b/d/e"] {
+		t.Errorf("ReadFile(b/d/e): want %d bytes, nil, got %d, %v", len(files["b/d/e"]), len(b), err)
+	}
+	if _, err := fsys.Open("../a"); err == nil {
+		t.Errorf("Open(../a): want err, got nil")
+	}
+}
+
+func TestFileBig(t *testing.T) {
+	tmpdir, err := ioutil.TempDir(os.TempDir(), "hi.dir")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer os.RemoveAll(tmpdir)
+
+	c, root := newTestClient(t, 4096, protocol.Version9P2000)
+	defer c.Close()
+	dir, err := root.Walk(tmpdir)
+	if err != nil {
+		t.Fatalf("Walk(%v): want nil, got %v", tmpdir, err)
+	}
+	f, err := dir.Create("f", 0644, protocol.ORDWR)
+	if err != nil {
+		t.Fatalf("Create(f): want nil, got %v", err)
+	}
+
+	// Many msizes' worth, from a Reader which returns a bit at a time.
+	data := make([]byte, 100000)
+	for i := range data {
+		data[i] = byte(i * 7)
+	}
+	if n, err := f.WriteFrom(iotest.HalfReader(bytes.NewReader(data))); err != nil || n != int64(len(data)) {
+		t.Fatalf("WriteFrom: want %d, nil, got %d, %v", len(data), n, err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		t.Fatalf("Seek: %v", err)
+	}
+	if b, err := f.ReadAll(); err != nil || !bytes.Equal(b, data) {
+		t.Errorf("ReadAll: want %d bytes, nil, got %d, %v", len(data), len(b), err)
+	}
+
+	// A failure part way reports what was done.
+	boom := fmt.Errorf("boom")
+	if n, err := f.WriteFrom(io.MultiReader(bytes.NewReader(data[:5000]), iotest.ErrReader(boom))); err != boom || n != 5000 {
+		t.Errorf("WriteFrom of failing Reader: want 5000, boom, got %d, %v", n, err)
+	}
+	if fi, err := os.Stat(path.Join(tmpdir, "f")); err != nil || fi.Size() != int64(len(data)+5000) {
+		t.Errorf("Stat: want size %d, got %v, %v", len(data)+5000, fi.Size(), err)
+	}
+}
+
+func TestStream(t *testing.T) {
+	tmpdir, err := ioutil.TempDir(os.TempDir(), "hi.dir")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer os.RemoveAll(tmpdir)
+
+	c, root := newTestClient(t, 4096, protocol.Version9P2000)
+	defer c.Close()
+	dir, err := root.Walk(tmpdir)
+	if err != nil {
+		t.Fatalf("Walk(%v): want nil, got %v", tmpdir, err)
+	}
+	f, err := dir.Create("f", 0644, protocol.ORDWR)
+	if err != nil {
+		t.Fatalf("Create(f): want nil, got %v", err)
+	}
+
+	data := make([]byte, 100000)
+	for i := range data {
+		data[i] = byte(i * 7)
+	}
+	w := f.NewWriter(0, 4)
+	if n, err := io.CopyBuffer(w, bytes.NewReader(data), make([]byte, 1000)); err != nil || n != int64(len(data)) {
+		t.Fatalf("Copy to Writer: want %d, nil, got %d, %v", len(data), n, err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Writer Close: want nil, got %v", err)
+	}
+	if b, err := ioutil.ReadFile(path.Join(tmpdir, "f")); err != nil || !bytes.Equal(b, data) {
+		t.Fatalf("ReadFile: want %d bytes, nil, got %d, %v", len(data), len(b), err)
+	}
+	if _, err := w.Write(data); err == nil {
+		t.Errorf("Write after Close: want error, got nil")
+	}
+
+	for _, off := range []int64{0, 12345} {
+		for _, window := range []int{0, 1, 3} {
+			r := f.NewReader(off, window)
+			b, err := ioutil.ReadAll(r)
+			if err != nil || !bytes.Equal(b, data[off:]) {
+				t.Errorf("ReadAll(NewReader(%d, %d)): want %d bytes, nil, got %d, %v", off, window, len(data[off:]), len(b), err)
+			}
+			r.Close()
+		}
+	}
+
+	// An error stops the Reader, and sticks.
+	if err := f.Close(); err != nil {
+		t.Fatalf("Close: want nil, got %v", err)
+	}
+	r := f.NewReader(0, 4)
+	if _, err := r.Read(make([]byte, 10)); err == nil {
+		t.Errorf("Read of closed File: want error, got nil")
+	}
+	if _, err := r.Read(make([]byte, 10)); err == nil {
+		t.Errorf("second Read of closed File: want error, got nil")
+	}
+	w = f.NewWriter(0, 4)
+	w.Write(data)
+	if err := w.Close(); err == nil || !strings.Contains(err.Error(), "Twrite at 0") {
+		t.Errorf("Writer Close for closed File: want error for offset 0, got %v", err)
+	}
+}
+
+func TestReaddir(t *testing.T) {
+	tmpdir, err := ioutil.TempDir(os.TempDir(), "hi.dir")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer os.RemoveAll(tmpdir)
+	want := map[string]bool{}
+	for i := 0; i < 50; i++ {
+		n := fmt.Sprintf("file%02d", i)
+		if err := ioutil.WriteFile(path.Join(tmpdir, n), []byte(n), 0644); err != nil {
+			t.Fatal(err)
+		}
+		want[n] = true
+	}
+
+	c, root := newTestClient(t, 4096, protocol.Version9P2000)
+	defer c.Close()
+	dir, err := root.Open(tmpdir, protocol.OREAD)
+	if err != nil {
+		t.Fatalf("Open(%v): want nil, got %v", tmpdir, err)
+	}
+
+	ds, err := dir.Readdir()
+	if err != nil {
+		t.Fatalf("Readdir: want nil, got %v", err)
+	}
+	got := map[string]bool{}
+	for _, d := range ds {
+		got[d.Name] = true
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Readdir: want %v, got %v", want, got)
+	}
+	if ds, err := dir.Readdir(); err != nil || len(ds) != 0 {
+		t.Errorf("Readdir at the end: want no entries, nil, got %v, %v", ds, err)
+	}
+
+	// The iterator can stop early, and a Seek to 0 starts again.
+	if _, err := dir.Seek(0, io.SeekStart); err != nil {
+		t.Fatalf("Seek: %v", err)
+	}
+	var i int
+	for d, err := range dir.Dirs() {
+		if err != nil {
+			t.Fatalf("Dirs: want nil, got %v", err)
+		}
+		if !want[d.Name] {
+			t.Errorf("Dirs: unexpected %v", d.Name)
+		}
+		if i++; i == 3 {
+			break
+		}
+	}
+	if i != 3 {
+		t.Errorf("Dirs: want 3 entries before break, got %d", i)
+	}
+	if _, err := dir.Seek(0, io.SeekStart); err != nil {
+		t.Fatalf("Seek: %v", err)
+	}
+	i = 0
+	for _, err := range dir.Dirs() {
+		if err != nil {
+			t.Fatalf("Dirs: want nil, got %v", err)
+		}
+		i++
+	}
+	if i != len(want) {
+		t.Errorf("Dirs: want %d entries, got %d", len(want), i)
+	}
+
+	// A failed Tread ends the iteration with its error.
+	if err := dir.Close(); err != nil {
+		t.Fatalf("Close: want nil, got %v", err)
+	}
+	if _, err := dir.Readdir(); err == nil {
+		t.Errorf("Readdir of closed File: want error, got nil")
+	}
+}
+
+func TestDirRead(t *testing.T) {
+	tmpdir, err := ioutil.TempDir(os.TempDir(), "hi.dir")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer os.RemoveAll(tmpdir)
+	const nfiles = 600
+	for i := 0; i < nfiles; i++ {
+		if err := ioutil.WriteFile(path.Join(tmpdir, fmt.Sprintf("file%03d", i)), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c, root := newTestClient(t, 65536, protocol.Version9P2000)
+	defer c.Close()
+	dir, err := root.Open(tmpdir, protocol.OREAD)
+	if err != nil {
+		t.Fatalf("Open(%v): want nil, got %v", tmpdir, err)
+	}
+
+	// Each read holds as many whole entries as fit.
+	const count = 1000
+	var (
+		o     protocol.Offset
+		reads int
+		names = map[string]bool{}
+	)
+	for {
+		b, err := c.CallTread(dir.FID(), o, count)
+		if err != nil {
+			t.Fatalf("CallTread(%v, %v, %v): want nil, got %v", dir.FID(), o, count, err)
+		}
+		if len(b) == 0 {
+			break
+		}
+		if len(b) > count {
+			t.Fatalf("CallTread: want at most %d bytes, got %d", count, len(b))
+		}
+		ds, err := protocol.Unmarshaldirs(b)
+		if err != nil {
+			t.Fatalf("Unmarshaldirs: want nil, got %v", err)
+		}
+		if len(ds) < 2 {
+			t.Errorf("CallTread at %v: want several entries, got %d", o, len(ds))
+		}
+		for _, d := range ds {
+			names[d.Name] = true
+		}
+		o += protocol.Offset(len(b))
+		reads++
+	}
+	if len(names) != nfiles {
+		t.Errorf("Directory reads: want %d entries, got %d", nfiles, len(names))
+	}
+	t.Logf("%d entries in %d reads", len(names), reads)
+
+	// Reads must start at 0 or where the last ended.
+	b, err := c.CallTread(dir.FID(), 0, count)
+	if err != nil || len(b) == 0 {
+		t.Fatalf("CallTread at 0 again: want data, nil, got %d bytes, %v", len(b), err)
+	}
+	if _, err := c.CallTread(dir.FID(), protocol.Offset(len(b)+1), count); err == nil {
+		t.Errorf("CallTread at a bad offset: want error, got nil")
+	}
+	if _, err := c.CallTread(dir.FID(), protocol.Offset(len(b)), count); err != nil {
+		t.Errorf("CallTread at the end of the last read: want nil, got %v", err)
+	}
+	ds, err := dir.Readdir()
+	if err != nil || len(ds) != nfiles {
+		t.Errorf("Readdir: want %d entries, nil, got %d, %v", nfiles, len(ds), err)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	for _, v := range []string{protocol.Version9P2000, protocol.Version9P2000u} {
+		c, root := newTestClient(t, 8192, v)
+		defer c.Close()
+
+		// Errors come across the wire as Plan 9 messages, and can be
+		// matched as the server's Go errors were.
+		_, err := root.Walk("nonexistent-file")
+		if !errors.Is(err, fs.ErrNotExist) || !errors.Is(err, syscall.ENOENT) {
+			t.Errorf("%v: Walk(nonexistent-file): want ErrNotExist, got %v", v, err)
+		}
+		var pe *protocol.Error
+		if !errors.As(err, &pe) || pe.Err != "file does not exist" {
+			t.Errorf("%v: Walk(nonexistent-file): want *protocol.Error \"file does not exist\", got %#v", v, err)
+		}
+		if _, err := c.CallTstat(1234); !errors.Is(err, syscall.EBADF) {
+			t.Errorf("%v: CallTstat of unknown fid: want EBADF, got %v", v, err)
+		}
+
+		// So are the errors of the host, which say more, but 9P2000
+		// only has room for the Plan 9 message.
+		tmpdir, err := ioutil.TempDir(os.TempDir(), "hi.dir")
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		defer os.RemoveAll(tmpdir)
+		if err := ioutil.WriteFile(path.Join(tmpdir, "gone"), []byte("hi"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		f, err := root.Walk(path.Join(tmpdir, "gone"))
+		if err != nil {
+			t.Fatalf("%v: Walk(%v): want nil, got %v", v, path.Join(tmpdir, "gone"), err)
+		}
+		if err := os.Remove(path.Join(tmpdir, "gone")); err != nil {
+			t.Fatal(err)
+		}
+		_, err = f.Stat()
+		if !errors.Is(err, fs.ErrNotExist) || !errors.Is(err, syscall.ENOENT) {
+			t.Errorf("%v: Stat of removed file: want ErrNotExist, got %v", v, err)
+		}
+		want := "file does not exist"
+		if v == protocol.Version9P2000u {
+			want = fmt.Sprintf("lstat %v: no such file or directory", path.Join(tmpdir, "gone"))
+		}
+		if !errors.As(err, &pe) || pe.Err != want {
+			t.Errorf("%v: Stat of removed file: want *protocol.Error %q, got %#v", v, want, err)
+		}
+	}
+}
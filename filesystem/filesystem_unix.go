@@ -8,7 +8,17 @@
 
 package ufs
 
-import "io"
+import (
+	"io"
+	"syscall"
+)
+
+const (
+	// oNofollow makes an open fail if the file is a symbolic link.
+	oNofollow = syscall.O_NOFOLLOW
+	// oNonblock keeps an open of a FIFO from waiting for the other end.
+	oNonblock = syscall.O_NONBLOCK
+)
 
 // resetDir seeks to the beginning of the file so that the file list can be
 // read again.
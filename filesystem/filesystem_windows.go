@@ -10,6 +10,13 @@ package ufs
 
 import "os"
 
+// Windows has no such open flags. nofollow checks for symbolic links
+// instead.
+const (
+	oNofollow = 0
+	oNonblock = 0
+)
+
 // resetDir closes the underlying file and reopens it so it can be read again.
 // This is because Windows doesn't seem to support calling Seek on a directory
 // handle.
@@ -57,25 +57,32 @@ func dirToQIDType(d os.FileInfo) uint8 {
 	return ret
 }
 
-func dirTo9p2000Mode(d os.FileInfo) uint32 {
-	ret := uint32(d.Mode() & 0777)
-	if d.IsDir() {
-		ret |= protocol.DMDIR
-	}
-	return ret
-}
-
 func dirTo9p2000Dir(fi os.FileInfo) (*protocol.Dir, error) {
-	d := &protocol.Dir{}
+	// TODO: use atime on systems that have it.
+	d := protocol.DirFromFileInfo(fi)
 	d.QID = fileInfoToQID(fi)
-	d.Mode = dirTo9p2000Mode(fi)
-	// TODO: use info on systems that have it.
-	d.Atime = uint32(fi.ModTime().Unix()) // uint32(atime(sysMode).Unix())
-	d.Mtime = uint32(fi.ModTime().Unix())
-	d.Length = uint64(fi.Size())
-	d.Name = fi.Name()
+	// 9P2000 has no special files.
+	d.Mode &= protocol.DMDIR | 0777
 	d.User = *user
 	d.Group = *user
 
+	return &d, nil
+}
+
+// dirTo9p2000uDir is like dirTo9p2000Dir but fills in the 9P2000.u fields.
+// fullName is needed to read the target of symlinks.
+func dirTo9p2000uDir(fullName string, fi os.FileInfo) (*protocol.Dir, error) {
+	d, err := dirTo9p2000Dir(fi)
+	if err != nil {
+		return nil, err
+	}
+	d.Mode = protocol.DirMode(fi.Mode())
+	if fi.Mode()&os.ModeSymlink != 0 {
+		if d.Extension, err = os.Readlink(fullName); err != nil {
+			return nil, err
+		}
+	}
+	d.UID, d.GID = fileInfoToIDs(fi)
+	d.MUID = protocol.NOUID
 	return d, nil
 }
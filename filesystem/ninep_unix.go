@@ -32,3 +32,10 @@ func fileInfoToQID(d os.FileInfo) protocol.QID {
 
 	return qid
 }
+
+func fileInfoToIDs(d os.FileInfo) (uint32, uint32) {
+	if stat, ok := d.Sys().(*syscall.Stat_t); ok {
+		return stat.Uid, stat.Gid
+	}
+	return protocol.NOUID, protocol.NOUID
+}
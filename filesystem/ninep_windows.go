@@ -23,3 +23,7 @@ func fileInfoToQID(d os.FileInfo) protocol.QID {
 
 	return qid
 }
+
+func fileInfoToIDs(d os.FileInfo) (uint32, uint32) {
+	return protocol.NOUID, protocol.NOUID
+}
@@ -0,0 +1,364 @@
+// Copyright 2018 The Ninep Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protocol
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// An Authenticator authenticates the users of a Server. A client starts
+// authentication with a Tauth, for which the Authenticator starts an
+// AuthConv. The client then reads and writes the new auth fid, and the
+// Server passes those on to the AuthConv. A Tattach using the auth fid
+// succeeds only once the AuthConv has authenticated the user it names.
+type Authenticator interface {
+	Start(uname, aname string) (AuthConv, error)
+}
+
+// An AuthConv is one run of an authentication protocol, as seen from the
+// server.
+type AuthConv interface {
+	io.Reader
+	io.Writer
+	// Authenticated returns nil once the client has proved who it is.
+	Authenticated() error
+}
+
+// authState is an auth fid, and the user and tree it authenticates for.
+type authState struct {
+	uname  string
+	aname  string
+	nuname uint32
+	conv   AuthConv
+}
+
+// SrvRauth and SrvRauthDotu are written by hand, not generated, as auth
+// fids belong to the Server, not the NineServer.
+func (s *Server) SrvRauth(ctx context.Context, b *bytes.Buffer) error {
+	AFID, Uname, Aname, t, err := UnmarshalTauthPkt(b)
+	if err != nil {
+		return err
+	}
+	s.auth(ctx, b, t, AFID, Uname, Aname, NOUID)
+	return nil
+}
+
+func (s *Server) SrvRauthDotu(ctx context.Context, b *bytes.Buffer) error {
+	AFID, Uname, Aname, NUname, t, err := UnmarshalTauthDotuPkt(b)
+	if err != nil {
+		return err
+	}
+	s.auth(ctx, b, t, AFID, Uname, Aname, NUname)
+	return nil
+}
+
+func (s *Server) auth(ctx context.Context, b *bytes.Buffer, t Tag, afid FID, uname, aname string, nuname uint32) {
+	if s.Auth == nil {
+		s.rerror(ctx, b, t, fmt.Errorf("authentication not required"))
+		return
+	}
+	conv, err := s.Auth.Start(uname, aname)
+	if err != nil {
+		s.rerror(ctx, b, t, err)
+		return
+	}
+	ss := s.session(ctx)
+	ss.mu.Lock()
+	_, inUse := ss.afids[afid]
+	if _, ok := ss.fids[afid]; ok || inUse || afid == NOFID {
+		ss.mu.Unlock()
+		s.rerror(ctx, b, t, fmt.Errorf("FID in use: auth fid %v", afid))
+		return
+	}
+	if ss.afids == nil {
+		ss.afids = make(map[FID]*authState)
+	}
+	ss.afids[afid] = &authState{uname: uname, aname: aname, nuname: nuname, conv: conv}
+	ss.mu.Unlock()
+	MarshalRauthPkt(b, t, QID{Type: QTAUTH, Path: uint64(afid)})
+}
+
+// authFID returns the auth fid which the T-message in b uses, or nil if
+// it uses another kind of fid.
+func (s *Server) authFID(ctx context.Context, b *bytes.Buffer, mt MType) (FID, *authState) {
+	switch mt {
+	case Tread, Twrite, Tclunk, Tremove:
+	default:
+		return 0, nil
+	}
+	// Skip the tag.
+	p := b.Bytes()
+	if len(p) < 6 {
+		return 0, nil
+	}
+	fid := FID(p[2]) | FID(p[3])<<8 | FID(p[4])<<16 | FID(p[5])<<24
+	ss := s.session(ctx)
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	return fid, ss.afids[fid]
+}
+
+// A newFID is a fid which a Tattach, Twalk or Txattrwalk makes if it
+// succeeds. A Twalk makes it only if it walks all of its names. The zero
+// newFID is none.
+type newFID struct {
+	mt     MType
+	fid    FID
+	nwname int
+}
+
+// checkFID checks the fid the T-message in b makes, which may not be an
+// auth fid, and returns it. A Tclunk or Tremove ends the NineServer's use
+// of its fid.
+func (s *Server) checkFID(ctx context.Context, b *bytes.Buffer, mt MType) (newFID, error) {
+	// Skip the tag.
+	p := b.Bytes()
+	if len(p) < 6 {
+		return newFID{}, nil
+	}
+	fid := FID(p[2]) | FID(p[3])<<8 | FID(p[4])<<16 | FID(p[5])<<24
+	nf := newFID{mt: mt, fid: fid}
+	switch mt {
+	case Tattach:
+	case Twalk, Txattrwalk:
+		if len(p) < 12 {
+			return newFID{}, nil
+		}
+		nf.fid = FID(p[6]) | FID(p[7])<<8 | FID(p[8])<<16 | FID(p[9])<<24
+		nf.nwname = int(p[10]) | int(p[11])<<8
+		if nf.fid == fid {
+			// The fid is walked in place, and was not an auth fid.
+			return newFID{}, nil
+		}
+	case Tclunk, Tremove:
+		ss := s.session(ctx)
+		ss.mu.Lock()
+		delete(ss.fids, fid)
+		ss.mu.Unlock()
+		return newFID{}, nil
+	default:
+		return newFID{}, nil
+	}
+	ss := s.session(ctx)
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	if _, ok := ss.afids[nf.fid]; ok {
+		return newFID{}, fmt.Errorf("FID in use: %v is an auth fid", nf.fid)
+	}
+	return nf, nil
+}
+
+// madeFID notes that the NineServer has the fid nf if the reply in b says
+// it made it.
+func (s *Server) madeFID(ctx context.Context, b *bytes.Buffer, nf newFID) {
+	p := b.Bytes()
+	if len(p) < 7 || MType(p[4]) != nf.mt+1 {
+		return
+	}
+	if nf.mt == Twalk && (len(p) < 9 || int(p[7])|int(p[8])<<8 != nf.nwname) {
+		return
+	}
+	ss := s.session(ctx)
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	if ss.fids == nil {
+		ss.fids = make(map[FID]struct{})
+	}
+	ss.fids[nf.fid] = struct{}{}
+}
+
+// srvAuthFID serves reads and writes, which carry on the authentication
+// conversation, and clunks of an auth fid.
+func (s *Server) srvAuthFID(ctx context.Context, b *bytes.Buffer, mt MType, fid FID, a *authState) error {
+	switch mt {
+	case Tread:
+		_, _, c, t, err := UnmarshalTreadPkt(b)
+		if err != nil {
+			return err
+		}
+		d := make([]byte, c)
+		n, err := a.conv.Read(d)
+		if err != nil && err != io.EOF {
+			s.rerror(ctx, b, t, err)
+			return nil
+		}
+		MarshalRreadPkt(b, t, d[:n])
+	case Twrite:
+		_, _, d, t, err := UnmarshalTwritePkt(b)
+		if err != nil {
+			return err
+		}
+		n, err := a.conv.Write(d)
+		if err != nil {
+			s.rerror(ctx, b, t, err)
+			return nil
+		}
+		MarshalRwritePkt(b, t, Count(n))
+	case Tclunk, Tremove:
+		_, t, err := UnmarshalTclunkPkt(b)
+		if err != nil {
+			return err
+		}
+		ss := s.session(ctx)
+		ss.mu.Lock()
+		delete(ss.afids, fid)
+		ss.mu.Unlock()
+		// A remove clunks the fid even if it fails, and there is
+		// nothing to remove.
+		if mt == Tremove {
+			s.rerror(ctx, b, t, fmt.Errorf("permission denied"))
+			return nil
+		}
+		MarshalRclunkPkt(b, t)
+	}
+	return nil
+}
+
+// checkAttach checks that the Tattach in b may go ahead. If there is an
+// Authenticator, it must have authenticated the user on the afid;
+// otherwise the NineServer decides what to do with the afid.
+func (s *Server) checkAttach(ctx context.Context, b *bytes.Buffer, dotu bool) (Tag, error) {
+	var (
+		afid         FID
+		uname, aname string
+		nuname       uint32 = NOUID
+		t            Tag
+		err          error
+	)
+	// Decode a copy, so that the real stub still has the message.
+	bb := bytes.NewBuffer(b.Bytes())
+	if dotu {
+		_, afid, uname, aname, nuname, t, err = UnmarshalTattachDotuPkt(bb)
+	} else {
+		_, afid, uname, aname, t, err = UnmarshalTattachPkt(bb)
+	}
+	if err != nil {
+		// Let the stub report it.
+		return t, nil
+	}
+	if s.Auth == nil {
+		return t, nil
+	}
+	if afid == NOFID {
+		return t, fmt.Errorf("authentication required")
+	}
+	ss := s.session(ctx)
+	ss.mu.Lock()
+	a, ok := ss.afids[afid]
+	ss.mu.Unlock()
+	if !ok {
+		return t, fmt.Errorf("unknown auth fid %v", afid)
+	}
+	if a.uname != uname || a.aname != aname || a.nuname != nuname {
+		return t, fmt.Errorf("auth fid %v is for %q, not %q", afid, a.uname, uname)
+	}
+	if err := a.conv.Authenticated(); err != nil {
+		return t, err
+	}
+	return t, nil
+}
+
+// sharedSecret authenticates users who know a secret shared with the
+// server. The server sends a random challenge, and the client proves it
+// knows the secret by replying with an HMAC-SHA256 of it, keyed by the
+// secret. See SharedSecretResponse.
+type sharedSecret struct {
+	secret []byte
+}
+
+// NewSharedSecret returns an Authenticator for the shared secret scheme.
+// Everyone with the secret may attach as any user.
+func NewSharedSecret(secret []byte) Authenticator {
+	return &sharedSecret{secret: append([]byte{}, secret...)}
+}
+
+// SharedSecretChallengeLen is the length of a shared secret challenge.
+const SharedSecretChallengeLen = 32
+
+// SharedSecretResponse returns the response to a shared secret challenge
+// for uname attaching to aname.
+func SharedSecretResponse(secret, challenge []byte, uname, aname string) []byte {
+	m := hmac.New(sha256.New, secret)
+	m.Write(challenge)
+	m.Write([]byte(uname))
+	m.Write([]byte{0})
+	m.Write([]byte(aname))
+	return m.Sum(nil)
+}
+
+func (a *sharedSecret) Start(uname, aname string) (AuthConv, error) {
+	c := &sharedSecretConv{secret: a.secret, uname: uname, aname: aname}
+	if _, err := rand.Read(c.challenge[:]); err != nil {
+		return nil, err
+	}
+	c.r = bytes.NewReader(c.challenge[:])
+	return c, nil
+}
+
+type sharedSecretConv struct {
+	secret    []byte
+	uname     string
+	aname     string
+	challenge [SharedSecretChallengeLen]byte
+	r         *bytes.Reader
+
+	// mu guards below
+	mu   sync.Mutex
+	done bool
+	err  error
+}
+
+// Read reads the challenge.
+func (c *sharedSecretConv) Read(b []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.r.Read(b)
+}
+
+// Write checks the response. There is only one try.
+func (c *sharedSecretConv) Write(b []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.done {
+		return 0, fmt.Errorf("authentication already done")
+	}
+	c.done = true
+	if !hmac.Equal(b, SharedSecretResponse(c.secret, c.challenge[:], c.uname, c.aname)) {
+		c.err = fmt.Errorf("authentication failed")
+		return 0, c.err
+	}
+	return len(b), nil
+}
+
+func (c *sharedSecretConv) Authenticated() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.done {
+		return fmt.Errorf("authentication not done")
+	}
+	return c.err
+}
+
+// AuthSharedSecret authenticates uname for aname with the shared secret
+// scheme, using the auth fid afid, which the caller has set up with a
+// Tauth.
+func (c *Client) AuthSharedSecret(afid FID, uname, aname string, secret []byte) error {
+	ch, err := c.CallTread(afid, 0, SharedSecretChallengeLen)
+	if err != nil {
+		return err
+	}
+	if len(ch) != SharedSecretChallengeLen {
+		return fmt.Errorf("short challenge: want %d bytes, got %d", SharedSecretChallengeLen, len(ch))
+	}
+	_, err = c.CallTwrite(afid, 0, SharedSecretResponse(secret, ch, uname, aname))
+	return err
+}
@@ -8,10 +8,12 @@ package protocol
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
-	"log"
 	"runtime"
+	"sync"
 	"sync/atomic"
 )
 
@@ -20,8 +22,12 @@ import (
 // client are unique), an array of MaxTag-2 RPC structs, a ReadWriteCloser
 // for IO, and two channels for a server goroutine: one down which RPCalls are
 // pushed and another from which RPCReplys return.
-// Once a client is marked Dead all further requests to it will fail.
+// Once a client is dead, because it was closed or its connection failed,
+// all pending and further requests to it fail with the error from Err.
 // The ToNet/FromNet are separate so we can use io.Pipe for testing.
+//
+// Each CallT method has a CallTContext variant, which sends a Tflush for
+// the request if the context is done before the reply comes.
 type Client struct {
 	Tags       chan Tag
 	FID        uint64
@@ -31,10 +37,43 @@ type Client struct {
 	FromClient chan *RPCCall
 	FromServer chan *RPCReply
 	Msize      uint32
-	Dead       bool
-	Trace      Tracer
+	// Deprecated: Dead is set when the client dies, but may not be
+	// read while it is running. Use Done or Err.
+	Dead  bool
+	Trace Tracer
+
+	// wantVersion is the protocol version Dial asks for, set by
+	// WithVersion. If uname is set, by WithAttach, Dial attaches as
+	// uname to the tree aname, and root is the File for its root.
+	wantVersion string
+	uname       string
+	aname       string
+	root        *File
+
+	// version is the negotiated protocol version.
+	version atomic.Value
+
+	// framer reads replies from FromNet, and writes calls to ToNet.
+	framer *Framer
+
+	// done is closed when the client dies, and wg waits for its
+	// goroutines.
+	done chan struct{}
+	wg   sync.WaitGroup
+
+	// mu guards below
+	mu sync.Mutex
+	// err is why the client died.
+	err error
+	// flushed are the tags of calls flushed before their replies came.
+	// A server may wrongly send the reply after the Rflush, so one for
+	// these tags is dropped, until the tag is used again.
+	flushed map[Tag]struct{}
 }
 
+// ErrClientClosed is the error of calls on a Client after Close.
+var ErrClientClosed = errors.New("9p client closed")
+
 func NewClient(opts ...ClientOpt) (*Client, error) {
 	var c = &Client{}
 
@@ -44,18 +83,228 @@ func NewClient(opts ...ClientOpt) (*Client, error) {
 	}
 	c.FID = 1
 	c.RPC = make([]*RPCCall, NumTags)
+	c.flushed = make(map[Tag]struct{})
 	for _, o := range opts {
 		if err := o(c); err != nil {
 			return nil, err
 		}
 	}
+	c.framer = NewFramer(c.FromNet, c.ToNet)
 	c.FromClient = make(chan *RPCCall, NumTags)
 	c.FromServer = make(chan *RPCReply)
-	go c.IO()
-	go c.readNetPackets()
+	c.done = make(chan struct{})
+	c.wg.Add(2)
+	go func() {
+		defer c.wg.Done()
+		c.IO()
+	}()
+	go func() {
+		defer c.wg.Done()
+		c.readNetPackets()
+	}()
 	return c, nil
 }
 
+// Close shuts the client down: it closes the connection, fails all
+// pending calls, and waits for the client's goroutines to finish.
+func (c *Client) Close() error {
+	c.fail(ErrClientClosed)
+	c.wg.Wait()
+	return nil
+}
+
+// Done returns a channel which is closed when the client dies.
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err returns why the client died, or nil if it is alive.
+func (c *Client) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// fail kills the client with err, unless it is dead already, and closes
+// the connection so that its goroutines finish.
+func (c *Client) fail(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return
+	}
+	if c.Trace != nil {
+		c.Trace("client dies: %v", err)
+	}
+	c.err = err
+	c.Dead = true
+	close(c.done)
+	if c.ToNet != nil {
+		c.ToNet.Close()
+	}
+	if c.FromNet != nil {
+		c.FromNet.Close()
+	}
+}
+
+// rpc sends the T-message in b, and returns the reply. If ctx is done
+// before the reply comes, rpc flushes the request.
+func (c *Client) rpc(ctx context.Context, b []byte) ([]byte, error) {
+	t, r, err := c.send(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+	return c.wait(ctx, t, r)
+}
+
+// send tags the T-message in b and queues it to be sent. Calls are sent
+// in the order in which they are queued.
+func (c *Client) send(ctx context.Context, b []byte) (Tag, *RPCCall, error) {
+	if err := c.Err(); err != nil {
+		return 0, nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, nil, err
+	}
+	var t Tag
+	select {
+	case t = <-c.Tags:
+	case <-c.done:
+		return 0, nil, c.Err()
+	case <-ctx.Done():
+		return 0, nil, ctx.Err()
+	}
+	if c.Trace != nil {
+		c.Trace("Tag for request is %v", t)
+	}
+	b[5], b[6] = uint8(t), uint8(t>>8)
+	r := &RPCCall{b: b, Reply: make(chan []byte, 1)}
+	c.mu.Lock()
+	c.RPC[t-1] = r
+	delete(c.flushed, t)
+	c.mu.Unlock()
+	select {
+	case c.FromClient <- r:
+		return t, r, nil
+	case <-c.done:
+		return 0, nil, c.Err()
+	case <-ctx.Done():
+		// It was never sent, so there is nothing to flush.
+		c.release(t, r)
+		return 0, nil, ctx.Err()
+	}
+}
+
+// wait waits for the reply to r, whose tag is t. If ctx is done first,
+// wait flushes r.
+func (c *Client) wait(ctx context.Context, t Tag, r *RPCCall) ([]byte, error) {
+	select {
+	case bb := <-r.Reply:
+		c.Tags <- t
+		return bb, nil
+	case <-c.done:
+		return nil, c.Err()
+	case <-ctx.Done():
+		return c.flush(ctx, t, r)
+	}
+}
+
+// A Call is an asynchronous request, made by one of the Client's Go
+// methods, such as GoTread. Once the reply comes, or the request fails,
+// Reply or Error is set, and the Call is sent on Done.
+type Call struct {
+	// Reply holds the results, such as a *TreadReply for a GoTread.
+	Reply interface{}
+	Error error
+	Done  chan *Call
+}
+
+// goCall sends the T-message in b, and returns its Call. decode decodes
+// the reply. If done is nil, goCall makes a channel; otherwise it must
+// be buffered, as in net/rpc, since the Client won't block sending on it.
+func (c *Client) goCall(ctx context.Context, b []byte, done chan *Call, decode func([]byte) (interface{}, error)) *Call {
+	if done == nil {
+		done = make(chan *Call, 1)
+	} else if cap(done) == 0 {
+		panic("9p: done channel is unbuffered")
+	}
+	call := &Call{Done: done}
+	if err := c.fits(len(b)); err != nil {
+		call.Error = err
+		call.done()
+		return call
+	}
+	t, r, err := c.send(ctx, b)
+	if err != nil {
+		call.Error = err
+		call.done()
+		return call
+	}
+	go func() {
+		bb, err := c.wait(ctx, t, r)
+		switch {
+		case err != nil:
+		case MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror:
+			err = rerror(bb)
+			putFrame(bb)
+		default:
+			call.Reply, err = decode(bb)
+		}
+		call.Error = err
+		call.done()
+	}()
+	return call
+}
+
+func (call *Call) done() {
+	select {
+	case call.Done <- call:
+	default:
+		// The caller made Done too small; like net/rpc, we drop
+		// the Call rather than block.
+	}
+}
+
+// flush flushes the request r, whose tag is t. As the spec requires, t is
+// not reused until the server has answered the Tflush, and if the server
+// answered r first, the reply stands.
+func (c *Client) flush(ctx context.Context, t Tag, r *RPCCall) ([]byte, error) {
+	if c.Trace != nil {
+		c.Trace("Flushing tag %v: %v", t, ctx.Err())
+	}
+	// A server which does not know the tag may send an Rerror; the
+	// flush is done all the same.
+	if err := c.CallTflush(t); err != nil && c.Err() != nil {
+		return nil, c.Err()
+	}
+	c.mu.Lock()
+	if c.RPC[t-1] == r {
+		c.RPC[t-1] = nil
+		c.flushed[t] = struct{}{}
+	}
+	c.mu.Unlock()
+	// Tags are reused in the order they come back, so it will be a
+	// while before t is, and a late reply for it can be told apart.
+	c.Tags <- t
+	// Replies come in order, so r's, if any, came before the Rflush.
+	select {
+	case bb := <-r.Reply:
+		return bb, nil
+	default:
+		return nil, ctx.Err()
+	}
+}
+
+// release gives back the tag t of r, which has no reply coming.
+func (c *Client) release(t Tag, r *RPCCall) {
+	c.mu.Lock()
+	if c.RPC[t-1] == r {
+		c.RPC[t-1] = nil
+	}
+	c.mu.Unlock()
+	c.Tags <- t
+}
+
 // GetTag gets a tag to be used to identify a message.
 func (c *Client) GetTag() Tag {
 	t := <-c.Tags
@@ -75,78 +324,62 @@ func (c *Client) GetFID() FID {
 }
 
 func (c *Client) readNetPackets() {
+	defer close(c.FromServer)
 	if c.FromNet == nil {
-		if c.Trace != nil {
-			c.Trace("c.FromNet is nil, marking dead")
-		}
-		c.Dead = true
+		c.fail(errors.New("no connection: FromNet is nil"))
 		return
 	}
-	defer c.FromNet.Close()
-	defer close(c.FromServer)
 	if c.Trace != nil {
 		c.Trace("Starting readNetPackets")
 	}
-	for !c.Dead {
-		l := make([]byte, 7)
+	for {
 		if c.Trace != nil {
 			c.Trace("Before read")
 		}
-
-		if n, err := c.FromNet.Read(l); err != nil || n < 7 {
-			log.Printf("readNetPackets: short read: %v", err)
-			c.Dead = true
+		b, err := c.framer.ReadFrame(MaxSize(c.msize()))
+		if err != nil {
+			c.fail(fmt.Errorf("reading from server: %v", err))
 			return
 		}
 		if c.Trace != nil {
-			c.Trace("Server reads %v", l)
-		}
-		s := int64(l[0]) + int64(l[1])<<8 + int64(l[2])<<16 + int64(l[3])<<24
-		b := bytes.NewBuffer(l)
-		r := io.LimitReader(c.FromNet, s-7)
-		if _, err := io.Copy(b, r); err != nil {
-			log.Printf("readNetPackets: short read: %v", err)
-			c.Dead = true
-			return
+			c.Trace("readNetPackets: got %v, len %d, sending to IO", RPCNames[MType(b[4])], len(b))
 		}
-		if c.Trace != nil {
-			c.Trace("readNetPackets: got %v, len %d, sending to IO", RPCNames[MType(l[4])], b.Len())
+		select {
+		case c.FromServer <- &RPCReply{b: b}:
+		case <-c.done:
+			return
 		}
-		c.FromServer <- &RPCReply{b: b.Bytes()}
 	}
-	if c.Trace != nil {
-		c.Trace("Client %v is all done", c)
-	}
-
 }
 
+// IO sends calls to the server, and passes replies back to the calls
+// they answer, until the client dies.
 func (c *Client) IO() {
+	c.wg.Add(1)
 	go func() {
+		defer c.wg.Done()
 		for {
-			r := <-c.FromClient
-			t := <-c.Tags
-			if c.Trace != nil {
-				c.Trace(fmt.Sprintf("Tag for request is %v", t))
-			}
-			r.b[5] = uint8(t)
-			r.b[6] = uint8(t >> 8)
-			if c.Trace != nil {
-				c.Trace(fmt.Sprintf("Tag for request is %v", t))
+			var r *RPCCall
+			select {
+			case r = <-c.FromClient:
+			case <-c.done:
+				return
 			}
-			c.RPC[int(t)-1] = r
 			if c.Trace != nil {
 				c.Trace("Write %v to ToNet", r.b)
 			}
-			if _, err := c.ToNet.Write(r.b); err != nil {
-				c.Dead = true
-				log.Fatalf("Write to server: %v", err)
+			if err := c.framer.WriteFrame(r.b); err != nil {
+				c.fail(fmt.Errorf("writing to server: %v", err))
 				return
 			}
 		}
 	}()
 
 	for {
-		r := <-c.FromServer
+		r, ok := <-c.FromServer
+		if !ok {
+			return
+		}
 		if c.Trace != nil {
 			c.Trace("Read %v FromServer", r.b)
 		}
@@ -154,22 +387,94 @@ func (c *Client) IO() {
 		if c.Trace != nil {
 			c.Trace(fmt.Sprintf("Tag for reply is %v", t))
 		}
-		if t < 1 {
-			panic(fmt.Sprintf("tag %d < 1", t))
+		// From now on, we hold the server to the msize it agreed to.
+		if MType(r.b[4]) == Rversion {
+			if m, v, _, err := UnmarshalRversionPkt(bytes.NewBuffer(r.b[5:])); err == nil {
+				atomic.StoreUint32(&c.Msize, uint32(m))
+				c.version.Store(v)
+			}
+		}
+		var rrr *RPCCall
+		var late bool
+		c.mu.Lock()
+		if t >= 1 && int(t) <= len(c.RPC) {
+			rrr, c.RPC[t-1] = c.RPC[t-1], nil
 		}
-		if int(t-1) >= len(c.RPC) {
-			panic(fmt.Sprintf("tag %d >= len(c.RPC) %d", t, len(c.RPC)))
+		if rrr == nil {
+			_, late = c.flushed[t]
+			delete(c.flushed, t)
+		}
+		c.mu.Unlock()
+		if late {
+			// The server answered the Tflush before the call it
+			// flushed. Nobody wants the reply.
+			if c.Trace != nil {
+				c.Trace("Dropping %v for flushed tag %d", RPCNames[MType(r.b[4])], t)
+			}
+			putFrame(r.b)
+			continue
 		}
-		c.Trace("RPC %v ", c.RPC[t-1])
-		rrr := c.RPC[t-1]
-		c.Trace("rrr %v ", rrr)
+		if rrr == nil {
+			c.fail(fmt.Errorf("%v reply has unknown tag %d", RPCNames[MType(r.b[4])], t))
+			return
+		}
+		if c.Trace != nil {
+			c.Trace("rrr %v ", rrr)
+		}
+		// The call gives back the tag, as a flushed call must keep
+		// it until the Rflush.
 		rrr.Reply <- r.b
-		c.Tags <- t
 	}
 }
 
+// msize returns the msize, or MSIZE if there is none.
+func (c *Client) msize() uint32 {
+	if m := atomic.LoadUint32(&c.Msize); m != 0 {
+		return m
+	}
+	return MSIZE
+}
+
+// Version returns the protocol version negotiated by the last Tversion,
+// or "" if there has been none.
+func (c *Client) Version() string {
+	v, _ := c.version.Load().(string)
+	return v
+}
+
+// fits returns an error if a message of n bytes is too big to send.
+func (c *Client) fits(n int) error {
+	if m := c.msize(); n > int(m) {
+		return fmt.Errorf("message is %d bytes; msize is %d", n, m)
+	}
+	return nil
+}
+
+// rerror decodes an Rerror, as an *Error, or, for 9P2000.L, an Rlerror, as
+// a syscall.Errno. A 9P2000.u server appends an errno to Rerror, which we
+// can tell from the length. Either errno is a Linux number, which we read
+// as the host's errno.
+func rerror(b []byte) error {
+	if MType(b[4]) == Rlerror {
+		ecode, _, err := UnmarshalRlerrorPkt(bytes.NewBuffer(b[5:]))
+		if err != nil {
+			return err
+		}
+		return hostErrno(ecode)
+	}
+	s, _, err := UnmarshalRerrorPkt(bytes.NewBuffer(b[5:]))
+	if err == nil {
+		return newError(s, 0)
+	}
+	s, ecode, _, err := UnmarshalRerrorDotuPkt(bytes.NewBuffer(b[5:]))
+	if err != nil {
+		return err
+	}
+	return newError(s, hostErrno(ecode))
+}
+
 func (c *Client) String() string {
 	z := map[bool]string{false: "Alive", true: "Dead"}
-	return fmt.Sprintf("%v tags available, Msize %v, %v FromNet %v ToNet %v", len(c.Tags), c.Msize, z[c.Dead],
+	return fmt.Sprintf("%v tags available, Msize %v, %v FromNet %v ToNet %v", len(c.Tags), c.Msize, z[c.Err() != nil],
 		c.FromNet, c.ToNet)
 }
@@ -0,0 +1,147 @@
+// Copyright 2018 The Ninep Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protocol
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// ParseDialString converts a Plan 9 dial string, such as tcp!host!564,
+// net!host!9fs or unix!/tmp/sock, to a Go network and address. The host *
+// means every address, as in announce. A tcp address without a port gets
+// the 9P port, 564. A string without a ! is a Go address, such as
+// host:564, on the network defnet.
+func ParseDialString(s, defnet string) (network, address string, err error) {
+	f := strings.Split(s, "!")
+	if len(f) == 1 {
+		return defnet, s, nil
+	}
+	switch network = f[0]; network {
+	case "unix":
+		if len(f) != 2 || f[1] == "" {
+			return "", "", fmt.Errorf("bad dial string %q: want unix!path", s)
+		}
+		return network, f[1], nil
+	case "net":
+		network = "tcp"
+	case "tcp", "tcp4", "tcp6":
+	default:
+		return "", "", fmt.Errorf("bad dial string %q: unknown network %q", s, network)
+	}
+	if len(f) > 3 {
+		return "", "", fmt.Errorf("bad dial string %q: want %v!host!port", s, f[0])
+	}
+	host, port := f[1], strconv.Itoa(PORT)
+	if host == "*" {
+		host = ""
+	}
+	if len(f) == 3 {
+		switch port = f[2]; port {
+		case "9fs", "9pfs":
+			port = strconv.Itoa(PORT)
+		}
+	}
+	return network, net.JoinHostPort(host, port), nil
+}
+
+// WithVersion makes Dial ask for the protocol version v, rather than
+// 9P2000.
+func WithVersion(v string) ClientOpt {
+	return func(c *Client) error {
+		c.wantVersion = v
+		return nil
+	}
+}
+
+// WithAttach makes Dial attach as uname to the tree aname once it has
+// negotiated the version. The File for its root is then Root.
+func WithAttach(uname, aname string) ClientOpt {
+	return func(c *Client) error {
+		if uname == "" {
+			return fmt.Errorf("WithAttach: empty uname")
+		}
+		c.uname, c.aname = uname, aname
+		return nil
+	}
+}
+
+// Root returns the File for the root of the tree Dial attached to, or nil
+// if it did not attach.
+func (c *Client) Root() *File {
+	return c.root
+}
+
+// Dial connects to the 9P server at addr, which is a Plan 9 dial string or
+// a TCP address, and negotiates the protocol version and msize. The opts
+// are applied to the Client before it sends the Tversion: it asks for the
+// version given by WithVersion, or 9P2000, and for Msize, or MSIZE if that
+// is 0. With WithAttach, Dial then attaches, and sets Root.
+func Dial(ctx context.Context, addr string, opts ...ClientOpt) (*Client, error) {
+	network, address, err := ParseDialString(addr, "tcp")
+	if err != nil {
+		return nil, err
+	}
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+	c, err := NewClient(append([]ClientOpt{func(c *Client) error {
+		c.FromNet, c.ToNet = conn, conn
+		return nil
+	}}, opts...)...)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	// The calls are flushed if ctx is done, but a server which does not
+	// answer them won't answer the Tflush either, so the client, which
+	// we are giving up on, is closed, which fails them.
+	stop := context.AfterFunc(ctx, func() {
+		c.Close()
+	})
+	if err := c.handshake(ctx); err != nil {
+		stop()
+		c.Close()
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		return nil, fmt.Errorf("dial %v: %w", addr, err)
+	}
+	if !stop() {
+		c.Close()
+		return nil, fmt.Errorf("dial %v: %w", addr, ctx.Err())
+	}
+	return c, nil
+}
+
+// handshake does Dial's Tversion and Tattach.
+func (c *Client) handshake(ctx context.Context) error {
+	v := c.wantVersion
+	if v == "" {
+		v = Version9P2000
+	}
+	m := c.Msize
+	if m == 0 {
+		m = MSIZE
+	}
+	_, rv, err := c.CallTversionContext(ctx, MaxSize(m), v)
+	if err != nil {
+		return err
+	}
+	if rv != v {
+		return fmt.Errorf("server speaks %q, not %q", rv, v)
+	}
+	if c.uname == "" {
+		return nil
+	}
+	c.root, err = c.AttachContext(ctx, NOFID, c.uname, c.aname)
+	return err
+}
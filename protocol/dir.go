@@ -0,0 +1,391 @@
+// Copyright 2018 The Ninep Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protocol
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"iter"
+	"sync"
+	"time"
+)
+
+// Unmarshaldirs decodes the Dirs packed in b, which is the data of an
+// Rread of a directory.
+func Unmarshaldirs(b []byte) ([]Dir, error) {
+	return unmarshaldirs(b, Decodedir)
+}
+
+// UnmarshaldirsDotu is Unmarshaldirs for 9P2000.u.
+func UnmarshaldirsDotu(b []byte) ([]Dir, error) {
+	return unmarshaldirs(b, DecodedirDotu)
+}
+
+func unmarshaldirs(b []byte, decode func([]byte) (Dir, error)) ([]Dir, error) {
+	var ds []Dir
+	for len(b) > 0 {
+		if len(b) < 2 {
+			return ds, fmt.Errorf("short directory entry: %d bytes", len(b))
+		}
+		n := 2 + (int(b[0]) | int(b[1])<<8)
+		if n > len(b) {
+			return ds, fmt.Errorf("directory entry is %d bytes; only have %d", n, len(b))
+		}
+		d, err := decode(b[:n])
+		if err != nil {
+			return ds, err
+		}
+		ds = append(ds, d)
+		b = b[n:]
+	}
+	return ds, nil
+}
+
+// readdir reads the next entries of the directory f with one Tread, and
+// returns io.EOF at the end. Each Rread of a directory holds whole
+// entries, and the next Tread must start where the last ended, so the
+// File's offset is advanced by what was read.
+func (f *File) readdir() ([]Dir, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	b, err := f.c.CallTread(f.fid, Offset(f.offset), Count(f.maxIO()))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, io.EOF
+	}
+	f.offset += int64(len(b))
+	if f.c.Version() == Version9P2000u {
+		return UnmarshaldirsDotu(b)
+	}
+	return Unmarshaldirs(b)
+}
+
+// Readdir reads the entries of the directory f, which must be open for
+// reading, from its offset to the end. It returns the entries read, even
+// if it fails part way. A directory may only be read from the start, or
+// from where the last read ended, so to read it again, Seek to 0 first.
+func (f *File) Readdir() ([]Dir, error) {
+	var ds []Dir
+	for d, err := range f.Dirs() {
+		if err != nil {
+			return ds, err
+		}
+		ds = append(ds, d)
+	}
+	return ds, nil
+}
+
+// Dirs returns an iterator over the entries of the directory f, as
+// Readdir reads them, one Tread at a time. If a Tread fails, the iterator
+// yields the error and stops. Stopping early drops the rest of the entries
+// from the last Tread.
+func (f *File) Dirs() iter.Seq2[Dir, error] {
+	return func(yield func(Dir, error) bool) {
+		for {
+			ds, err := f.readdir()
+			for _, d := range ds {
+				if !yield(d, nil) {
+					return
+				}
+			}
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				yield(Dir{}, err)
+				return
+			}
+		}
+	}
+}
+
+// A DirReader makes the data of Rreads of a directory for a NineServer. It
+// packs as many whole entries into each read as fit, carries an entry which
+// does not fit over to the next read, and checks that each read starts at
+// 0, which starts the listing again, or where the last read ended, as 9P
+// requires. A server keeps one for each fid it has open on a directory.
+type DirReader struct {
+	list func() iter.Seq2[Dir, error]
+	dotu bool
+
+	// mu guards below
+	mu sync.Mutex
+	// next and stop pull from the current listing.
+	next func() (Dir, error, bool)
+	stop func()
+	// offset is where the last read ended.
+	offset int64
+	// oflow is the entry which did not fit in the last read.
+	oflow []byte
+	// err is an error held back until the data before it is read.
+	err error
+}
+
+// NewDirReader returns a DirReader for the entries of a directory. list is
+// called each time the directory is read from offset 0, and returns its
+// entries; they are marshaled for 9P2000.u if dotu is set.
+func NewDirReader(list func() iter.Seq2[Dir, error], dotu bool) *DirReader {
+	return &DirReader{list: list, dotu: dotu}
+}
+
+// Read returns the data of an Rread at o of at most c bytes, which is empty
+// at the end of the directory. It fails if o is not 0 or where the last
+// Read ended, or if c is too small for the next entry.
+func (r *DirReader) Read(o Offset, c Count) ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if o == 0 {
+		r.reset()
+		r.next, r.stop = iter.Pull2(r.list())
+	} else if int64(o) != r.offset {
+		return nil, fmt.Errorf("bad offset in directory read: %d, want %d", o, r.offset)
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	if r.next == nil {
+		return nil, fmt.Errorf("bad offset in directory read: %d, want 0", o)
+	}
+
+	var b []byte
+	for {
+		n := len(b)
+		if r.oflow != nil {
+			b, r.oflow = append(b, r.oflow...), nil
+		} else {
+			d, err, ok := r.next()
+			if !ok {
+				break
+			}
+			if err != nil {
+				// Send what we have, and the error next time.
+				if n == 0 {
+					return nil, err
+				}
+				r.err = err
+				break
+			}
+			if r.dotu {
+				b = AppenddirDotu(b, d)
+			} else {
+				b = Appenddir(b, d)
+			}
+		}
+		if len(b) > int(c) {
+			// The entry is kept for the next read.
+			r.oflow = append([]byte(nil), b[n:]...)
+			if n == 0 {
+				return nil, fmt.Errorf("directory entry of %d bytes does not fit in read of %d", len(r.oflow), c)
+			}
+			b = b[:n]
+			break
+		}
+	}
+	r.offset += int64(len(b))
+	return b, nil
+}
+
+// reset stops the current listing, if any.
+func (r *DirReader) reset() {
+	if r.stop != nil {
+		r.stop()
+	}
+	r.next, r.stop, r.offset, r.oflow, r.err = nil, nil, 0, nil, nil
+}
+
+// Close stops the current listing. A server closes the DirReader when the
+// fid is clunked.
+func (r *DirReader) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reset()
+	return nil
+}
+
+// NullDir returns a Dir with every field set to its "don't touch" value, ~0
+// for numbers and "" for strings, as for a Twstat. Set the fields to be
+// changed; a Twstat of NullDir itself asks the server to commit the file
+// to stable storage.
+func NullDir() Dir {
+	return Dir{
+		Type:   ^uint16(0),
+		Dev:    ^uint32(0),
+		QID:    QID{Type: ^uint8(0), Version: ^uint32(0), Path: ^uint64(0)},
+		Mode:   ^uint32(0),
+		Atime:  ^uint32(0),
+		Mtime:  ^uint32(0),
+		Length: ^uint64(0),
+		UID:    NOUID,
+		GID:    NOUID,
+		MUID:   NOUID,
+	}
+}
+
+// DirFromFileInfo returns the Dir for fi. If fi is from Dir.FileInfo, that
+// is the Dir, named fi.Name(). Otherwise the QID has only its type, the
+// user and group names are empty, and the 9P2000.u ids are NOUID, for the
+// caller to fill in.
+func DirFromFileInfo(fi fs.FileInfo) Dir {
+	if d, ok := fi.Sys().(Dir); ok {
+		d.Name = fi.Name()
+		return d
+	}
+	d := Dir{
+		Mode:   DirMode(fi.Mode()),
+		Length: uint64(fi.Size()),
+		Name:   fi.Name(),
+		UID:    NOUID,
+		GID:    NOUID,
+		MUID:   NOUID,
+	}
+	d.QID.Type = uint8(d.Mode >> 24)
+	d.SetModTime(fi.ModTime())
+	d.SetAccessTime(fi.ModTime())
+	return d
+}
+
+// FileInfo returns d as an fs.FileInfo, whose Sys method returns d.
+func (d Dir) FileInfo() fs.FileInfo {
+	return &dirInfo{d: d, name: d.Name}
+}
+
+// ModTime returns the time the file was last modified.
+func (d Dir) ModTime() time.Time {
+	return time.Unix(int64(d.Mtime), 0)
+}
+
+// AccessTime returns the time the file was last read.
+func (d Dir) AccessTime() time.Time {
+	return time.Unix(int64(d.Atime), 0)
+}
+
+// SetModTime sets the time the file was last modified to t, to the second.
+func (d *Dir) SetModTime(t time.Time) {
+	d.Mtime = uint32(t.Unix())
+}
+
+// SetAccessTime sets the time the file was last read to t, to the second.
+func (d *Dir) SetAccessTime(t time.Time) {
+	d.Atime = uint32(t.Unix())
+}
+
+// String returns d as Plan 9's ls -l shows it, such as
+//
+//	--rw-r--r-- M 3 glenda glenda 1024 Jan  2 15:04 name
+//
+// with the target of a 9P2000.u symbolic link after the name.
+func (d Dir) String() string {
+	t := d.ModTime()
+	tf := "Jan _2 15:04"
+	if time.Since(t) > 180*24*time.Hour || time.Until(t) > 0 {
+		tf = "Jan _2  2006"
+	}
+	typ := "-"
+	if d.Type != 0 {
+		typ = string(rune(d.Type))
+	}
+	s := fmt.Sprintf("%s %s %d %s %s %d %s %s", modeString(d.Mode), typ, d.Dev, d.User, d.Group, d.Length, t.Format(tf), d.Name)
+	if d.Mode&DMSYMLINK != 0 && d.Extension != "" {
+		s += " -> " + d.Extension
+	}
+	return s
+}
+
+// modeString formats m as Plan 9's %M does.
+func modeString(m uint32) string {
+	b := []byte("-----------")
+	switch {
+	case m&DMDIR != 0:
+		b[0] = 'd'
+	case m&DMAPPEND != 0:
+		b[0] = 'a'
+	case m&DMAUTH != 0:
+		b[0] = 'A'
+	}
+	if m&DMEXCL != 0 {
+		b[1] = 'l'
+	}
+	for i, c := range "rwxrwxrwx" {
+		if m&(1<<uint(8-i)) != 0 {
+			b[2+i] = byte(c)
+		}
+	}
+	return string(b)
+}
+
+// modeBits pairs the bits of a Dir's mode with those of an fs.FileMode.
+var modeBits = []struct {
+	dm uint32
+	fm fs.FileMode
+}{
+	{DMDIR, fs.ModeDir},
+	{DMAPPEND, fs.ModeAppend},
+	{DMEXCL, fs.ModeExclusive},
+	{DMTMP, fs.ModeTemporary},
+	{DMSYMLINK, fs.ModeSymlink},
+	{DMDEVICE, fs.ModeDevice},
+	{DMNAMEDPIPE, fs.ModeNamedPipe},
+	{DMSOCKET, fs.ModeSocket},
+	{DMSETUID, fs.ModeSetuid},
+	{DMSETGID, fs.ModeSetgid},
+	{DMSETVTX, fs.ModeSticky},
+}
+
+// FileMode returns d's mode as an fs.FileMode.
+func (d Dir) FileMode() fs.FileMode {
+	fm := fs.FileMode(d.Mode & 0777)
+	for _, b := range modeBits {
+		if d.Mode&b.dm != 0 {
+			fm |= b.fm
+		}
+	}
+	return fm
+}
+
+// DirMode returns the mode of a Dir for fm. Bits with no 9P equivalent,
+// such as fs.ModeCharDevice and fs.ModeIrregular, are dropped.
+func DirMode(fm fs.FileMode) uint32 {
+	m := uint32(fm.Perm())
+	for _, b := range modeBits {
+		if fm&b.fm != 0 {
+			m |= b.dm
+		}
+	}
+	return m
+}
+
+// dirInfo is a Dir as an fs.FileInfo.
+type dirInfo struct {
+	d    Dir
+	name string
+}
+
+func (i *dirInfo) Name() string {
+	return i.name
+}
+
+func (i *dirInfo) Size() int64 {
+	return int64(i.d.Length)
+}
+
+func (i *dirInfo) Mode() fs.FileMode {
+	return i.d.FileMode()
+}
+
+func (i *dirInfo) ModTime() time.Time {
+	return i.d.ModTime()
+}
+
+func (i *dirInfo) IsDir() bool {
+	return i.d.Mode&DMDIR != 0
+}
+
+// Sys returns the Dir.
+func (i *dirInfo) Sys() interface{} {
+	return i.d
+}
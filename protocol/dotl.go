@@ -0,0 +1,143 @@
+// Copyright 2018 The Ninep Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protocol
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Flags for Tlopen and Tlcreate. These are the Linux open(2) flags, with
+// their x86 values, whatever the client and server run on.
+const (
+	DotlRdonly    = 00000000
+	DotlWronly    = 00000001
+	DotlRdwr      = 00000002
+	DotlNoaccess  = 00000003
+	DotlCreate    = 00000100
+	DotlExcl      = 00000200
+	DotlNoctty    = 00000400
+	DotlTrunc     = 00001000
+	DotlAppend    = 00002000
+	DotlNonblock  = 00004000
+	DotlDsync     = 00010000
+	DotlFasync    = 00020000
+	DotlDirect    = 00040000
+	DotlLargefile = 00100000
+	DotlDirectory = 00200000
+	DotlNofollow  = 00400000
+	DotlNoatime   = 01000000
+	DotlCloexec   = 02000000
+	DotlSync      = 04000000
+)
+
+// Mask bits for Tgetattr and Attr.Valid.
+const (
+	GetattrMode        = 0x00000001
+	GetattrNlink       = 0x00000002
+	GetattrUID         = 0x00000004
+	GetattrGID         = 0x00000008
+	GetattrRdev        = 0x00000010
+	GetattrAtime       = 0x00000020
+	GetattrMtime       = 0x00000040
+	GetattrCtime       = 0x00000080
+	GetattrIno         = 0x00000100
+	GetattrSize        = 0x00000200
+	GetattrBlocks      = 0x00000400
+	GetattrBtime       = 0x00000800
+	GetattrGen         = 0x00001000
+	GetattrDataVersion = 0x00002000
+	GetattrBasic       = 0x000007ff // everything up to GetattrBlocks
+	GetattrAll         = 0x00003fff
+)
+
+// Mask bits for SetAttr.Valid.
+const (
+	SetattrMode     = 0x00000001
+	SetattrUID      = 0x00000002
+	SetattrGID      = 0x00000004
+	SetattrSize     = 0x00000008
+	SetattrAtime    = 0x00000010
+	SetattrMtime    = 0x00000020
+	SetattrCtime    = 0x00000040
+	SetattrAtimeSet = 0x00000080 // use ATimeSec and ATimeNSec, not the current time
+	SetattrMtimeSet = 0x00000100 // use MTimeSec and MTimeNSec, not the current time
+)
+
+// Lock types for Tlock and Tgetlock
+const (
+	LockTypeRdlck = 0
+	LockTypeWrlck = 1
+	LockTypeUnlck = 2
+)
+
+// Flags for Tlock
+const (
+	LockFlagsBlock   = 1
+	LockFlagsReclaim = 2
+)
+
+// Status in Rlock
+const (
+	LockSuccess = 0
+	LockBlocked = 1
+	LockError   = 2
+	LockGrace   = 3
+)
+
+// AtRemoveDir is the flag to Tunlinkat to remove a directory.
+const AtRemoveDir = 0x200
+
+// Dirent is a directory entry as returned by 9P2000.L readdir.
+type Dirent struct {
+	QID    QID
+	Offset uint64 // offset of the next entry
+	Type   uint8  // Linux DT_ type
+	Name   string
+}
+
+// DirentLen returns the length of the encoded Dirent.
+func DirentLen(d Dirent) int {
+	return QIDLen + 8 + 1 + 2 + len(d.Name)
+}
+
+// MarshalDirent appends d to b. Unlike Marshaldir, b is not reset, so
+// entries can be packed one after another for an Rreaddir.
+func MarshalDirent(b *bytes.Buffer, d Dirent) {
+	q, o, l := d.QID, d.Offset, len(d.Name)
+	b.Write([]byte{q.Type,
+		uint8(q.Version), uint8(q.Version >> 8), uint8(q.Version >> 16), uint8(q.Version >> 24),
+		uint8(q.Path), uint8(q.Path >> 8), uint8(q.Path >> 16), uint8(q.Path >> 24),
+		uint8(q.Path >> 32), uint8(q.Path >> 40), uint8(q.Path >> 48), uint8(q.Path >> 56),
+		uint8(o), uint8(o >> 8), uint8(o >> 16), uint8(o >> 24),
+		uint8(o >> 32), uint8(o >> 40), uint8(o >> 48), uint8(o >> 56),
+		d.Type,
+		uint8(l), uint8(l >> 8),
+	})
+	b.WriteString(d.Name)
+}
+
+// UnmarshalDirent reads one Dirent from the front of b.
+func UnmarshalDirent(b *bytes.Buffer) (d Dirent, err error) {
+	u := b.Next(QIDLen + 8 + 1 + 2)
+	if len(u) < QIDLen+8+1+2 {
+		return d, fmt.Errorf("dirent too short: need %d, have %d", QIDLen+8+1+2, len(u))
+	}
+	d.QID.Type = u[0]
+	for i := uint(0); i < 4; i++ {
+		d.QID.Version |= uint32(u[1+i]) << (8 * i)
+	}
+	for i := uint(0); i < 8; i++ {
+		d.QID.Path |= uint64(u[5+i]) << (8 * i)
+		d.Offset |= uint64(u[13+i]) << (8 * i)
+	}
+	d.Type = u[21]
+	l := int(u[22]) | int(u[23])<<8
+	if b.Len() < l {
+		return d, fmt.Errorf("dirent name too short: need %d, have %d", l, b.Len())
+	}
+	d.Name = string(b.Next(l))
+	return d, nil
+}
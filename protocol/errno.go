@@ -0,0 +1,113 @@
+// Copyright 2018 The Ninep Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !plan9
+
+package protocol
+
+import "syscall"
+
+// The errnos we use ourselves, which are the host's.
+const (
+	eNOENT       = syscall.ENOENT
+	eACCES       = syscall.EACCES
+	ePERM        = syscall.EPERM
+	eEXIST       = syscall.EEXIST
+	eNOTDIR      = syscall.ENOTDIR
+	eISDIR       = syscall.EISDIR
+	eNOTEMPTY    = syscall.ENOTEMPTY
+	eBADF        = syscall.EBADF
+	eBUSY        = syscall.EBUSY
+	eIO          = syscall.EIO
+	eNAMETOOLONG = syscall.ENAMETOOLONG
+	eROFS        = syscall.EROFS
+	eINVAL       = syscall.EINVAL
+	eNOSYS       = syscall.ENOSYS
+	eINTR        = syscall.EINTR
+)
+
+// linuxErrnos are the Linux numbers of the host's errnos. 9P2000.u and
+// 9P2000.L carry errnos as Linux numbers, those of Linux on x86, whatever
+// the host is. The first number for an errno is the one sent, and the
+// first errno for a number is the one it is read as.
+var linuxErrnos = []struct {
+	linux uint32
+	errno syscall.Errno
+}{
+	{1, syscall.EPERM},
+	{2, syscall.ENOENT},
+	{3, syscall.ESRCH},
+	{4, syscall.EINTR},
+	{5, syscall.EIO},
+	{6, syscall.ENXIO},
+	{7, syscall.E2BIG},
+	{8, syscall.ENOEXEC},
+	{9, syscall.EBADF},
+	{10, syscall.ECHILD},
+	{11, syscall.EAGAIN},
+	{12, syscall.ENOMEM},
+	{13, syscall.EACCES},
+	{14, syscall.EFAULT},
+	{16, syscall.EBUSY},
+	{17, syscall.EEXIST},
+	{18, syscall.EXDEV},
+	{19, syscall.ENODEV},
+	{20, syscall.ENOTDIR},
+	{21, syscall.EISDIR},
+	{22, syscall.EINVAL},
+	{23, syscall.ENFILE},
+	{24, syscall.EMFILE},
+	{25, syscall.ENOTTY},
+	{26, syscall.ETXTBSY},
+	{27, syscall.EFBIG},
+	{28, syscall.ENOSPC},
+	{29, syscall.ESPIPE},
+	{30, syscall.EROFS},
+	{31, syscall.EMLINK},
+	{32, syscall.EPIPE},
+	{33, syscall.EDOM},
+	{34, syscall.ERANGE},
+	{35, syscall.EDEADLK},
+	{36, syscall.ENAMETOOLONG},
+	{37, syscall.ENOLCK},
+	{38, syscall.ENOSYS},
+	{39, syscall.ENOTEMPTY},
+	{40, syscall.ELOOP},
+	{75, syscall.EOVERFLOW},
+	{84, syscall.EILSEQ},
+	{95, syscall.EOPNOTSUPP},
+	{95, syscall.ENOTSUP},
+	{104, syscall.ECONNRESET},
+	{110, syscall.ETIMEDOUT},
+	{116, syscall.ESTALE},
+	{122, syscall.EDQUOT},
+	{125, syscall.ECANCELED},
+}
+
+// linuxErrno returns the Linux number of errno, if it has one.
+func linuxErrno(errno syscall.Errno) (uint32, bool) {
+	if errno == 0 {
+		return 0, true
+	}
+	for _, e := range linuxErrnos {
+		if e.errno == errno {
+			return e.linux, true
+		}
+	}
+	return 0, false
+}
+
+// hostErrno returns the errno for the Linux number l, or EIO if the host
+// has none.
+func hostErrno(l uint32) syscall.Errno {
+	if l == 0 {
+		return 0
+	}
+	for _, e := range linuxErrnos {
+		if e.linux == l {
+			return e.errno
+		}
+	}
+	return eIO
+}
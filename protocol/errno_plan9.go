@@ -0,0 +1,39 @@
+// Copyright 2018 The Ninep Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build plan9
+
+package protocol
+
+import "syscall"
+
+// The errnos we use ourselves. Plan 9 has none, but 9P2000.u and 9P2000.L
+// carry them, and they are Linux's.
+const (
+	eNOENT       syscall.Errno = 2
+	eACCES       syscall.Errno = 13
+	ePERM        syscall.Errno = 1
+	eEXIST       syscall.Errno = 17
+	eNOTDIR      syscall.Errno = 20
+	eISDIR       syscall.Errno = 21
+	eNOTEMPTY    syscall.Errno = 39
+	eBADF        syscall.Errno = 9
+	eBUSY        syscall.Errno = 16
+	eIO          syscall.Errno = 5
+	eNAMETOOLONG syscall.Errno = 36
+	eROFS        syscall.Errno = 30
+	eINVAL       syscall.Errno = 22
+	eNOSYS       syscall.Errno = 38
+	eINTR        syscall.Errno = 4
+)
+
+// linuxErrno returns the Linux number of errno. Our errnos are Linux's.
+func linuxErrno(errno syscall.Errno) (uint32, bool) {
+	return uint32(errno), true
+}
+
+// hostErrno returns the errno for the Linux number l, which is l.
+func hostErrno(l uint32) syscall.Errno {
+	return syscall.Errno(l)
+}
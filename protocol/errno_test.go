@@ -0,0 +1,51 @@
+// Copyright 2018 The Ninep Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !plan9
+
+package protocol
+
+import (
+	"syscall"
+	"testing"
+)
+
+// These tests use the host's errnos, which Plan 9 doesn't have.
+
+func TestToErrorHost(t *testing.T) {
+	// An errno with no Plan 9 message keeps the Unix one.
+	e := ToError(syscall.EXDEV)
+	if e.Err != syscall.EXDEV.Error() || e.Errno != syscall.EXDEV {
+		t.Errorf("ToError(EXDEV): want %q, %v, got %q, %v", syscall.EXDEV.Error(), syscall.EXDEV, e.Err, e.Errno)
+	}
+}
+
+func TestLinuxErrno(t *testing.T) {
+	// Errnos go on the wire as Linux's, whatever the host's are.
+	for _, tt := range []struct {
+		errno syscall.Errno
+		l     uint32
+	}{
+		{0, 0},
+		{syscall.ENOENT, 2},
+		{syscall.EAGAIN, 11},
+		{syscall.ENOSYS, 38},
+		{syscall.ENOTEMPTY, 39},
+		{syscall.ELOOP, 40},
+		{syscall.EOPNOTSUPP, 95},
+	} {
+		if l, ok := linuxErrno(tt.errno); !ok || l != tt.l {
+			t.Errorf("linuxErrno(%v): want %v, true, got %v, %v", tt.errno, tt.l, l, ok)
+		}
+		if errno := hostErrno(tt.l); errno != tt.errno {
+			t.Errorf("hostErrno(%v): want %v, got %v", tt.l, tt.errno, errno)
+		}
+	}
+	if l, ok := linuxErrno(syscall.Errno(12345)); ok {
+		t.Errorf("linuxErrno(12345): want false, got %v, true", l)
+	}
+	if errno := hostErrno(12345); errno != syscall.EIO {
+		t.Errorf("hostErrno(12345): want EIO, got %v", errno)
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2018 The Ninep Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protocol
+
+import (
+	"errors"
+	"io/fs"
+	"strings"
+	"syscall"
+)
+
+// An Error is an error sent by a 9P server in an Rerror. 9P2000 sends only
+// the message, Err, and 9P2000.u adds the Unix errno, so that, on either
+// side of the wire, errors.Is(err, fs.ErrNotExist) and the like work as
+// they do for an os error. A 9P2000 message is only taken to have an errno
+// if it is exactly the Plan 9 message for one, such as "file does not
+// exist", which is what a server sends for an error with that errno.
+// 9P2000.L sends only the errno, in an Rlerror, which the Client returns
+// as a syscall.Errno.
+type Error struct {
+	Err   string
+	Errno syscall.Errno
+}
+
+func (e *Error) Error() string {
+	return e.Err
+}
+
+// Unwrap returns the Error's errno, if it has one.
+func (e *Error) Unwrap() error {
+	if e.Errno == 0 {
+		return nil
+	}
+	return e.Errno
+}
+
+// errorStrings are the Plan 9 messages for errnos. The first message for
+// an errno is the one sent, and the first errno for a message is the one
+// it is read as.
+var errorStrings = []struct {
+	s     string
+	errno syscall.Errno
+}{
+	{"file does not exist", eNOENT},
+	{"permission denied", eACCES},
+	{"permission denied", ePERM},
+	{"file already exists", eEXIST},
+	{"not a directory", eNOTDIR},
+	{"is a directory", eISDIR},
+	{"directory not empty", eNOTEMPTY},
+	{"unknown fid", eBADF},
+	{"file in use", eBUSY},
+	{"i/o error", eIO},
+	{"file name too long", eNAMETOOLONG},
+	{"read-only file system", eROFS},
+	{"invalid argument", eINVAL},
+	{"not implemented", eNOSYS},
+	{"interrupted", eINTR},
+}
+
+// goErrors are the errnos for errors which are not, or do not wrap, a
+// syscall.Errno.
+var goErrors = []struct {
+	err   error
+	errno syscall.Errno
+}{
+	{fs.ErrNotExist, eNOENT},
+	{fs.ErrExist, eEXIST},
+	{fs.ErrPermission, eACCES},
+	{fs.ErrInvalid, eINVAL},
+	{fs.ErrClosed, eBADF},
+	{errors.ErrUnsupported, eNOSYS},
+}
+
+// ToError returns err as an *Error, as a server sends it. If err is, or
+// wraps, an *Error, that is it. If it is, or wraps, a syscall.Errno, or
+// one of the fs errors, such as fs.ErrNotExist, the Error has the errno.
+// Otherwise the Error has EIO. The Error has err's message, unless that
+// says no more than the errno, when it has the Plan 9 message for it.
+func ToError(err error) *Error {
+	e, _ := toError(err)
+	return e
+}
+
+// toError is ToError, and also says if the Error's errno is err's, rather
+// than EIO for want of one.
+func toError(err error) (*Error, bool) {
+	var pe *Error
+	if errors.As(err, &pe) {
+		return pe, pe.Errno != 0
+	}
+	var errno syscall.Errno
+	if errors.As(err, &errno) {
+		// An errno we can't send, such as a Windows error, may still
+		// be one of the fs errors.
+		if _, ok := linuxErrno(errno); !ok {
+			errno = 0
+		}
+	}
+	if errno == 0 {
+		for _, g := range goErrors {
+			if errors.Is(err, g.err) {
+				errno = g.errno
+				break
+			}
+		}
+	}
+	s := err.Error()
+	if errno == 0 {
+		// An errno may be all an error's message says.
+		if errno = errnoFor(s); errno == 0 {
+			return &Error{Err: s, Errno: eIO}, false
+		}
+	}
+	if errnoFor(s) == errno {
+		s = message(errno)
+	}
+	return &Error{Err: s, Errno: errno}, true
+}
+
+// message returns the Plan 9 message for errno, or the Unix one if there
+// is none.
+func message(errno syscall.Errno) string {
+	if s, ok := plan9Message(errno); ok {
+		return s
+	}
+	return errno.Error()
+}
+
+// plan9Message returns the Plan 9 message for errno, if it has one.
+func plan9Message(errno syscall.Errno) (string, bool) {
+	for _, e := range errorStrings {
+		if e.errno == errno {
+			return e.s, true
+		}
+	}
+	return "", false
+}
+
+// errnoFor returns the errno for the message s, or 0 if it has none.
+// Plan 9 and Unix messages are both understood, in any case.
+func errnoFor(s string) syscall.Errno {
+	for _, e := range errorStrings {
+		if strings.EqualFold(s, e.s) {
+			return e.errno
+		}
+	}
+	for _, e := range errorStrings {
+		if strings.EqualFold(s, e.errno.Error()) {
+			return e.errno
+		}
+	}
+	return 0
+}
+
+// newError returns the Error for an Rerror, which has an errno only for
+// 9P2000.u. Otherwise s has one only if it is a Plan 9 message, as sent.
+func newError(s string, errno syscall.Errno) *Error {
+	if errno == 0 {
+		for _, e := range errorStrings {
+			if s == e.s {
+				errno = e.errno
+				break
+			}
+		}
+	}
+	return &Error{Err: s, Errno: errno}
+}
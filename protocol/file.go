@@ -0,0 +1,385 @@
+// Copyright 2018 The Ninep Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protocol
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// MAXWELEM is the most names a Twalk may carry.
+const MAXWELEM = 16
+
+// A File is a fid on a 9P server, as seen by a Client. It is a file, or a
+// directory, which has been walked to and possibly opened, and it keeps
+// the offset used by Read, Write and Seek. A File speaks 9P2000 and
+// 9P2000.u; for 9P2000.L, use the Client's Call methods.
+//
+// A File is safe for concurrent use, though concurrent Reads, Writes and
+// Seeks race for the offset, as they do on an os.File.
+type File struct {
+	c      *Client
+	fid    FID
+	qid    QID
+	iounit MaxSize
+
+	// mu guards below
+	mu     sync.Mutex
+	offset int64
+}
+
+// Attach attaches to the tree aname as uname, and returns the File for
+// its root. afid is an auth fid, or NOFID if the server does not need
+// authentication.
+func (c *Client) Attach(afid FID, uname, aname string) (*File, error) {
+	return c.AttachContext(context.Background(), afid, uname, aname)
+}
+
+// AttachContext is Attach, which flushes the Tattach if ctx is done before
+// the reply comes.
+func (c *Client) AttachContext(ctx context.Context, afid FID, uname, aname string) (*File, error) {
+	var (
+		fid = c.GetFID()
+		q   QID
+		err error
+	)
+	// 9P2000.L uses the 9P2000.u Tattach.
+	if v := c.Version(); v == Version9P2000u || v == Version9P2000L {
+		q, err = c.CallTattachDotuContext(ctx, fid, afid, uname, aname, NOUID)
+	} else {
+		q, err = c.CallTattachContext(ctx, fid, afid, uname, aname)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &File{c: c, fid: fid, qid: q}, nil
+}
+
+// FID returns the File's fid.
+func (f *File) FID() FID {
+	return f.fid
+}
+
+// QID returns the File's QID.
+func (f *File) QID() QID {
+	return f.qid
+}
+
+// Walk walks to name, a slash-separated path relative to f, and returns
+// the File for it. An empty name, or ".", gives a new File for the same
+// file as f.
+func (f *File) Walk(name string) (*File, error) {
+	var names []string
+	for _, n := range strings.Split(name, "/") {
+		if n != "" && n != "." {
+			names = append(names, n)
+		}
+	}
+	fid := f.c.GetFID()
+	nf := &File{c: f.c, fid: fid, qid: f.qid}
+	// A Twalk carries at most MAXWELEM names, so long paths take
+	// several, the first from f and the rest from the new fid.
+	from := f.fid
+	for {
+		n := names
+		if len(n) > MAXWELEM {
+			n = n[:MAXWELEM]
+		}
+		q, err := f.c.CallTwalk(from, fid, n)
+		if err == nil && len(q) < len(n) {
+			err = &os.PathError{Op: "walk", Path: name, Err: os.ErrNotExist}
+		}
+		if err != nil {
+			// Only a complete walk makes the new fid.
+			if from == fid {
+				nf.Close()
+			}
+			return nil, err
+		}
+		if len(q) > 0 {
+			nf.qid = q[len(q)-1]
+		}
+		from, names = fid, names[len(n):]
+		if len(names) == 0 {
+			return nf, nil
+		}
+	}
+}
+
+// notDotl returns an error for the File method op when the client speaks
+// 9P2000.L, which does not have the 9P2000 message op would send.
+func (f *File) notDotl(op string) error {
+	if f.c.Version() != Version9P2000L {
+		return nil
+	}
+	return fmt.Errorf("%v: not in %v: %w", op, Version9P2000L, eNOSYS)
+}
+
+// Open walks to name, and opens it with mode, which is OREAD, OWRITE,
+// ORDWR or OEXEC, possibly or'ed with OTRUNC or ORCLOSE. It is not
+// available in 9P2000.L.
+func (f *File) Open(name string, mode Mode) (*File, error) {
+	if err := f.notDotl("open"); err != nil {
+		return nil, err
+	}
+	nf, err := f.Walk(name)
+	if err != nil {
+		return nil, err
+	}
+	q, iounit, err := f.c.CallTopen(nf.fid, mode)
+	if err != nil {
+		nf.Close()
+		return nil, err
+	}
+	nf.qid, nf.iounit = q, iounit
+	return nf, nil
+}
+
+// Create creates name in the directory f with perm, and returns it
+// opened with mode. It is not available in 9P2000.L.
+func (f *File) Create(name string, perm Perm, mode Mode) (*File, error) {
+	if err := f.notDotl("create"); err != nil {
+		return nil, err
+	}
+	nf, err := f.Walk("")
+	if err != nil {
+		return nil, err
+	}
+	var (
+		q      QID
+		iounit MaxSize
+	)
+	if f.c.Version() == Version9P2000u {
+		q, iounit, err = f.c.CallTcreateDotu(nf.fid, name, perm, mode, "")
+	} else {
+		q, iounit, err = f.c.CallTcreate(nf.fid, name, perm, mode)
+	}
+	if err != nil {
+		nf.Close()
+		return nil, err
+	}
+	nf.qid, nf.iounit = q, iounit
+	return nf, nil
+}
+
+// Stat returns the File's Dir. It is not available in 9P2000.L.
+func (f *File) Stat() (Dir, error) {
+	if err := f.notDotl("stat"); err != nil {
+		return Dir{}, err
+	}
+	b, err := f.c.CallTstat(f.fid)
+	if err != nil {
+		return Dir{}, err
+	}
+	if f.c.Version() == Version9P2000u {
+		return UnmarshaldirDotu(bytes.NewBuffer(b))
+	}
+	return Unmarshaldir(bytes.NewBuffer(b))
+}
+
+// Wstat changes the File's Dir to d. Fields which are to be left alone
+// must have their "don't touch" values, so start from NullDir. It is not
+// available in 9P2000.L.
+func (f *File) Wstat(d Dir) error {
+	if err := f.notDotl("wstat"); err != nil {
+		return err
+	}
+	var b bytes.Buffer
+	if f.c.Version() == Version9P2000u {
+		MarshaldirDotu(&b, d)
+	} else {
+		Marshaldir(&b, d)
+	}
+	return f.c.CallTwstat(f.fid, b.Bytes())
+}
+
+// Remove removes the file, and clunks the File whether or not the remove
+// succeeds.
+func (f *File) Remove() error {
+	return f.c.CallTremove(f.fid)
+}
+
+// Close clunks the File.
+func (f *File) Close() error {
+	return f.c.CallTclunk(f.fid)
+}
+
+// maxIO returns the most bytes a single Tread or Twrite of the File may
+// carry.
+func (f *File) maxIO() int {
+	m := int(f.c.msize()) - IOHDRSZ
+	if f.iounit != 0 && int(f.iounit) < m {
+		m = int(f.iounit)
+	}
+	return m
+}
+
+// read reads at most one message's worth of data at off.
+func (f *File) read(b []byte, off int64) (int, error) {
+	if m := f.maxIO(); len(b) > m {
+		b = b[:m]
+	}
+	d, err := f.c.CallTread(f.fid, Offset(off), Count(len(b)))
+	if err != nil {
+		return 0, err
+	}
+	if len(d) == 0 && len(b) > 0 {
+		return 0, io.EOF
+	}
+	return copy(b, d), nil
+}
+
+// write writes at most one message's worth of data at off.
+func (f *File) write(b []byte, off int64) (int, error) {
+	if m := f.maxIO(); len(b) > m {
+		b = b[:m]
+	}
+	n, err := f.c.CallTwrite(f.fid, Offset(off), b)
+	if err != nil {
+		return 0, err
+	}
+	if int(n) > len(b) {
+		return 0, fmt.Errorf("Rwrite count %d > %d bytes written", n, len(b))
+	}
+	return int(n), nil
+}
+
+// Read reads from the File at its offset, with one Tread.
+func (f *File) Read(b []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n, err := f.read(b, f.offset)
+	f.offset += int64(n)
+	return n, err
+}
+
+// ReadAt reads len(b) bytes from the File at off, with as many Treads as
+// it takes.
+func (f *File) ReadAt(b []byte, off int64) (int, error) {
+	var tot int
+	for tot < len(b) {
+		n, err := f.read(b[tot:], off+int64(tot))
+		tot += n
+		if err != nil {
+			return tot, err
+		}
+	}
+	return tot, nil
+}
+
+// Write writes b to the File at its offset.
+func (f *File) Write(b []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n, err := f.WriteAt(b, f.offset)
+	f.offset += int64(n)
+	return n, err
+}
+
+// WriteAt writes b to the File at off, with as many Twrites as it takes.
+func (f *File) WriteAt(b []byte, off int64) (int, error) {
+	var tot int
+	for tot < len(b) {
+		n, err := f.write(b[tot:], off+int64(tot))
+		tot += n
+		if err != nil {
+			return tot, err
+		}
+		if n == 0 {
+			return tot, io.ErrShortWrite
+		}
+	}
+	return tot, nil
+}
+
+// ReadAll reads from the File's offset to the end of the file, in Treads
+// as big as the iounit allows. It returns what it has read, even if it
+// fails part way.
+func (f *File) ReadAll() ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	m := f.maxIO()
+	var b []byte
+	for {
+		if cap(b)-len(b) < m {
+			nb := make([]byte, len(b), 2*cap(b)+m)
+			copy(nb, b)
+			b = nb
+		}
+		n, err := f.read(b[len(b):len(b)+m], f.offset)
+		b = b[:len(b)+n]
+		f.offset += int64(n)
+		if err == io.EOF {
+			return b, nil
+		}
+		if err != nil {
+			return b, err
+		}
+	}
+}
+
+// WriteFrom writes what it reads from r, until r's EOF, to the File at
+// its offset, in Twrites as big as the iounit allows. It returns the
+// number of bytes written, even if it fails part way.
+func (f *File) WriteFrom(r io.Reader) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	b := make([]byte, f.maxIO())
+	var tot int64
+	for {
+		// Fill the buffer, so a Reader which returns a little at a
+		// time doesn't make for lots of little Twrites.
+		n, rerr := io.ReadFull(r, b)
+		if n > 0 {
+			w, err := f.WriteAt(b[:n], f.offset)
+			f.offset += int64(w)
+			tot += int64(w)
+			if err != nil {
+				return tot, err
+			}
+		}
+		if rerr == io.EOF || rerr == io.ErrUnexpectedEOF {
+			return tot, nil
+		}
+		if rerr != nil {
+			return tot, rerr
+		}
+	}
+}
+
+// ReadFrom is WriteFrom, so that io.Copy to a File uses it.
+func (f *File) ReadFrom(r io.Reader) (int64, error) {
+	return f.WriteFrom(r)
+}
+
+// Seek sets the offset for the next Read or Write. Seeking relative to
+// the end stats the file for its length.
+func (f *File) Seek(offset int64, whence int) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		d, err := f.Stat()
+		if err != nil {
+			return f.offset, err
+		}
+		offset += int64(d.Length)
+	default:
+		return f.offset, fmt.Errorf("Seek: bad whence %d", whence)
+	}
+	if offset < 0 {
+		return f.offset, fmt.Errorf("Seek: negative offset %d", offset)
+	}
+	f.offset = offset
+	return offset, nil
+}
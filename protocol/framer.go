@@ -0,0 +1,112 @@
+// Copyright 2018 The Ninep Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protocol
+
+import (
+	"fmt"
+	"io"
+	"math/bits"
+	"sync"
+)
+
+// A Framer reads and writes 9P messages, or frames, on a stream. A frame
+// starts with its size, in 4 bytes, which counts itself, then its type and
+// tag. The Client and Server both use one.
+//
+// The frames ReadFrame returns come from a pool of buffers, which saves
+// allocating one for each message; a frame which is done with can be
+// given back with Release.
+type Framer struct {
+	r   io.Reader
+	hdr [7]byte
+
+	// wmu serializes writes.
+	wmu sync.Mutex
+	w   io.Writer
+}
+
+// NewFramer returns a Framer which reads frames from r and writes them to
+// w.
+func NewFramer(r io.Reader, w io.Writer) *Framer {
+	return &Framer{r: r, w: w}
+}
+
+// ReadFrame reads the next frame, which must be at most max bytes. It reads
+// the whole frame, however the stream splits it up. An error leaves the
+// stream at an unknown place, so nothing more can be read. ReadFrame must
+// not be called concurrently.
+func (f *Framer) ReadFrame(max MaxSize) ([]byte, error) {
+	if _, err := io.ReadFull(f.r, f.hdr[:]); err != nil {
+		return nil, err
+	}
+	sz := MaxSize(f.hdr[0]) | MaxSize(f.hdr[1])<<8 | MaxSize(f.hdr[2])<<16 | MaxSize(f.hdr[3])<<24
+	if sz < MaxSize(len(f.hdr)) || sz > max {
+		return nil, fmt.Errorf("%v is %d bytes; msize is %d", RPCNames[MType(f.hdr[4])], sz, max)
+	}
+	b := getFrame(int(sz))
+	copy(b, f.hdr[:])
+	if _, err := io.ReadFull(f.r, b[len(f.hdr):]); err != nil {
+		putFrame(b)
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, fmt.Errorf("short %v: %v", RPCNames[MType(f.hdr[4])], err)
+	}
+	return b, nil
+}
+
+// WriteFrame writes the frame b. It may be called concurrently, and the
+// frames are not interleaved.
+func (f *Framer) WriteFrame(b []byte) error {
+	f.wmu.Lock()
+	defer f.wmu.Unlock()
+	_, err := f.w.Write(b)
+	return err
+}
+
+// Release gives the buffer of a frame from ReadFrame back to the pool. The
+// frame, and anything decoded from it which refers to it, such as the Data
+// of an Rread, must not be used afterwards.
+func (f *Framer) Release(b []byte) {
+	putFrame(b)
+}
+
+// Frame buffers are pooled in size classes, which are the powers of two
+// from 1<<minFrameShift to 1<<maxFrameShift, which is more than MSIZE.
+const (
+	minFrameShift = 7
+	maxFrameShift = 22
+)
+
+var framePools [maxFrameShift - minFrameShift + 1]sync.Pool
+
+// getFrame returns a buffer of n bytes, from the pool for the smallest
+// class which holds it.
+func getFrame(n int) []byte {
+	i := 0
+	if n > 1<<minFrameShift {
+		i = bits.Len(uint(n-1)) - minFrameShift
+	}
+	if i >= len(framePools) {
+		return make([]byte, n)
+	}
+	if p, ok := framePools[i].Get().(*[]byte); ok {
+		return (*p)[:n]
+	}
+	return make([]byte, n, 1<<(i+minFrameShift))
+}
+
+// putFrame puts b in the pool for the largest class it holds.
+func putFrame(b []byte) {
+	if cap(b) < 1<<minFrameShift {
+		return
+	}
+	i := bits.Len(uint(cap(b))) - 1 - minFrameShift
+	if i >= len(framePools) {
+		return
+	}
+	b = b[:0]
+	framePools[i].Put(&b)
+}
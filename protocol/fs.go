@@ -0,0 +1,201 @@
+// Copyright 2018 The Ninep Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protocol
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"sync"
+)
+
+// FS is the tree under a File, as an fs.FS. It also implements
+// fs.ReadDirFS, fs.StatFS and fs.ReadFileFS, so that a remote tree can be
+// used with fs.WalkDir, http.FS and the like.
+type FS struct {
+	root *File
+}
+
+// NewFS returns an FS for the tree under root, which is typically the
+// File returned by Client.Attach. Closing the FS's files does not close
+// root.
+func NewFS(root *File) *FS {
+	return &FS{root: root}
+}
+
+// walk walks to name, checking that it is a valid fs.FS path.
+func (fsys *FS) walk(op, name string) (*File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	f, err := fsys.root.Walk(name)
+	if err != nil {
+		return nil, pathError(op, name, err)
+	}
+	return f, nil
+}
+
+// pathError returns err as an *fs.PathError for name.
+func pathError(op, name string, err error) error {
+	var pe *fs.PathError
+	if errors.As(err, &pe) {
+		err = pe.Err
+	}
+	return &fs.PathError{Op: op, Path: name, Err: err}
+}
+
+// Open opens name for reading.
+func (fsys *FS) Open(name string) (fs.File, error) {
+	f, err := fsys.walk("open", name)
+	if err != nil {
+		return nil, err
+	}
+	q, iounit, err := f.c.CallTopen(f.fid, OREAD)
+	if err != nil {
+		f.Close()
+		return nil, pathError("open", name, err)
+	}
+	f.qid, f.iounit = q, iounit
+	if q.Type&QTDIR != 0 {
+		return &fsDir{f: f, name: name}, nil
+	}
+	return &fsFile{f: f, name: name}, nil
+}
+
+// Stat returns the fs.FileInfo for name.
+func (fsys *FS) Stat(name string) (fs.FileInfo, error) {
+	f, err := fsys.walk("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return stat(f, name)
+}
+
+// ReadDir returns the entries of the directory name, sorted by name.
+func (fsys *FS) ReadDir(name string) ([]fs.DirEntry, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	d, ok := f.(fs.ReadDirFile)
+	if !ok {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: errors.New("not a directory")}
+	}
+	ents, err := d.ReadDir(-1)
+	sort.Slice(ents, func(i, j int) bool { return ents[i].Name() < ents[j].Name() })
+	return ents, err
+}
+
+// ReadFile returns the contents of name.
+func (fsys *FS) ReadFile(name string) ([]byte, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	b, err := io.ReadAll(f)
+	if err != nil {
+		return nil, pathError("read", name, err)
+	}
+	return b, nil
+}
+
+func stat(f *File, name string) (fs.FileInfo, error) {
+	d, err := f.Stat()
+	if err != nil {
+		return nil, pathError("stat", name, err)
+	}
+	return &dirInfo{d: d, name: path.Base(name)}, nil
+}
+
+// fsFile is an open file in an FS.
+type fsFile struct {
+	f    *File
+	name string
+}
+
+func (f *fsFile) Stat() (fs.FileInfo, error) {
+	return stat(f.f, f.name)
+}
+
+func (f *fsFile) Read(b []byte) (int, error) {
+	return f.f.Read(b)
+}
+
+func (f *fsFile) ReadAt(b []byte, off int64) (int, error) {
+	return f.f.ReadAt(b, off)
+}
+
+func (f *fsFile) Seek(offset int64, whence int) (int64, error) {
+	return f.f.Seek(offset, whence)
+}
+
+func (f *fsFile) Close() error {
+	return f.f.Close()
+}
+
+// fsDir is an open directory in an FS.
+type fsDir struct {
+	f    *File
+	name string
+
+	// mu guards below
+	mu sync.Mutex
+	// ents are the entries read but not yet returned.
+	ents []Dir
+	eof  bool
+}
+
+func (d *fsDir) Stat() (fs.FileInfo, error) {
+	return stat(d.f, d.name)
+}
+
+func (d *fsDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.name, Err: errors.New("is a directory")}
+}
+
+func (d *fsDir) Close() error {
+	return d.f.Close()
+}
+
+// ReadDir returns the next n entries, or all of them if n <= 0, as
+// fs.ReadDirFile requires.
+func (d *fsDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var ents []fs.DirEntry
+	for n <= 0 || len(ents) < n {
+		if len(d.ents) == 0 {
+			if d.eof {
+				break
+			}
+			if err := d.fill(); err != nil {
+				return ents, pathError("readdir", d.name, err)
+			}
+			continue
+		}
+		ents = append(ents, fs.FileInfoToDirEntry(d.ents[0].FileInfo()))
+		d.ents = d.ents[1:]
+	}
+	if n > 0 && len(ents) == 0 {
+		return nil, io.EOF
+	}
+	return ents, nil
+}
+
+// fill reads the next entries from the server.
+func (d *fsDir) fill() error {
+	ents, err := d.f.readdir()
+	if err == io.EOF {
+		d.eof = true
+		return nil
+	}
+	d.ents = append(d.ents, ents...)
+	return err
+}
@@ -20,6 +20,18 @@
 // uses the  UnmarshalT* and MarshalR* information.
 // Hence the caller needs the call MarshalT params, and UnmarshalR* returns;
 // a dispatcher needs the UnmarshalT returns, and the MarshalR params.
+//
+// The work is done on byte slices: AppendTwalkPkt appends a Twalk to a []byte,
+// and DecodeTwalkPkt decodes one. Decoding allocates nothing for the numbers
+// and QIDs, and byte slices refer to the packet, but each string, and each
+// other slice, is allocated: DecodeTversionPkt allocates its version, and
+// DecodeTwalkPkt its names and the slice of them. MarshalTwalkPkt and
+// UnmarshalTwalkPkt wrap them for a bytes.Buffer, which is what the Server
+// uses. The struct for each message, such as TwalkPkt, is made a Msg, with
+// methods which call them. A benchmark of each message's codecs, and a
+// sample of each Msg, go in genout_test.go. The benchmarks compare them with
+// the bytes.Buffer codecs gen made before, which are kept in
+// genbuffer_test.go.
 package main
 
 import (
@@ -30,6 +42,7 @@ import (
 	"io/ioutil"
 	"log"
 	"reflect"
+	"strings"
 	"text/template"
 
 	"github.com/Harvey-OS/ninep/protocol"
@@ -40,6 +53,8 @@ const (
 package protocol
 import (
 "bytes"
+"context"
+"encoding/binary"
 "fmt"
 _ "log"
 )
@@ -61,11 +76,52 @@ type emitter struct {
 	UCode    *bytes.Buffer
 	URet     *bytes.Buffer
 	inBWrite bool
+
+	// dotu is set when the 9P2000.u extension fields are to be included.
+	dotu bool
+
+	// ULen is set if the decoder needs l, for the length of a string or
+	// slice.
+	ULen bool
 }
 
 type call struct {
 	T *emitter
 	R *emitter
+	// Srv is the name of the server stub and NineServerContext method,
+	// and NS the expression which yields the server implementing it.
+	Srv string
+	NS  string
+	// RFields are the fields of the reply struct the client's Go
+	// methods return, and RKeys the keyed fields for a literal of it.
+	RFields string
+	RKeys   string
+	// Free is set if nothing decoded from the reply refers to it, so
+	// its frame can go back to the pool.
+	Free bool
+}
+
+// bench is a benchmark of the codecs of a message. Vars declares a
+// sample of each of its fields, and Blanks has a blank for each.
+type bench struct {
+	*emitter
+	Vars   string
+	Blanks string
+}
+
+// msg is the methods which make a message's struct a Msg. Struct is the
+// name of the struct, Args its fields as arguments for the encoder, Vars
+// the variables the decoder returns them in, and Keys the keyed fields for
+// a literal of it. Format and FArgs make its String.
+type msg struct {
+	*emitter
+	Struct  string
+	Args    string
+	Vars    string
+	Keys    string
+	Format  string
+	FArgs   string
+	HasDotu bool
 }
 
 type pack struct {
@@ -74,9 +130,22 @@ type pack struct {
 	tn string
 	r  interface{}
 	rn string
+	// manual is set when the server stub is written by hand.
+	manual bool
+	// ns is the interface the server stub calls, if not
+	// NineServerContext.
+	ns string
 }
 
 const (
+	testHeader = `
+package protocol
+import (
+"bytes"
+"fmt"
+"testing"
+)
+`
 	serverError = `func ServerError (b *bytes.Buffer, s string) {
 	var u [8]byte
 	// This can't really happen. 
@@ -94,9 +163,10 @@ var (
 	debug    = nodebug //log.Printf
 	packages = []*pack{
 		{n: "error", t: protocol.RerrorPkt{}, tn: "Rerror", r: protocol.RerrorPkt{}, rn: "Rerror"},
-		{n: "version", t: protocol.TversionPkt{}, tn: "Tversion", r: protocol.RversionPkt{}, rn: "Rversion"},
+		{n: "version", t: protocol.TversionPkt{}, tn: "Tversion", r: protocol.RversionPkt{}, rn: "Rversion", manual: true},
+		{n: "auth", t: protocol.TauthPkt{}, tn: "Tauth", r: protocol.RauthPkt{}, rn: "Rauth", manual: true},
 		{n: "attach", t: protocol.TattachPkt{}, tn: "Tattach", r: protocol.RattachPkt{}, rn: "Rattach"},
-		{n: "flush", t: protocol.TflushPkt{}, tn: "Tflush", r: protocol.RflushPkt{}, rn: "Rflush"},
+		{n: "flush", t: protocol.TflushPkt{}, tn: "Tflush", r: protocol.RflushPkt{}, rn: "Rflush", manual: true},
 		{n: "walk", t: protocol.TwalkPkt{}, tn: "Twalk", r: protocol.RwalkPkt{}, rn: "Rwalk"},
 		{n: "open", t: protocol.TopenPkt{}, tn: "Topen", r: protocol.RopenPkt{}, rn: "Ropen"},
 		{n: "create", t: protocol.TcreatePkt{}, tn: "Tcreate", r: protocol.RcreatePkt{}, rn: "Rcreate"},
@@ -104,110 +174,337 @@ var (
 		{n: "wstat", t: protocol.TwstatPkt{}, tn: "Twstat", r: protocol.RwstatPkt{}, rn: "Rwstat"},
 		{n: "clunk", t: protocol.TclunkPkt{}, tn: "Tclunk", r: protocol.RclunkPkt{}, rn: "Rclunk"},
 		{n: "remove", t: protocol.TremovePkt{}, tn: "Tremove", r: protocol.RremovePkt{}, rn: "Rremove"},
-		{n: "read", t: protocol.TreadPkt{}, tn: "Tread", r: protocol.RreadPkt{}, rn: "Rread"},
-		{n: "write", t: protocol.TwritePkt{}, tn: "Twrite", r: protocol.RwritePkt{}, rn: "Rwrite"},
-	}
-	msfunc = template.Must(template.New("ms").Parse(`func Marshal{{.MFunc}} (b *bytes.Buffer, {{.MParms}}) {
-var l uint64
-b.Reset()
-b.Write([]byte{0,0,})
+		{n: "read", t: protocol.TreadPkt{}, tn: "Tread", r: protocol.RreadPkt{}, rn: "Rread", manual: true},
+		{n: "write", t: protocol.TwritePkt{}, tn: "Twrite", r: protocol.RwritePkt{}, rn: "Rwrite", manual: true},
+
+		// 9P2000.L
+		{n: "lerror", t: protocol.RlerrorPkt{}, tn: "Rlerror", r: protocol.RlerrorPkt{}, rn: "Rlerror"},
+		{n: "statfs", t: protocol.TstatfsPkt{}, tn: "Tstatfs", r: protocol.RstatfsPkt{}, rn: "Rstatfs", ns: "NineServerLContext"},
+		{n: "lopen", t: protocol.TlopenPkt{}, tn: "Tlopen", r: protocol.RlopenPkt{}, rn: "Rlopen", ns: "NineServerLContext"},
+		{n: "lcreate", t: protocol.TlcreatePkt{}, tn: "Tlcreate", r: protocol.RlcreatePkt{}, rn: "Rlcreate", ns: "NineServerLContext"},
+		{n: "symlink", t: protocol.TsymlinkPkt{}, tn: "Tsymlink", r: protocol.RsymlinkPkt{}, rn: "Rsymlink", ns: "NineServerLContext"},
+		{n: "mknod", t: protocol.TmknodPkt{}, tn: "Tmknod", r: protocol.RmknodPkt{}, rn: "Rmknod", ns: "NineServerLContext"},
+		{n: "rename", t: protocol.TrenamePkt{}, tn: "Trename", r: protocol.RrenamePkt{}, rn: "Rrename", ns: "NineServerLContext"},
+		{n: "readlink", t: protocol.TreadlinkPkt{}, tn: "Treadlink", r: protocol.RreadlinkPkt{}, rn: "Rreadlink", ns: "NineServerLContext"},
+		{n: "getattr", t: protocol.TgetattrPkt{}, tn: "Tgetattr", r: protocol.RgetattrPkt{}, rn: "Rgetattr", ns: "NineServerLContext"},
+		{n: "setattr", t: protocol.TsetattrPkt{}, tn: "Tsetattr", r: protocol.RsetattrPkt{}, rn: "Rsetattr", ns: "NineServerLContext"},
+		{n: "xattrwalk", t: protocol.TxattrwalkPkt{}, tn: "Txattrwalk", r: protocol.RxattrwalkPkt{}, rn: "Rxattrwalk", ns: "NineServerLContext"},
+		{n: "xattrcreate", t: protocol.TxattrcreatePkt{}, tn: "Txattrcreate", r: protocol.RxattrcreatePkt{}, rn: "Rxattrcreate", ns: "NineServerLContext"},
+		{n: "readdir", t: protocol.TreaddirPkt{}, tn: "Treaddir", r: protocol.RreaddirPkt{}, rn: "Rreaddir", ns: "NineServerLContext"},
+		{n: "fsync", t: protocol.TfsyncPkt{}, tn: "Tfsync", r: protocol.RfsyncPkt{}, rn: "Rfsync", ns: "NineServerLContext"},
+		{n: "lock", t: protocol.TlockPkt{}, tn: "Tlock", r: protocol.RlockPkt{}, rn: "Rlock", ns: "NineServerLContext"},
+		{n: "getlock", t: protocol.TgetlockPkt{}, tn: "Tgetlock", r: protocol.RgetlockPkt{}, rn: "Rgetlock", ns: "NineServerLContext"},
+		{n: "link", t: protocol.TlinkPkt{}, tn: "Tlink", r: protocol.RlinkPkt{}, rn: "Rlink", ns: "NineServerLContext"},
+		{n: "mkdir", t: protocol.TmkdirPkt{}, tn: "Tmkdir", r: protocol.RmkdirPkt{}, rn: "Rmkdir", ns: "NineServerLContext"},
+		{n: "renameat", t: protocol.TrenameatPkt{}, tn: "Trenameat", r: protocol.RrenameatPkt{}, rn: "Rrenameat", ns: "NineServerLContext"},
+		{n: "unlinkat", t: protocol.TunlinkatPkt{}, tn: "Tunlinkat", r: protocol.RunlinkatPkt{}, rn: "Runlinkat", ns: "NineServerLContext"},
+	}
+	msfunc = template.Must(template.New("ms").Parse(`// Append{{.MFunc}} appends the encoding of a {{.Name}}, with its size, to b.
+func Append{{.MFunc}} (b []byte, {{.MParms}}) []byte {
+o := len(b)
+b = append(b, 0, 0)
 {{.MCode}}
-l = uint64(b.Len()) - 2
-copy(b.Bytes(), []byte{uint8(l), uint8(l>>8)})
-return
+binary.LittleEndian.PutUint16(b[o:], uint16(len(b)-o-2))
+return b
+}
+
+func Marshal{{.MFunc}} (b *bytes.Buffer, {{.MParms}}) {
+b.Reset()
+*b = *bytes.NewBuffer(Append{{.MFunc}}(b.Bytes(), {{.MList}}))
 }
 `))
-	usfunc = template.Must(template.New("us").Parse(`func Unmarshal{{.UFunc}} (b *bytes.Buffer) ({{.URet}} err error) {
-var u [8]uint8
-var l uint64
-_ = b.Next(2) // eat the length too
+	usfunc = template.Must(template.New("us").Parse(`// Decode{{.UFunc}} decodes a {{.Name}} from the front of b, which starts with its size.
+func Decode{{.UFunc}} (b []byte) ({{.URet}} err error) {
+{{if .ULen}}var l uint64
+{{end}}if len(b) < 2 {
+err = fmt.Errorf("pkt too short for size: need 2, have %d", len(b))
+return
+}
+b = b[2:]
 {{.UCode}}
 return
 }
-`))
 
-	mfunc = template.Must(template.New("mt").Parse(`func Marshal{{.MFunc}}Pkt (b *bytes.Buffer, t Tag, {{.MParms}}) {
-var l uint64
+func Unmarshal{{.UFunc}} (b *bytes.Buffer) ({{.URet}} err error) {
+{{.UList}} err = Decode{{.UFunc}}(b.Bytes())
 b.Reset()
-b.Write([]byte{0,0,0,0,
+return
+}
+`))
+
+	mfunc = template.Must(template.New("mt").Parse(`// Append{{.MFunc}}Pkt appends a {{.Name}} with tag t, from its size on, to b.
+func Append{{.MFunc}}Pkt (b []byte, t Tag, {{.MParms}}) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
 uint8({{.Name}}),
 byte(t), byte(t>>8),
 {{.MCode}}
-{
-l = uint64(b.Len())
-copy(b.Bytes(), []byte{uint8(l), uint8(l>>8), uint8(l>>16), uint8(l>>24)})
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
 }
-return
+
+func Marshal{{.MFunc}}Pkt (b *bytes.Buffer, t Tag, {{.MParms}}) {
+b.Reset()
+*b = *bytes.NewBuffer(Append{{.MFunc}}Pkt(b.Bytes(), t, {{.MList}}))
 }
 `))
-	ufunc = template.Must(template.New("mr").Parse(`func Unmarshal{{.UFunc}}Pkt (b *bytes.Buffer) ({{.URet}} t Tag, err error) {
-var u [8]uint8
-var l uint64
-if _, err = b.Read(u[:2]); err != nil {
-err = fmt.Errorf("pkt too short for Tag; need 2, have %d", b.Len())
+	ufunc = template.Must(template.New("mr").Parse(`// Decode{{.UFunc}}Pkt decodes a {{.Name}} from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func Decode{{.UFunc}}Pkt (b []byte) ({{.URet}} t Tag, err error) {
+{{if .ULen}}var l uint64
+{{end}}if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
 return
 }
-l = uint64(u[0]) | uint64(u[1])<<8
-t = Tag(l)
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
 {{.UCode}}
-if b.Len() > 0 {
-err = fmt.Errorf("Packet too long: %d bytes left over after decode", b.Len())
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
 }
+
+func Unmarshal{{.UFunc}}Pkt (b *bytes.Buffer) ({{.URet}} t Tag, err error) {
+{{.UList}} t, err = Decode{{.UFunc}}Pkt(b.Bytes())
+b.Reset()
 return
 }
 `))
-	sfunc = template.Must(template.New("s").Parse(`func (s *Server) Srv{{.R.UFunc}}(b*bytes.Buffer) (err error) {
+	sfunc = template.Must(template.New("s").Parse(`func (s *Server) Srv{{.Srv}}(ctx context.Context, b*bytes.Buffer) (err error) {
 	{{.T.MList}}{{.T.MLsep}} t, err := Unmarshal{{.T.MFunc}}Pkt(b)
 	//if err != nil {
 	//}
-	if {{.R.MList}}{{.R.MLsep}} err := s.NS.{{.R.MFunc}}({{.T.MList}}); err != nil {
-	MarshalRerrorPkt(b, t, fmt.Sprintf("%v", err))
+	if {{.R.MList}}{{.R.MLsep}} err := {{.NS}}.{{.Srv}}(ctx, {{.T.MList}}); err != nil {
+	s.rerror(ctx, b, t, err)
 } else {
 	Marshal{{.R.MFunc}}Pkt(b, t, {{.R.MList}})
 }
 	return nil
 }
+`))
+	msgfunc = template.Must(template.New("msg").Parse(`
+func ({{.Struct}}) Type() MType {
+	return {{.Name}}
+}
+
+// Encode appends m, with tag t, to b.
+func (m {{.Struct}}) Encode(b []byte, t Tag) []byte {
+	return Append{{.MFunc}}Pkt(b, t, {{.Args}})
+}
+
+// Decode decodes m from b, which holds all of a {{.Name}}, and returns its tag.
+func (m *{{.Struct}}) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, {{.Name}}); err != nil {
+		return 0, err
+	}
+	{{.Vars}}t, err := Decode{{.UFunc}}Pkt(b[5:])
+	if err != nil {
+		{{if .HasDotu}}if t, derr := m.DecodeDotu(b); derr == nil {
+			return t, nil
+		}
+		{{end}}return t, err
+	}
+	*m = {{.Struct}}{ {{.Keys}} }
+	return t, nil
+}
+
+func (m {{.Struct}}) String() string {
+	return fmt.Sprintf("{{.Name}}{{.Format}}"{{.FArgs}})
+}
+`))
+	msgdotufunc = template.Must(template.New("msgdotu").Parse(`
+// EncodeDotu appends m, with tag t, to b, with its 9P2000.u fields.
+func (m {{.Struct}}) EncodeDotu(b []byte, t Tag) []byte {
+	return Append{{.MFunc}}Pkt(b, t, {{.Args}})
+}
+
+// DecodeDotu is Decode for a {{.Name}} with its 9P2000.u fields.
+func (m *{{.Struct}}) DecodeDotu(b []byte) (Tag, error) {
+	if err := checkMsg(b, {{.Name}}); err != nil {
+		return 0, err
+	}
+	{{.Vars}}t, err := Decode{{.UFunc}}Pkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = {{.Struct}}{ {{.Keys}} }
+	return t, nil
+}
+`))
+	bfunc = template.Must(template.New("b").Parse(`
+func Benchmark{{.MFunc}}(b *testing.B) {
+{{.Vars}}	var m bytes.Buffer
+	Marshal{{.MFunc}}Pkt(&m, 1, {{.MList}})
+	pkt := m.Bytes()
+	want := fmt.Sprint({{.MList}}{{.MLsep}}Tag(1), error(nil))
+	if got := fmt.Sprint(Decode{{.UFunc}}Pkt(pkt[5:])); got != want {
+		b.Fatalf("Decode{{.UFunc}}Pkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshal{{.UFunc}}Pkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshal{{.UFunc}}Pkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshal{{.MFunc}}Pkt(&bb, 1, {{.MList}})
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			Marshal{{.MFunc}}Pkt(&bb, 1, {{.MList}})
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = Append{{.MFunc}}Pkt(bb[:0], 1, {{.MList}})
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if {{.Blanks}}_, err := bufferUnmarshal{{.UFunc}}Pkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if {{.Blanks}}_, err := Unmarshal{{.UFunc}}Pkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if {{.Blanks}}_, err := Decode{{.UFunc}}Pkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
 `))
 	cfunc = template.Must(template.New("s").Parse(`
 func (c *Client)Call{{.T.MFunc}} ({{.T.MParms}}) ({{.R.URet}} err error) {
-var b = bytes.Buffer{}
-if c.Trace != nil {c.Trace("%v", {{.T.MFunc}})}
+return c.Call{{.T.MFunc}}Context(context.Background(), {{.T.MList}})
+}
+
+func (c *Client)Call{{.T.MFunc}}Context (ctx context.Context, {{.T.MParms}}) ({{.R.URet}} err error) {
+if c.Trace != nil {c.Trace("%v", {{.T.Name}})}
 t := Tag(0)
-r := make (chan []byte)
 if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
-Marshal{{.T.MFunc}}Pkt(&b, t, {{.T.MList}})
-c.FromClient <- &RPCCall{b: b.Bytes(), Reply: r}
-bb := <-r
-if MType(bb[4]) == Rerror {
-	s, _, err := UnmarshalRerrorPkt(bytes.NewBuffer(bb[5:]))
-	if err != nil {
-		return {{.R.UList}} err
-	}
-	return {{.R.UList}} fmt.Errorf("%v", s)
-} else {
-	{{.R.MList}}{{.R.MLsep}} _, err = Unmarshal{{.R.UFunc}}Pkt(bytes.NewBuffer(bb[5:]))
+b := Append{{.T.MFunc}}Pkt(nil, t, {{.T.MList}})
+if err = c.fits(len(b)); err != nil {
+	return {{.R.UList}} err
+}
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return {{.R.UList}} err
+}
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return {{.R.UList}} err
+}
+{{.R.MList}}{{.R.MLsep}} _, err = Decode{{.R.UFunc}}Pkt(bb[5:])
+{{if .Free}}putFrame(bb)
+{{end}}return {{.R.UList}} err
+}
+
+type {{.T.MFunc}}Reply struct {
+{{.RFields}}
 }
-return {{.R.UList}} err
+
+func (c *Client)Go{{.T.MFunc}} (ctx context.Context, {{.T.MParms}}, done chan *Call) *Call {
+b := Append{{.T.MFunc}}Pkt(nil, Tag(0), {{.T.MList}})
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	{{.R.MList}}{{.R.MLsep}} _, err := Decode{{.R.UFunc}}Pkt(bb[5:])
+	{{if .Free}}putFrame(bb)
+	{{end}}return &{{.T.MFunc}}Reply{ {{.RKeys}} }, err
+})
 }
 `))
+
+	// tb holds the benchmarks, which go in genout_test.go.
+	tb = bytes.NewBufferString(testHeader)
+	// newMsgs has a case of newMsg for each message, and samples and
+	// samplesDotu a sample of each Msg and DotuMsg, for the tests.
+	newMsgs     = &bytes.Buffer{}
+	samples     = &bytes.Buffer{}
+	samplesDotu = &bytes.Buffer{}
 )
 
 func nodebug(string, ...interface{}) {
 }
 
-func newCall(p *pack) *call {
+// newCall sets up the emitters for a pack. If tdotu or rdotu is set, the T or R
+// emitter generates the 9P2000.u variant of the message, with a Dotu suffix.
+func newCall(p *pack, tdotu, rdotu bool) *call {
 	c := &call{}
+	tn, rn := p.tn, p.rn
+	if tdotu {
+		tn += "Dotu"
+	}
+	if rdotu {
+		rn += "Dotu"
+	}
 	// We set inBWrite to true because the prologue marshal code sets up some default writes to b
-	c.T = &emitter{"T" + p.n, p.tn, &bytes.Buffer{}, &bytes.Buffer{}, "", &bytes.Buffer{}, p.tn, &bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{}, true}
-	c.R = &emitter{"R" + p.n, p.rn, &bytes.Buffer{}, &bytes.Buffer{}, "", &bytes.Buffer{}, p.rn, &bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{}, true}
+	c.T = &emitter{"T" + p.n, tn, &bytes.Buffer{}, &bytes.Buffer{}, "", &bytes.Buffer{}, tn, &bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{}, true, tdotu, false}
+	c.R = &emitter{"R" + p.n, rn, &bytes.Buffer{}, &bytes.Buffer{}, "", &bytes.Buffer{}, rn, &bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{}, true, rdotu, false}
+	c.Srv = p.rn
+	c.NS = "s.session(ctx).nsc"
+	if p.ns == "NineServerLContext" {
+		c.NS = "s.session(ctx).dotl"
+	}
+	if tdotu {
+		c.Srv = p.rn + "Dotu"
+		c.NS = "s.session(ctx).dotu"
+	}
 	return c
 }
 
+// skip returns true if field i of t is a 9P2000.u extension and e is not
+// generating the 9P2000.u variant.
+func (e *emitter) skip(t reflect.Type, i int) bool {
+	return t.Field(i).Tag.Get("ninep") == "dotu" && !e.dotu
+}
+
+// field returns field i of the struct v. A []byte tagged `ninep:"count16"`
+// is returned as a []protocol.DataCnt16, which is how the rest of gen knows
+// to give it a 16-bit count.
+func field(v reflect.Value, i int) reflect.Value {
+	if v.Type().Field(i).Tag.Get("ninep") == "count16" {
+		return reflect.ValueOf([]protocol.DataCnt16(nil))
+	}
+	return v.Field(i)
+}
+
+// hasDotu returns true if t, or any struct in it, has 9P2000.u extension fields.
+func hasDotu(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Tag.Get("ninep") == "dotu" {
+			return true
+		}
+		if f.Type.Kind() == reflect.Struct && hasDotu(f.Type) {
+			return true
+		}
+	}
+	return false
+}
+
 func emitEncodeInt(v interface{}, n string, l int, e *emitter) {
 	debug("emit %v, %v", n, l)
 	for i := 0; i < l; i++ {
 		if !e.inBWrite {
-			e.MCode.WriteString("\tb.Write([]byte{")
+			e.MCode.WriteString("\tb = append(b,")
 			e.inBWrite = true
 		}
 		e.MCode.WriteString(fmt.Sprintf("\tuint8(%v>>%v),\n", n, i*8))
@@ -217,38 +514,49 @@ func emitEncodeInt(v interface{}, n string, l int, e *emitter) {
 func emitDecodeInt(v interface{}, n string, l int, e *emitter) {
 	t := reflect.ValueOf(v).Type().Name()
 	debug("emit reflect.ValueOf(v) %s %v, %v", t, n, l)
-	e.UCode.WriteString(fmt.Sprintf("\tif _, err = b.Read(u[:%v]); err != nil {\n\t\terr = fmt.Errorf(\"pkt too short for uint%v: need %v, have %%d\", b.Len())\n\treturn\n\t}\n", l, l*8, l))
-	e.UCode.WriteString(fmt.Sprintf("\t%v = %s(u[0])\n", n, t))
-	for i := 1; i < l; i++ {
-		e.UCode.WriteString(fmt.Sprintf("\t%v |= %s(u[%d])<<%v\n", n, t, i, i*8))
+	e.UCode.WriteString(fmt.Sprintf("\tif len(b) < %v {\n\t\terr = fmt.Errorf(\"pkt too short for uint%v: need %v, have %%d\", len(b))\n\treturn\n\t}\n", l, l*8, l))
+	if l == 1 {
+		e.UCode.WriteString(fmt.Sprintf("\t%v = %s(b[0])\n", n, t))
+	} else {
+		e.UCode.WriteString(fmt.Sprintf("\t%v = %s(binary.LittleEndian.Uint%d(b))\n", n, t, l*8))
 	}
+	e.UCode.WriteString(fmt.Sprintf("\tb = b[%d:]\n", l))
 }
 
 // TODO: templates.
 func emitEncodeString(v interface{}, n string, e *emitter) {
 	if !e.inBWrite {
-		e.MCode.WriteString("\tb.Write([]byte{")
+		e.MCode.WriteString("\tb = append(b,")
 		e.inBWrite = true
 	}
 	e.MCode.WriteString(fmt.Sprintf("\tuint8(len(%v)),uint8(len(%v)>>8),\n", n, n))
-	e.MCode.WriteString("\t})\n")
+	e.MCode.WriteString("\t)\n")
 	e.inBWrite = false
-	e.MCode.WriteString(fmt.Sprintf("\tb.Write([]byte(%v))\n", n))
+	e.MCode.WriteString(fmt.Sprintf("\tb = append(b, %v...)\n", n))
 }
 
 func emitDecodeString(n string, e *emitter) {
 	var l uint64
 	emitDecodeInt(l, "l", 2, e)
-	e.UCode.WriteString(fmt.Sprintf("\tif b.Len() < int(l) {\n\t\terr = fmt.Errorf(\"pkt too short for string: need %%d, have %%d\", l, b.Len())\n\treturn\n\t}\n"))
-	e.UCode.WriteString(fmt.Sprintf("\t%v = string(b.Bytes()[:l])\n", n))
-	e.UCode.WriteString("\t_ = b.Next(int(l))\n")
+	emitDecodeLen("string", e)
+	e.UCode.WriteString(fmt.Sprintf("\t%v = string(b[:l])\n", n))
+	e.UCode.WriteString("\tb = b[l:]\n")
+}
+
+// emitDecodeLen checks that the l bytes of a kind of data are there.
+func emitDecodeLen(kind string, e *emitter) {
+	e.ULen = true
+	e.UCode.WriteString(fmt.Sprintf("\tif uint64(len(b)) < l {\n\t\terr = fmt.Errorf(\"pkt too short for %s: need %%d, have %%d\", l, len(b))\n\treturn\n\t}\n", kind))
 }
 
 func genEncodeStruct(v interface{}, n string, e *emitter) error {
 	debug("genEncodeStruct(%T, %v, %v)", v, n, e)
 	t := reflect.ValueOf(v)
 	for i := 0; i < t.NumField(); i++ {
-		f := t.Field(i)
+		if e.skip(t.Type(), i) {
+			continue
+		}
+		f := field(t, i)
 		fn := t.Type().Field(i).Name
 		debug("genEncodeStruct %T n %v field %d %v %v\n", t, n, i, f.Type(), f.Type().Name())
 		genEncodeData(f.Interface(), n+fn, e)
@@ -260,7 +568,10 @@ func genDecodeStruct(v interface{}, n string, e *emitter) error {
 	debug("genDecodeStruct(%T, %v, %v)", v, n, "")
 	t := reflect.ValueOf(v)
 	for i := 0; i < t.NumField(); i++ {
-		f := t.Field(i)
+		if e.skip(t.Type(), i) {
+			continue
+		}
+		f := field(t, i)
 		fn := t.Type().Field(i).Name
 		debug("genDecodeStruct %T n %v field %d %v %v\n", t, n, i, f.Type(), f.Type().Name())
 		genDecodeData(f.Interface(), n+fn, e)
@@ -276,7 +587,7 @@ func genEncodeSlice(v interface{}, n string, e *emitter) error {
 		var u uint16
 		emitEncodeInt(u, fmt.Sprintf("len(%v)", n), 2, e)
 		if e.inBWrite {
-			e.MCode.WriteString("\t})\n")
+			e.MCode.WriteString("\t)\n")
 			e.inBWrite = false
 		}
 		e.MCode.WriteString(fmt.Sprintf("for i := range %v {\n", n))
@@ -287,30 +598,30 @@ func genEncodeSlice(v interface{}, n string, e *emitter) error {
 		var u uint16
 		emitEncodeInt(u, fmt.Sprintf("len(%v)", n), 2, e)
 		if e.inBWrite {
-			e.MCode.WriteString("\t})\n")
+			e.MCode.WriteString("\t)\n")
 			e.inBWrite = false
 		}
 		e.MCode.WriteString(fmt.Sprintf("for i := range %v {\n", n))
 		genEncodeData(protocol.QID{}, n+"[i]", e)
-		e.MCode.WriteString("\t})\n")
+		e.MCode.WriteString("\t)\n")
 		e.inBWrite = false
 		e.MCode.WriteString("}\n")
 	case "[]byte", "[]uint8":
 		var u uint32
 		emitEncodeInt(u, fmt.Sprintf("len(%v)", n), 4, e)
 		if e.inBWrite {
-			e.MCode.WriteString("\t})\n")
+			e.MCode.WriteString("\t)\n")
 			e.inBWrite = false
 		}
-		e.MCode.WriteString(fmt.Sprintf("\tb.Write(%v)\n", n))
+		e.MCode.WriteString(fmt.Sprintf("\tb = append(b, %v...)\n", n))
 	case "[]protocol.DataCnt16":
 		var u uint16
 		emitEncodeInt(u, fmt.Sprintf("len(%v)", n), 2, e)
 		if e.inBWrite {
-			e.MCode.WriteString("\t})\n")
+			e.MCode.WriteString("\t)\n")
 			e.inBWrite = false
 		}
-		e.MCode.WriteString(fmt.Sprintf("\tb.Write(%v)\n", n))
+		e.MCode.WriteString(fmt.Sprintf("\tb = append(b, %v...)\n", n))
 	default:
 		log.Printf("genEncodeSlice: Can't handle slice of %s", t)
 	}
@@ -324,6 +635,7 @@ func genDecodeSlice(v interface{}, n string, e *emitter) error {
 	case "[]string":
 		var u uint64
 		emitDecodeInt(u, "l", 2, e)
+		e.ULen = true
 		e.UCode.WriteString(fmt.Sprintf("\t%v = make([]string, l)\n", n))
 		e.UCode.WriteString(fmt.Sprintf("for i := range %v {\n", n))
 		var s string
@@ -332,6 +644,7 @@ func genDecodeSlice(v interface{}, n string, e *emitter) error {
 	case "[]protocol.QID":
 		var u uint64
 		emitDecodeInt(u, "l", 2, e)
+		e.ULen = true
 		e.UCode.WriteString(fmt.Sprintf("\t%v = make([]QID, l)\n", n))
 		e.UCode.WriteString(fmt.Sprintf("for i := range %v {\n", n))
 		genDecodeData(protocol.QID{}, n+"[i]", e)
@@ -339,13 +652,15 @@ func genDecodeSlice(v interface{}, n string, e *emitter) error {
 	case "[]byte", "[]uint8":
 		var u uint64
 		emitDecodeInt(u, "l", 4, e)
-		e.UCode.WriteString(fmt.Sprintf("\t%v = b.Bytes()[:l]\n", n))
-		e.UCode.WriteString("\t_ = b.Next(int(l))\n")
+		emitDecodeLen("data", e)
+		e.UCode.WriteString(fmt.Sprintf("\t%v = b[:l:l]\n", n))
+		e.UCode.WriteString("\tb = b[l:]\n")
 	case "[]protocol.DataCnt16":
 		var u uint64
 		emitDecodeInt(u, "l", 2, e)
-		e.UCode.WriteString(fmt.Sprintf("\t%v = b.Bytes()[:l]\n", n))
-		e.UCode.WriteString("\t_ = b.Next(int(l))\n")
+		emitDecodeLen("data", e)
+		e.UCode.WriteString(fmt.Sprintf("\t%v = b[:l:l]\n", n))
+		e.UCode.WriteString("\tb = b[l:]\n")
 	default:
 		log.Printf("genDecodeSlice: Can't handle slice of %v", t)
 	}
@@ -430,7 +745,10 @@ func tn(f reflect.Value) string {
 func genParms(v interface{}, n string, e *emitter) error {
 	t := reflect.ValueOf(v)
 	for i := 0; i < t.NumField(); i++ {
-		f := t.Field(i)
+		if e.skip(t.Type(), i) {
+			continue
+		}
+		f := field(t, i)
 		fn := t.Type().Field(i).Name
 		e.MList.WriteString(e.MLsep + fn)
 		e.MParms.WriteString(e.MLsep + fn + " " + tn(f))
@@ -444,7 +762,10 @@ func genParms(v interface{}, n string, e *emitter) error {
 func genRets(v interface{}, n string, e *emitter) error {
 	t := reflect.ValueOf(v)
 	for i := 0; i < t.NumField(); i++ {
-		f := t.Field(i)
+		if e.skip(t.Type(), i) {
+			continue
+		}
+		f := field(t, i)
 		fn := t.Type().Field(i).Name
 		e.UList.WriteString(fn + ", ")
 		e.URet.WriteString(fn + " " + tn(f) + ", ")
@@ -452,17 +773,113 @@ func genRets(v interface{}, n string, e *emitter) error {
 	return nil
 }
 
+// sample returns an expression for a sample value of type t, which is, or is
+// in, a message e is generating.
+func sample(t reflect.Type, e *emitter) string {
+	switch t.Kind() {
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Int32, reflect.Uint64:
+		return fmt.Sprintf("^%s(0)", t.Name())
+	case reflect.String:
+		return `"sample"`
+	case reflect.Struct:
+		var f []string
+		for i := 0; i < t.NumField(); i++ {
+			if !e.skip(t, i) {
+				f = append(f, t.Field(i).Name+": "+sample(t.Field(i).Type, e))
+			}
+		}
+		return t.Name() + "{" + strings.Join(f, ", ") + "}"
+	}
+	switch t.String() {
+	case "[]string":
+		return `[]string{"a", "sample"}`
+	case "[]protocol.QID":
+		q := sample(reflect.TypeOf(protocol.QID{}), e)
+		return "[]QID{" + q + ", " + q + "}"
+	case "[]uint8":
+		return "make([]byte, 1024)"
+	}
+	log.Fatalf("sample: Can't handle type %v", t)
+	return ""
+}
+
+// genBench generates the benchmark of the codecs of v, the message e is
+// generating.
+func genBench(b io.Writer, v interface{}, e *emitter) {
+	t := reflect.TypeOf(v)
+	bb := &bench{emitter: e}
+	for i := 0; i < t.NumField(); i++ {
+		if e.skip(t, i) {
+			continue
+		}
+		bb.Vars += fmt.Sprintf("\t%s := %s\n", t.Field(i).Name, sample(t.Field(i).Type, e))
+		bb.Blanks += "_, "
+	}
+	bfunc.Execute(b, bb)
+}
+
+// genMsg generates the methods which make v, the message e is generating, a
+// Msg, or, for the 9P2000.u variant, a DotuMsg.
+func genMsg(b io.Writer, v interface{}, e *emitter) {
+	t := reflect.TypeOf(v)
+	m := &msg{emitter: e, Struct: t.Name(), HasDotu: hasDotu(t)}
+	var args, keys []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		switch {
+		case f.Type.String() == "[]uint8":
+			m.Format += " " + f.Name + " <%d bytes>"
+			m.FArgs += ", len(m." + f.Name + ")"
+		case f.Type.String() == "string" || f.Type.String() == "[]string":
+			m.Format += " " + f.Name + " %q"
+			m.FArgs += ", m." + f.Name
+		default:
+			m.Format += " " + f.Name + " %v"
+			m.FArgs += ", m." + f.Name
+		}
+		if e.skip(t, i) {
+			continue
+		}
+		args = append(args, "m."+f.Name)
+		keys = append(keys, f.Name+": "+f.Name)
+		m.Vars += f.Name + ", "
+	}
+	m.Args, m.Keys = strings.Join(args, ", "), strings.Join(keys, ", ")
+	if e.dotu {
+		msgdotufunc.Execute(b, m)
+		fmt.Fprintf(samplesDotu, "\t&%s,\n", sample(t, e))
+		return
+	}
+	msgfunc.Execute(b, m)
+	fmt.Fprintf(newMsgs, "\tcase %s:\n\t\treturn &%s{}\n", e.Name, m.Struct)
+	fmt.Fprintf(samples, "\t&%s,\n", sample(t, e))
+}
+
 // genMsgRPC generates the call and reply declarations and marshalers. We don't think of encoders as too separate
 // because the 9p encoding is so simple.
 func genMsgRPC(b io.Writer, p *pack) (*call, error) {
+	c, err := genCall(b, p, false, false)
+	if err != nil {
+		return nil, err
+	}
+	// 9P2000.u adds fields to a few messages. We generate a second set of
+	// functions for those, leaving the 9P2000 ones alone.
+	tdotu, rdotu := hasDotu(reflect.TypeOf(p.t)), hasDotu(reflect.TypeOf(p.r))
+	if !tdotu && !rdotu {
+		return c, nil
+	}
+	return genCall(b, p, tdotu, rdotu)
+}
+
+func genCall(b io.Writer, p *pack, tdotu, rdotu bool) (*call, error) {
 
-	c := newCall(p)
+	c := newCall(p, tdotu, rdotu)
 
 	if err := genEncodeStruct(p.r, "", c.R); err != nil {
 		log.Fatalf("%v", err)
 	}
 	if c.R.inBWrite {
-		c.R.MCode.WriteString("\t})\n")
+		c.R.MCode.WriteString("\t)\n")
 		c.R.inBWrite = false
 	}
 	if err := genDecodeStruct(p.r, "", c.R); err != nil {
@@ -473,7 +890,7 @@ func genMsgRPC(b io.Writer, p *pack) (*call, error) {
 		log.Fatalf("%v", err)
 	}
 	if c.T.inBWrite {
-		c.T.MCode.WriteString("\t})\n")
+		c.T.MCode.WriteString("\t)\n")
 		c.T.inBWrite = false
 	}
 
@@ -497,21 +914,44 @@ func genMsgRPC(b io.Writer, p *pack) (*call, error) {
 		log.Fatalf("%v", err)
 	}
 
+	if l := c.R.MList.String(); l != "" {
+		var keys []string
+		for _, k := range strings.Split(l, ", ") {
+			keys = append(keys, k+": "+k)
+		}
+		c.RKeys = strings.Join(keys, ", ")
+		c.RFields = strings.Replace(strings.TrimSuffix(c.R.URet.String(), ", "), ", ", "\n", -1)
+	}
+	// Byte slices are decoded in place; anything else is copied.
+	c.Free = !strings.Contains(c.R.URet.String(), "[]byte") && !strings.Contains(c.R.URet.String(), "[]uint8")
+
 	//log.Print("e %v d %v", c.T, c.R)
 
 	//	log.Print("------------------", c.T.MParms, "0", c.T.MList, "1", c.R.URet, "2", c.R.UList)
 	//	log.Print("------------------", c.T.MCode)
-	mfunc.Execute(b, c.R)
-	ufunc.Execute(b, c.R)
+	// The dotu variant of a pack only needs the side which changed.
+	if rdotu || !tdotu {
+		mfunc.Execute(b, c.R)
+		ufunc.Execute(b, c.R)
+		genMsg(b, p.r, c.R)
+		genBench(tb, p.r, c.R)
+	}
 
-	if p.n == "error" {
+	// Error packs only have a reply.
+	if p.tn == p.rn {
 		return c, nil
 	}
 
-	mfunc.Execute(b, c.T)
-	ufunc.Execute(b, c.T)
-	sfunc.Execute(b, c)
-	cfunc.Execute(b, c)
+	if tdotu || !rdotu {
+		mfunc.Execute(b, c.T)
+		ufunc.Execute(b, c.T)
+		genMsg(b, p.t, c.T)
+		genBench(tb, p.t, c.T)
+		if !p.manual {
+			sfunc.Execute(b, c)
+		}
+		cfunc.Execute(b, c)
+	}
 	return nil, nil
 
 }
@@ -529,28 +969,44 @@ func main() {
 		}
 	}
 	b.WriteString(serverError)
+	fmt.Fprintf(b, "\n// newMsg returns a new Msg of type t, or nil if there is none.\nfunc newMsg(t MType) Msg {\n\tswitch t {\n%s\t}\n\treturn nil\n}\n", newMsgs)
+	fmt.Fprintf(tb, "\n// msgSamples has a sample of each Msg.\nvar msgSamples = []Msg{\n%s}\n", samples)
+	fmt.Fprintf(tb, "\n// msgSamplesDotu has a sample of each DotuMsg, with its 9P2000.u fields.\nvar msgSamplesDotu = []DotuMsg{\n%s}\n", samplesDotu)
 
 	// yeah, it's a hack.
-	dir := &emitter{"dir", "dir", &bytes.Buffer{}, &bytes.Buffer{}, "", &bytes.Buffer{}, "dir", &bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{}, false}
-	if err := genEncodeStruct(protocol.DirPkt{}, "", dir); err != nil {
-		log.Fatalf("%v", err)
-	}
-	if err := genDecodeStruct(protocol.DirPkt{}, "", dir); err != nil {
-		log.Fatalf("%v", err)
-	}
-	if err := genParms(protocol.DirPkt{}, "dir", dir); err != nil {
-		log.Fatalf("%v", err)
-	}
+	for _, dotu := range []bool{false, true} {
+		n := "dir"
+		if dotu {
+			n = "dirDotu"
+		}
+		dir := &emitter{"dir", n, &bytes.Buffer{}, &bytes.Buffer{}, "", &bytes.Buffer{}, n, &bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{}, false, dotu, false}
+		if err := genEncodeStruct(protocol.DirPkt{}, "", dir); err != nil {
+			log.Fatalf("%v", err)
+		}
+		if dir.inBWrite {
+			dir.MCode.WriteString("\t)\n")
+			dir.inBWrite = false
+		}
+		if err := genDecodeStruct(protocol.DirPkt{}, "", dir); err != nil {
+			log.Fatalf("%v", err)
+		}
+		if err := genParms(protocol.DirPkt{}, "dir", dir); err != nil {
+			log.Fatalf("%v", err)
+		}
 
-	if err := genRets(protocol.DirPkt{}, "dir", dir); err != nil {
-		log.Fatalf("%v", err)
-	}
+		if err := genRets(protocol.DirPkt{}, "dir", dir); err != nil {
+			log.Fatalf("%v", err)
+		}
 
-	msfunc.Execute(b, dir)
-	usfunc.Execute(b, dir)
+		msfunc.Execute(b, dir)
+		usfunc.Execute(b, dir)
+	}
 
 	if err := ioutil.WriteFile("genout.go", b.Bytes(), 0600); err != nil {
 		log.Fatalf("%v", err)
 	}
+	if err := ioutil.WriteFile("genout_test.go", tb.Bytes(), 0600); err != nil {
+		log.Fatalf("%v", err)
+	}
 
 }
@@ -2,56 +2,177 @@
 package protocol
 import (
 "bytes"
+"context"
+"encoding/binary"
 "fmt"
 _ "log"
 )
+// AppendRerrorPkt appends a Rerror with tag t, from its size on, to b.
+func AppendRerrorPkt (b []byte, t Tag, Error string) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Rerror),
+byte(t), byte(t>>8),
+	uint8(len(Error)),uint8(len(Error)>>8),
+	)
+	b = append(b, Error...)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
 func MarshalRerrorPkt (b *bytes.Buffer, t Tag, Error string) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendRerrorPkt(b.Bytes(), t, Error))
+}
+// DecodeRerrorPkt decodes a Rerror from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeRerrorPkt (b []byte) (Error string,  t Tag, err error) {
 var l uint64
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
+	return
+	}
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
+	return
+	}
+	Error = string(b[:l])
+	b = b[l:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalRerrorPkt (b *bytes.Buffer) (Error string,  t Tag, err error) {
+Error,  t, err = DecodeRerrorPkt(b.Bytes())
 b.Reset()
-b.Write([]byte{0,0,0,0,
+return
+}
+
+func (RerrorPkt) Type() MType {
+	return Rerror
+}
+
+// Encode appends m, with tag t, to b.
+func (m RerrorPkt) Encode(b []byte, t Tag) []byte {
+	return AppendRerrorPkt(b, t, m.Error)
+}
+
+// Decode decodes m from b, which holds all of a Rerror, and returns its tag.
+func (m *RerrorPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Rerror); err != nil {
+		return 0, err
+	}
+	Error, t, err := DecodeRerrorPkt(b[5:])
+	if err != nil {
+		if t, derr := m.DecodeDotu(b); derr == nil {
+			return t, nil
+		}
+		return t, err
+	}
+	*m = RerrorPkt{ Error: Error }
+	return t, nil
+}
+
+func (m RerrorPkt) String() string {
+	return fmt.Sprintf("Rerror Error %q Errno %v", m.Error, m.Errno)
+}
+// AppendRerrorDotuPkt appends a Rerror with tag t, from its size on, to b.
+func AppendRerrorDotuPkt (b []byte, t Tag, Error string, Errno uint32) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
 uint8(Rerror),
 byte(t), byte(t>>8),
 	uint8(len(Error)),uint8(len(Error)>>8),
-	})
-	b.Write([]byte(Error))
+	)
+	b = append(b, Error...)
+	b = append(b,	uint8(Errno>>0),
+	uint8(Errno>>8),
+	uint8(Errno>>16),
+	uint8(Errno>>24),
+	)
 
-{
-l = uint64(b.Len())
-copy(b.Bytes(), []byte{uint8(l), uint8(l>>8), uint8(l>>16), uint8(l>>24)})
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
 }
-return
+
+func MarshalRerrorDotuPkt (b *bytes.Buffer, t Tag, Error string, Errno uint32) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendRerrorDotuPkt(b.Bytes(), t, Error, Errno))
 }
-func UnmarshalRerrorPkt (b *bytes.Buffer) (Error string,  t Tag, err error) {
-var u [8]uint8
+// DecodeRerrorDotuPkt decodes a Rerror from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeRerrorDotuPkt (b []byte) (Error string, Errno uint32,  t Tag, err error) {
 var l uint64
-if _, err = b.Read(u[:2]); err != nil {
-err = fmt.Errorf("pkt too short for Tag; need 2, have %d", b.Len())
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
 return
 }
-l = uint64(u[0]) | uint64(u[1])<<8
-t = Tag(l)
-	if _, err = b.Read(u[:2]); err != nil {
-		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", b.Len())
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
+	return
+	}
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
 	return
 	}
-	l = uint64(u[0])
-	l |= uint64(u[1])<<8
-	if b.Len() < int(l) {
-		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, b.Len())
+	Error = string(b[:l])
+	b = b[l:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
 	return
 	}
-	Error = string(b.Bytes()[:l])
-	_ = b.Next(int(l))
+	Errno = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
 
-if b.Len() > 0 {
-err = fmt.Errorf("Packet too long: %d bytes left over after decode", b.Len())
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
 }
 return
 }
-func MarshalRversionPkt (b *bytes.Buffer, t Tag, RMsize MaxSize, RVersion string) {
-var l uint64
+
+func UnmarshalRerrorDotuPkt (b *bytes.Buffer) (Error string, Errno uint32,  t Tag, err error) {
+Error, Errno,  t, err = DecodeRerrorDotuPkt(b.Bytes())
 b.Reset()
-b.Write([]byte{0,0,0,0,
+return
+}
+
+// EncodeDotu appends m, with tag t, to b, with its 9P2000.u fields.
+func (m RerrorPkt) EncodeDotu(b []byte, t Tag) []byte {
+	return AppendRerrorDotuPkt(b, t, m.Error, m.Errno)
+}
+
+// DecodeDotu is Decode for a Rerror with its 9P2000.u fields.
+func (m *RerrorPkt) DecodeDotu(b []byte) (Tag, error) {
+	if err := checkMsg(b, Rerror); err != nil {
+		return 0, err
+	}
+	Error, Errno, t, err := DecodeRerrorDotuPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = RerrorPkt{ Error: Error, Errno: Errno }
+	return t, nil
+}
+// AppendRversionPkt appends a Rversion with tag t, from its size on, to b.
+func AppendRversionPkt (b []byte, t Tag, RMsize MaxSize, RVersion string) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
 uint8(Rversion),
 byte(t), byte(t>>8),
 	uint8(RMsize>>0),
@@ -59,54 +180,87 @@ byte(t), byte(t>>8),
 	uint8(RMsize>>16),
 	uint8(RMsize>>24),
 	uint8(len(RVersion)),uint8(len(RVersion)>>8),
-	})
-	b.Write([]byte(RVersion))
+	)
+	b = append(b, RVersion...)
 
-{
-l = uint64(b.Len())
-copy(b.Bytes(), []byte{uint8(l), uint8(l>>8), uint8(l>>16), uint8(l>>24)})
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
 }
-return
+
+func MarshalRversionPkt (b *bytes.Buffer, t Tag, RMsize MaxSize, RVersion string) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendRversionPkt(b.Bytes(), t, RMsize, RVersion))
 }
-func UnmarshalRversionPkt (b *bytes.Buffer) (RMsize MaxSize, RVersion string,  t Tag, err error) {
-var u [8]uint8
+// DecodeRversionPkt decodes a Rversion from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeRversionPkt (b []byte) (RMsize MaxSize, RVersion string,  t Tag, err error) {
 var l uint64
-if _, err = b.Read(u[:2]); err != nil {
-err = fmt.Errorf("pkt too short for Tag; need 2, have %d", b.Len())
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
 return
 }
-l = uint64(u[0]) | uint64(u[1])<<8
-t = Tag(l)
-	if _, err = b.Read(u[:4]); err != nil {
-		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", b.Len())
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
 	return
 	}
-	RMsize = MaxSize(u[0])
-	RMsize |= MaxSize(u[1])<<8
-	RMsize |= MaxSize(u[2])<<16
-	RMsize |= MaxSize(u[3])<<24
-	if _, err = b.Read(u[:2]); err != nil {
-		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", b.Len())
+	RMsize = MaxSize(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
 	return
 	}
-	l = uint64(u[0])
-	l |= uint64(u[1])<<8
-	if b.Len() < int(l) {
-		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, b.Len())
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
 	return
 	}
-	RVersion = string(b.Bytes()[:l])
-	_ = b.Next(int(l))
+	RVersion = string(b[:l])
+	b = b[l:]
 
-if b.Len() > 0 {
-err = fmt.Errorf("Packet too long: %d bytes left over after decode", b.Len())
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
 }
 return
 }
-func MarshalTversionPkt (b *bytes.Buffer, t Tag, TMsize MaxSize, TVersion string) {
-var l uint64
+
+func UnmarshalRversionPkt (b *bytes.Buffer) (RMsize MaxSize, RVersion string,  t Tag, err error) {
+RMsize, RVersion,  t, err = DecodeRversionPkt(b.Bytes())
 b.Reset()
-b.Write([]byte{0,0,0,0,
+return
+}
+
+func (RversionPkt) Type() MType {
+	return Rversion
+}
+
+// Encode appends m, with tag t, to b.
+func (m RversionPkt) Encode(b []byte, t Tag) []byte {
+	return AppendRversionPkt(b, t, m.RMsize, m.RVersion)
+}
+
+// Decode decodes m from b, which holds all of a Rversion, and returns its tag.
+func (m *RversionPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Rversion); err != nil {
+		return 0, err
+	}
+	RMsize, RVersion, t, err := DecodeRversionPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = RversionPkt{ RMsize: RMsize, RVersion: RVersion }
+	return t, nil
+}
+
+func (m RversionPkt) String() string {
+	return fmt.Sprintf("Rversion RMsize %v RVersion %q", m.RMsize, m.RVersion)
+}
+// AppendTversionPkt appends a Tversion with tag t, from its size on, to b.
+func AppendTversionPkt (b []byte, t Tag, TMsize MaxSize, TVersion string) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
 uint8(Tversion),
 byte(t), byte(t>>8),
 	uint8(TMsize>>0),
@@ -114,1625 +268,8201 @@ byte(t), byte(t>>8),
 	uint8(TMsize>>16),
 	uint8(TMsize>>24),
 	uint8(len(TVersion)),uint8(len(TVersion)>>8),
-	})
-	b.Write([]byte(TVersion))
+	)
+	b = append(b, TVersion...)
 
-{
-l = uint64(b.Len())
-copy(b.Bytes(), []byte{uint8(l), uint8(l>>8), uint8(l>>16), uint8(l>>24)})
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
 }
-return
+
+func MarshalTversionPkt (b *bytes.Buffer, t Tag, TMsize MaxSize, TVersion string) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendTversionPkt(b.Bytes(), t, TMsize, TVersion))
 }
-func UnmarshalTversionPkt (b *bytes.Buffer) (TMsize MaxSize, TVersion string,  t Tag, err error) {
-var u [8]uint8
+// DecodeTversionPkt decodes a Tversion from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeTversionPkt (b []byte) (TMsize MaxSize, TVersion string,  t Tag, err error) {
 var l uint64
-if _, err = b.Read(u[:2]); err != nil {
-err = fmt.Errorf("pkt too short for Tag; need 2, have %d", b.Len())
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
 return
 }
-l = uint64(u[0]) | uint64(u[1])<<8
-t = Tag(l)
-	if _, err = b.Read(u[:4]); err != nil {
-		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", b.Len())
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
 	return
 	}
-	TMsize = MaxSize(u[0])
-	TMsize |= MaxSize(u[1])<<8
-	TMsize |= MaxSize(u[2])<<16
-	TMsize |= MaxSize(u[3])<<24
-	if _, err = b.Read(u[:2]); err != nil {
-		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", b.Len())
+	TMsize = MaxSize(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
 	return
 	}
-	l = uint64(u[0])
-	l |= uint64(u[1])<<8
-	if b.Len() < int(l) {
-		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, b.Len())
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
 	return
 	}
-	TVersion = string(b.Bytes()[:l])
-	_ = b.Next(int(l))
+	TVersion = string(b[:l])
+	b = b[l:]
 
-if b.Len() > 0 {
-err = fmt.Errorf("Packet too long: %d bytes left over after decode", b.Len())
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
 }
 return
 }
-func (s *Server) SrvRversion(b*bytes.Buffer) (err error) {
-	TMsize, TVersion,  t, err := UnmarshalTversionPkt(b)
-	//if err != nil {
-	//}
-	if RMsize, RVersion,  err := s.NS.Rversion(TMsize, TVersion); err != nil {
-	MarshalRerrorPkt(b, t, fmt.Sprintf("%v", err))
-} else {
-	MarshalRversionPkt(b, t, RMsize, RVersion)
+
+func UnmarshalTversionPkt (b *bytes.Buffer) (TMsize MaxSize, TVersion string,  t Tag, err error) {
+TMsize, TVersion,  t, err = DecodeTversionPkt(b.Bytes())
+b.Reset()
+return
 }
-	return nil
+
+func (TversionPkt) Type() MType {
+	return Tversion
+}
+
+// Encode appends m, with tag t, to b.
+func (m TversionPkt) Encode(b []byte, t Tag) []byte {
+	return AppendTversionPkt(b, t, m.TMsize, m.TVersion)
+}
+
+// Decode decodes m from b, which holds all of a Tversion, and returns its tag.
+func (m *TversionPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Tversion); err != nil {
+		return 0, err
+	}
+	TMsize, TVersion, t, err := DecodeTversionPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = TversionPkt{ TMsize: TMsize, TVersion: TVersion }
+	return t, nil
+}
+
+func (m TversionPkt) String() string {
+	return fmt.Sprintf("Tversion TMsize %v TVersion %q", m.TMsize, m.TVersion)
 }
 
 func (c *Client)CallTversion (TMsize MaxSize, TVersion string) (RMsize MaxSize, RVersion string,  err error) {
-var b = bytes.Buffer{}
+return c.CallTversionContext(context.Background(), TMsize, TVersion)
+}
+
+func (c *Client)CallTversionContext (ctx context.Context, TMsize MaxSize, TVersion string) (RMsize MaxSize, RVersion string,  err error) {
 if c.Trace != nil {c.Trace("%v", Tversion)}
 t := Tag(0)
-r := make (chan []byte)
 if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
-MarshalTversionPkt(&b, t, TMsize, TVersion)
-c.FromClient <- &RPCCall{b: b.Bytes(), Reply: r}
-bb := <-r
-if MType(bb[4]) == Rerror {
-	s, _, err := UnmarshalRerrorPkt(bytes.NewBuffer(bb[5:]))
-	if err != nil {
-		return RMsize, RVersion,  err
-	}
-	return RMsize, RVersion,  fmt.Errorf("%v", s)
-} else {
-	RMsize, RVersion,  _, err = UnmarshalRversionPkt(bytes.NewBuffer(bb[5:]))
+b := AppendTversionPkt(nil, t, TMsize, TVersion)
+if err = c.fits(len(b)); err != nil {
+	return RMsize, RVersion,  err
+}
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return RMsize, RVersion,  err
 }
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return RMsize, RVersion,  err
+}
+RMsize, RVersion,  _, err = DecodeRversionPkt(bb[5:])
+putFrame(bb)
 return RMsize, RVersion,  err
 }
-func MarshalRattachPkt (b *bytes.Buffer, t Tag, QID QID) {
-var l uint64
-b.Reset()
-b.Write([]byte{0,0,0,0,
-uint8(Rattach),
+
+type TversionReply struct {
+RMsize MaxSize
+RVersion string
+}
+
+func (c *Client)GoTversion (ctx context.Context, TMsize MaxSize, TVersion string, done chan *Call) *Call {
+b := AppendTversionPkt(nil, Tag(0), TMsize, TVersion)
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	RMsize, RVersion,  _, err := DecodeRversionPkt(bb[5:])
+	putFrame(bb)
+	return &TversionReply{ RMsize: RMsize, RVersion: RVersion }, err
+})
+}
+// AppendRauthPkt appends a Rauth with tag t, from its size on, to b.
+func AppendRauthPkt (b []byte, t Tag, AQID QID) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Rauth),
 byte(t), byte(t>>8),
-	uint8(QID.Type>>0),
-	uint8(QID.Version>>0),
-	uint8(QID.Version>>8),
-	uint8(QID.Version>>16),
-	uint8(QID.Version>>24),
-	uint8(QID.Path>>0),
-	uint8(QID.Path>>8),
-	uint8(QID.Path>>16),
-	uint8(QID.Path>>24),
-	uint8(QID.Path>>32),
-	uint8(QID.Path>>40),
-	uint8(QID.Path>>48),
-	uint8(QID.Path>>56),
-	})
+	uint8(AQID.Type>>0),
+	uint8(AQID.Version>>0),
+	uint8(AQID.Version>>8),
+	uint8(AQID.Version>>16),
+	uint8(AQID.Version>>24),
+	uint8(AQID.Path>>0),
+	uint8(AQID.Path>>8),
+	uint8(AQID.Path>>16),
+	uint8(AQID.Path>>24),
+	uint8(AQID.Path>>32),
+	uint8(AQID.Path>>40),
+	uint8(AQID.Path>>48),
+	uint8(AQID.Path>>56),
+	)
 
-{
-l = uint64(b.Len())
-copy(b.Bytes(), []byte{uint8(l), uint8(l>>8), uint8(l>>16), uint8(l>>24)})
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
 }
-return
+
+func MarshalRauthPkt (b *bytes.Buffer, t Tag, AQID QID) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendRauthPkt(b.Bytes(), t, AQID))
 }
-func UnmarshalRattachPkt (b *bytes.Buffer) (QID QID,  t Tag, err error) {
-var u [8]uint8
-var l uint64
-if _, err = b.Read(u[:2]); err != nil {
-err = fmt.Errorf("pkt too short for Tag; need 2, have %d", b.Len())
+// DecodeRauthPkt decodes a Rauth from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeRauthPkt (b []byte) (AQID QID,  t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
 return
 }
-l = uint64(u[0]) | uint64(u[1])<<8
-t = Tag(l)
-	if _, err = b.Read(u[:1]); err != nil {
-		err = fmt.Errorf("pkt too short for uint8: need 1, have %d", b.Len())
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 1 {
+		err = fmt.Errorf("pkt too short for uint8: need 1, have %d", len(b))
 	return
 	}
-	QID.Type = uint8(u[0])
-	if _, err = b.Read(u[:4]); err != nil {
-		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", b.Len())
+	AQID.Type = uint8(b[0])
+	b = b[1:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
 	return
 	}
-	QID.Version = uint32(u[0])
-	QID.Version |= uint32(u[1])<<8
-	QID.Version |= uint32(u[2])<<16
-	QID.Version |= uint32(u[3])<<24
-	if _, err = b.Read(u[:8]); err != nil {
-		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", b.Len())
+	AQID.Version = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
 	return
 	}
-	QID.Path = uint64(u[0])
-	QID.Path |= uint64(u[1])<<8
-	QID.Path |= uint64(u[2])<<16
-	QID.Path |= uint64(u[3])<<24
-	QID.Path |= uint64(u[4])<<32
-	QID.Path |= uint64(u[5])<<40
-	QID.Path |= uint64(u[6])<<48
-	QID.Path |= uint64(u[7])<<56
+	AQID.Path = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
 
-if b.Len() > 0 {
-err = fmt.Errorf("Packet too long: %d bytes left over after decode", b.Len())
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
 }
 return
 }
-func MarshalTattachPkt (b *bytes.Buffer, t Tag, SFID FID, AFID FID, Uname string, Aname string) {
-var l uint64
+
+func UnmarshalRauthPkt (b *bytes.Buffer) (AQID QID,  t Tag, err error) {
+AQID,  t, err = DecodeRauthPkt(b.Bytes())
 b.Reset()
-b.Write([]byte{0,0,0,0,
-uint8(Tattach),
+return
+}
+
+func (RauthPkt) Type() MType {
+	return Rauth
+}
+
+// Encode appends m, with tag t, to b.
+func (m RauthPkt) Encode(b []byte, t Tag) []byte {
+	return AppendRauthPkt(b, t, m.AQID)
+}
+
+// Decode decodes m from b, which holds all of a Rauth, and returns its tag.
+func (m *RauthPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Rauth); err != nil {
+		return 0, err
+	}
+	AQID, t, err := DecodeRauthPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = RauthPkt{ AQID: AQID }
+	return t, nil
+}
+
+func (m RauthPkt) String() string {
+	return fmt.Sprintf("Rauth AQID %v", m.AQID)
+}
+// AppendTauthPkt appends a Tauth with tag t, from its size on, to b.
+func AppendTauthPkt (b []byte, t Tag, AFID FID, Uname string, Aname string) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Tauth),
 byte(t), byte(t>>8),
-	uint8(SFID>>0),
-	uint8(SFID>>8),
-	uint8(SFID>>16),
-	uint8(SFID>>24),
 	uint8(AFID>>0),
 	uint8(AFID>>8),
 	uint8(AFID>>16),
 	uint8(AFID>>24),
 	uint8(len(Uname)),uint8(len(Uname)>>8),
-	})
-	b.Write([]byte(Uname))
-	b.Write([]byte{	uint8(len(Aname)),uint8(len(Aname)>>8),
-	})
-	b.Write([]byte(Aname))
+	)
+	b = append(b, Uname...)
+	b = append(b,	uint8(len(Aname)),uint8(len(Aname)>>8),
+	)
+	b = append(b, Aname...)
 
-{
-l = uint64(b.Len())
-copy(b.Bytes(), []byte{uint8(l), uint8(l>>8), uint8(l>>16), uint8(l>>24)})
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
 }
-return
+
+func MarshalTauthPkt (b *bytes.Buffer, t Tag, AFID FID, Uname string, Aname string) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendTauthPkt(b.Bytes(), t, AFID, Uname, Aname))
 }
-func UnmarshalTattachPkt (b *bytes.Buffer) (SFID FID, AFID FID, Uname string, Aname string,  t Tag, err error) {
-var u [8]uint8
+// DecodeTauthPkt decodes a Tauth from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeTauthPkt (b []byte) (AFID FID, Uname string, Aname string,  t Tag, err error) {
 var l uint64
-if _, err = b.Read(u[:2]); err != nil {
-err = fmt.Errorf("pkt too short for Tag; need 2, have %d", b.Len())
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
 return
 }
-l = uint64(u[0]) | uint64(u[1])<<8
-t = Tag(l)
-	if _, err = b.Read(u[:4]); err != nil {
-		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", b.Len())
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
 	return
 	}
-	SFID = FID(u[0])
-	SFID |= FID(u[1])<<8
-	SFID |= FID(u[2])<<16
-	SFID |= FID(u[3])<<24
-	if _, err = b.Read(u[:4]); err != nil {
-		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", b.Len())
+	AFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
 	return
 	}
-	AFID = FID(u[0])
-	AFID |= FID(u[1])<<8
-	AFID |= FID(u[2])<<16
-	AFID |= FID(u[3])<<24
-	if _, err = b.Read(u[:2]); err != nil {
-		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", b.Len())
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
 	return
 	}
-	l = uint64(u[0])
-	l |= uint64(u[1])<<8
-	if b.Len() < int(l) {
-		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, b.Len())
+	Uname = string(b[:l])
+	b = b[l:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
 	return
 	}
-	Uname = string(b.Bytes()[:l])
-	_ = b.Next(int(l))
-	if _, err = b.Read(u[:2]); err != nil {
-		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", b.Len())
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
 	return
 	}
-	l = uint64(u[0])
-	l |= uint64(u[1])<<8
-	if b.Len() < int(l) {
-		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, b.Len())
-	return
-	}
-	Aname = string(b.Bytes()[:l])
-	_ = b.Next(int(l))
+	Aname = string(b[:l])
+	b = b[l:]
 
-if b.Len() > 0 {
-err = fmt.Errorf("Packet too long: %d bytes left over after decode", b.Len())
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
 }
 return
 }
-func (s *Server) SrvRattach(b*bytes.Buffer) (err error) {
-	SFID, AFID, Uname, Aname,  t, err := UnmarshalTattachPkt(b)
-	//if err != nil {
-	//}
-	if QID,  err := s.NS.Rattach(SFID, AFID, Uname, Aname); err != nil {
-	MarshalRerrorPkt(b, t, fmt.Sprintf("%v", err))
-} else {
-	MarshalRattachPkt(b, t, QID)
-}
-	return nil
-}
 
-func (c *Client)CallTattach (SFID FID, AFID FID, Uname string, Aname string) (QID QID,  err error) {
-var b = bytes.Buffer{}
-if c.Trace != nil {c.Trace("%v", Tattach)}
-t := Tag(0)
-r := make (chan []byte)
-if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
-MarshalTattachPkt(&b, t, SFID, AFID, Uname, Aname)
-c.FromClient <- &RPCCall{b: b.Bytes(), Reply: r}
-bb := <-r
-if MType(bb[4]) == Rerror {
-	s, _, err := UnmarshalRerrorPkt(bytes.NewBuffer(bb[5:]))
-	if err != nil {
-		return QID,  err
-	}
-	return QID,  fmt.Errorf("%v", s)
-} else {
-	QID,  _, err = UnmarshalRattachPkt(bytes.NewBuffer(bb[5:]))
-}
-return QID,  err
-}
-func MarshalRflushPkt (b *bytes.Buffer, t Tag, ) {
-var l uint64
+func UnmarshalTauthPkt (b *bytes.Buffer) (AFID FID, Uname string, Aname string,  t Tag, err error) {
+AFID, Uname, Aname,  t, err = DecodeTauthPkt(b.Bytes())
 b.Reset()
-b.Write([]byte{0,0,0,0,
-uint8(Rflush),
-byte(t), byte(t>>8),
-	})
-
-{
-l = uint64(b.Len())
-copy(b.Bytes(), []byte{uint8(l), uint8(l>>8), uint8(l>>16), uint8(l>>24)})
-}
-return
-}
-func UnmarshalRflushPkt (b *bytes.Buffer) ( t Tag, err error) {
-var u [8]uint8
-var l uint64
-if _, err = b.Read(u[:2]); err != nil {
-err = fmt.Errorf("pkt too short for Tag; need 2, have %d", b.Len())
 return
 }
-l = uint64(u[0]) | uint64(u[1])<<8
-t = Tag(l)
 
-if b.Len() > 0 {
-err = fmt.Errorf("Packet too long: %d bytes left over after decode", b.Len())
+func (TauthPkt) Type() MType {
+	return Tauth
 }
-return
+
+// Encode appends m, with tag t, to b.
+func (m TauthPkt) Encode(b []byte, t Tag) []byte {
+	return AppendTauthPkt(b, t, m.AFID, m.Uname, m.Aname)
 }
-func MarshalTflushPkt (b *bytes.Buffer, t Tag, OTag Tag) {
-var l uint64
-b.Reset()
-b.Write([]byte{0,0,0,0,
-uint8(Tflush),
-byte(t), byte(t>>8),
-	uint8(OTag>>0),
-	uint8(OTag>>8),
-	})
 
-{
-l = uint64(b.Len())
-copy(b.Bytes(), []byte{uint8(l), uint8(l>>8), uint8(l>>16), uint8(l>>24)})
+// Decode decodes m from b, which holds all of a Tauth, and returns its tag.
+func (m *TauthPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Tauth); err != nil {
+		return 0, err
+	}
+	AFID, Uname, Aname, t, err := DecodeTauthPkt(b[5:])
+	if err != nil {
+		if t, derr := m.DecodeDotu(b); derr == nil {
+			return t, nil
+		}
+		return t, err
+	}
+	*m = TauthPkt{ AFID: AFID, Uname: Uname, Aname: Aname }
+	return t, nil
 }
-return
+
+func (m TauthPkt) String() string {
+	return fmt.Sprintf("Tauth AFID %v Uname %q Aname %q NUname %v", m.AFID, m.Uname, m.Aname, m.NUname)
 }
-func UnmarshalTflushPkt (b *bytes.Buffer) (OTag Tag,  t Tag, err error) {
-var u [8]uint8
-var l uint64
-if _, err = b.Read(u[:2]); err != nil {
-err = fmt.Errorf("pkt too short for Tag; need 2, have %d", b.Len())
-return
+
+func (c *Client)CallTauth (AFID FID, Uname string, Aname string) (AQID QID,  err error) {
+return c.CallTauthContext(context.Background(), AFID, Uname, Aname)
 }
-l = uint64(u[0]) | uint64(u[1])<<8
-t = Tag(l)
-	if _, err = b.Read(u[:2]); err != nil {
-		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", b.Len())
-	return
-	}
-	OTag = Tag(u[0])
-	OTag |= Tag(u[1])<<8
 
-if b.Len() > 0 {
-err = fmt.Errorf("Packet too long: %d bytes left over after decode", b.Len())
+func (c *Client)CallTauthContext (ctx context.Context, AFID FID, Uname string, Aname string) (AQID QID,  err error) {
+if c.Trace != nil {c.Trace("%v", Tauth)}
+t := Tag(0)
+if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
+b := AppendTauthPkt(nil, t, AFID, Uname, Aname)
+if err = c.fits(len(b)); err != nil {
+	return AQID,  err
 }
-return
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return AQID,  err
 }
-func (s *Server) SrvRflush(b*bytes.Buffer) (err error) {
-	OTag,  t, err := UnmarshalTflushPkt(b)
-	//if err != nil {
-	//}
-	if  err := s.NS.Rflush(OTag); err != nil {
-	MarshalRerrorPkt(b, t, fmt.Sprintf("%v", err))
-} else {
-	MarshalRflushPkt(b, t, )
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return AQID,  err
 }
-	return nil
+AQID,  _, err = DecodeRauthPkt(bb[5:])
+putFrame(bb)
+return AQID,  err
 }
 
-func (c *Client)CallTflush (OTag Tag) ( err error) {
-var b = bytes.Buffer{}
-if c.Trace != nil {c.Trace("%v", Tflush)}
-t := Tag(0)
-r := make (chan []byte)
-if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
-MarshalTflushPkt(&b, t, OTag)
-c.FromClient <- &RPCCall{b: b.Bytes(), Reply: r}
-bb := <-r
-if MType(bb[4]) == Rerror {
-	s, _, err := UnmarshalRerrorPkt(bytes.NewBuffer(bb[5:]))
-	if err != nil {
-		return  err
-	}
-	return  fmt.Errorf("%v", s)
-} else {
-	 _, err = UnmarshalRflushPkt(bytes.NewBuffer(bb[5:]))
+type TauthReply struct {
+AQID QID
 }
-return  err
-}
-func MarshalRwalkPkt (b *bytes.Buffer, t Tag, QIDs []QID) {
-var l uint64
-b.Reset()
-b.Write([]byte{0,0,0,0,
-uint8(Rwalk),
+
+func (c *Client)GoTauth (ctx context.Context, AFID FID, Uname string, Aname string, done chan *Call) *Call {
+b := AppendTauthPkt(nil, Tag(0), AFID, Uname, Aname)
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	AQID,  _, err := DecodeRauthPkt(bb[5:])
+	putFrame(bb)
+	return &TauthReply{ AQID: AQID }, err
+})
+}
+// AppendTauthDotuPkt appends a Tauth with tag t, from its size on, to b.
+func AppendTauthDotuPkt (b []byte, t Tag, AFID FID, Uname string, Aname string, NUname uint32) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Tauth),
 byte(t), byte(t>>8),
-	uint8(len(QIDs)>>0),
-	uint8(len(QIDs)>>8),
-	})
-for i := range QIDs {
-	b.Write([]byte{	uint8(QIDs[i].Type>>0),
-	uint8(QIDs[i].Version>>0),
-	uint8(QIDs[i].Version>>8),
-	uint8(QIDs[i].Version>>16),
-	uint8(QIDs[i].Version>>24),
-	uint8(QIDs[i].Path>>0),
-	uint8(QIDs[i].Path>>8),
-	uint8(QIDs[i].Path>>16),
-	uint8(QIDs[i].Path>>24),
-	uint8(QIDs[i].Path>>32),
-	uint8(QIDs[i].Path>>40),
-	uint8(QIDs[i].Path>>48),
-	uint8(QIDs[i].Path>>56),
-	})
-}
+	uint8(AFID>>0),
+	uint8(AFID>>8),
+	uint8(AFID>>16),
+	uint8(AFID>>24),
+	uint8(len(Uname)),uint8(len(Uname)>>8),
+	)
+	b = append(b, Uname...)
+	b = append(b,	uint8(len(Aname)),uint8(len(Aname)>>8),
+	)
+	b = append(b, Aname...)
+	b = append(b,	uint8(NUname>>0),
+	uint8(NUname>>8),
+	uint8(NUname>>16),
+	uint8(NUname>>24),
+	)
 
-{
-l = uint64(b.Len())
-copy(b.Bytes(), []byte{uint8(l), uint8(l>>8), uint8(l>>16), uint8(l>>24)})
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
 }
-return
+
+func MarshalTauthDotuPkt (b *bytes.Buffer, t Tag, AFID FID, Uname string, Aname string, NUname uint32) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendTauthDotuPkt(b.Bytes(), t, AFID, Uname, Aname, NUname))
 }
-func UnmarshalRwalkPkt (b *bytes.Buffer) (QIDs []QID,  t Tag, err error) {
-var u [8]uint8
+// DecodeTauthDotuPkt decodes a Tauth from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeTauthDotuPkt (b []byte) (AFID FID, Uname string, Aname string, NUname uint32,  t Tag, err error) {
 var l uint64
-if _, err = b.Read(u[:2]); err != nil {
-err = fmt.Errorf("pkt too short for Tag; need 2, have %d", b.Len())
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
 return
 }
-l = uint64(u[0]) | uint64(u[1])<<8
-t = Tag(l)
-	if _, err = b.Read(u[:2]); err != nil {
-		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", b.Len())
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
 	return
 	}
-	l = uint64(u[0])
-	l |= uint64(u[1])<<8
-	QIDs = make([]QID, l)
-for i := range QIDs {
-	if _, err = b.Read(u[:1]); err != nil {
-		err = fmt.Errorf("pkt too short for uint8: need 1, have %d", b.Len())
+	AFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
 	return
 	}
-	QIDs[i].Type = uint8(u[0])
-	if _, err = b.Read(u[:4]); err != nil {
-		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", b.Len())
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
 	return
 	}
-	QIDs[i].Version = uint32(u[0])
-	QIDs[i].Version |= uint32(u[1])<<8
-	QIDs[i].Version |= uint32(u[2])<<16
-	QIDs[i].Version |= uint32(u[3])<<24
-	if _, err = b.Read(u[:8]); err != nil {
-		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", b.Len())
+	Uname = string(b[:l])
+	b = b[l:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
 	return
 	}
-	QIDs[i].Path = uint64(u[0])
-	QIDs[i].Path |= uint64(u[1])<<8
-	QIDs[i].Path |= uint64(u[2])<<16
-	QIDs[i].Path |= uint64(u[3])<<24
-	QIDs[i].Path |= uint64(u[4])<<32
-	QIDs[i].Path |= uint64(u[5])<<40
-	QIDs[i].Path |= uint64(u[6])<<48
-	QIDs[i].Path |= uint64(u[7])<<56
-}
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
+	return
+	}
+	Aname = string(b[:l])
+	b = b[l:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	NUname = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
 
-if b.Len() > 0 {
-err = fmt.Errorf("Packet too long: %d bytes left over after decode", b.Len())
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
 }
 return
 }
-func MarshalTwalkPkt (b *bytes.Buffer, t Tag, SFID FID, NewFID FID, Paths []string) {
+
+func UnmarshalTauthDotuPkt (b *bytes.Buffer) (AFID FID, Uname string, Aname string, NUname uint32,  t Tag, err error) {
+AFID, Uname, Aname, NUname,  t, err = DecodeTauthDotuPkt(b.Bytes())
+b.Reset()
+return
+}
+
+// EncodeDotu appends m, with tag t, to b, with its 9P2000.u fields.
+func (m TauthPkt) EncodeDotu(b []byte, t Tag) []byte {
+	return AppendTauthDotuPkt(b, t, m.AFID, m.Uname, m.Aname, m.NUname)
+}
+
+// DecodeDotu is Decode for a Tauth with its 9P2000.u fields.
+func (m *TauthPkt) DecodeDotu(b []byte) (Tag, error) {
+	if err := checkMsg(b, Tauth); err != nil {
+		return 0, err
+	}
+	AFID, Uname, Aname, NUname, t, err := DecodeTauthDotuPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = TauthPkt{ AFID: AFID, Uname: Uname, Aname: Aname, NUname: NUname }
+	return t, nil
+}
+
+func (c *Client)CallTauthDotu (AFID FID, Uname string, Aname string, NUname uint32) (AQID QID,  err error) {
+return c.CallTauthDotuContext(context.Background(), AFID, Uname, Aname, NUname)
+}
+
+func (c *Client)CallTauthDotuContext (ctx context.Context, AFID FID, Uname string, Aname string, NUname uint32) (AQID QID,  err error) {
+if c.Trace != nil {c.Trace("%v", Tauth)}
+t := Tag(0)
+if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
+b := AppendTauthDotuPkt(nil, t, AFID, Uname, Aname, NUname)
+if err = c.fits(len(b)); err != nil {
+	return AQID,  err
+}
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return AQID,  err
+}
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return AQID,  err
+}
+AQID,  _, err = DecodeRauthPkt(bb[5:])
+putFrame(bb)
+return AQID,  err
+}
+
+type TauthDotuReply struct {
+AQID QID
+}
+
+func (c *Client)GoTauthDotu (ctx context.Context, AFID FID, Uname string, Aname string, NUname uint32, done chan *Call) *Call {
+b := AppendTauthDotuPkt(nil, Tag(0), AFID, Uname, Aname, NUname)
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	AQID,  _, err := DecodeRauthPkt(bb[5:])
+	putFrame(bb)
+	return &TauthDotuReply{ AQID: AQID }, err
+})
+}
+// AppendRattachPkt appends a Rattach with tag t, from its size on, to b.
+func AppendRattachPkt (b []byte, t Tag, QID QID) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Rattach),
+byte(t), byte(t>>8),
+	uint8(QID.Type>>0),
+	uint8(QID.Version>>0),
+	uint8(QID.Version>>8),
+	uint8(QID.Version>>16),
+	uint8(QID.Version>>24),
+	uint8(QID.Path>>0),
+	uint8(QID.Path>>8),
+	uint8(QID.Path>>16),
+	uint8(QID.Path>>24),
+	uint8(QID.Path>>32),
+	uint8(QID.Path>>40),
+	uint8(QID.Path>>48),
+	uint8(QID.Path>>56),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalRattachPkt (b *bytes.Buffer, t Tag, QID QID) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendRattachPkt(b.Bytes(), t, QID))
+}
+// DecodeRattachPkt decodes a Rattach from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeRattachPkt (b []byte) (QID QID,  t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 1 {
+		err = fmt.Errorf("pkt too short for uint8: need 1, have %d", len(b))
+	return
+	}
+	QID.Type = uint8(b[0])
+	b = b[1:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	QID.Version = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	QID.Path = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalRattachPkt (b *bytes.Buffer) (QID QID,  t Tag, err error) {
+QID,  t, err = DecodeRattachPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (RattachPkt) Type() MType {
+	return Rattach
+}
+
+// Encode appends m, with tag t, to b.
+func (m RattachPkt) Encode(b []byte, t Tag) []byte {
+	return AppendRattachPkt(b, t, m.QID)
+}
+
+// Decode decodes m from b, which holds all of a Rattach, and returns its tag.
+func (m *RattachPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Rattach); err != nil {
+		return 0, err
+	}
+	QID, t, err := DecodeRattachPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = RattachPkt{ QID: QID }
+	return t, nil
+}
+
+func (m RattachPkt) String() string {
+	return fmt.Sprintf("Rattach QID %v", m.QID)
+}
+// AppendTattachPkt appends a Tattach with tag t, from its size on, to b.
+func AppendTattachPkt (b []byte, t Tag, SFID FID, AFID FID, Uname string, Aname string) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Tattach),
+byte(t), byte(t>>8),
+	uint8(SFID>>0),
+	uint8(SFID>>8),
+	uint8(SFID>>16),
+	uint8(SFID>>24),
+	uint8(AFID>>0),
+	uint8(AFID>>8),
+	uint8(AFID>>16),
+	uint8(AFID>>24),
+	uint8(len(Uname)),uint8(len(Uname)>>8),
+	)
+	b = append(b, Uname...)
+	b = append(b,	uint8(len(Aname)),uint8(len(Aname)>>8),
+	)
+	b = append(b, Aname...)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalTattachPkt (b *bytes.Buffer, t Tag, SFID FID, AFID FID, Uname string, Aname string) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendTattachPkt(b.Bytes(), t, SFID, AFID, Uname, Aname))
+}
+// DecodeTattachPkt decodes a Tattach from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeTattachPkt (b []byte) (SFID FID, AFID FID, Uname string, Aname string,  t Tag, err error) {
+var l uint64
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	SFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	AFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
+	return
+	}
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
+	return
+	}
+	Uname = string(b[:l])
+	b = b[l:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
+	return
+	}
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
+	return
+	}
+	Aname = string(b[:l])
+	b = b[l:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalTattachPkt (b *bytes.Buffer) (SFID FID, AFID FID, Uname string, Aname string,  t Tag, err error) {
+SFID, AFID, Uname, Aname,  t, err = DecodeTattachPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (TattachPkt) Type() MType {
+	return Tattach
+}
+
+// Encode appends m, with tag t, to b.
+func (m TattachPkt) Encode(b []byte, t Tag) []byte {
+	return AppendTattachPkt(b, t, m.SFID, m.AFID, m.Uname, m.Aname)
+}
+
+// Decode decodes m from b, which holds all of a Tattach, and returns its tag.
+func (m *TattachPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Tattach); err != nil {
+		return 0, err
+	}
+	SFID, AFID, Uname, Aname, t, err := DecodeTattachPkt(b[5:])
+	if err != nil {
+		if t, derr := m.DecodeDotu(b); derr == nil {
+			return t, nil
+		}
+		return t, err
+	}
+	*m = TattachPkt{ SFID: SFID, AFID: AFID, Uname: Uname, Aname: Aname }
+	return t, nil
+}
+
+func (m TattachPkt) String() string {
+	return fmt.Sprintf("Tattach SFID %v AFID %v Uname %q Aname %q NUname %v", m.SFID, m.AFID, m.Uname, m.Aname, m.NUname)
+}
+func (s *Server) SrvRattach(ctx context.Context, b*bytes.Buffer) (err error) {
+	SFID, AFID, Uname, Aname,  t, err := UnmarshalTattachPkt(b)
+	//if err != nil {
+	//}
+	if QID,  err := s.session(ctx).nsc.Rattach(ctx, SFID, AFID, Uname, Aname); err != nil {
+	s.rerror(ctx, b, t, err)
+} else {
+	MarshalRattachPkt(b, t, QID)
+}
+	return nil
+}
+
+func (c *Client)CallTattach (SFID FID, AFID FID, Uname string, Aname string) (QID QID,  err error) {
+return c.CallTattachContext(context.Background(), SFID, AFID, Uname, Aname)
+}
+
+func (c *Client)CallTattachContext (ctx context.Context, SFID FID, AFID FID, Uname string, Aname string) (QID QID,  err error) {
+if c.Trace != nil {c.Trace("%v", Tattach)}
+t := Tag(0)
+if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
+b := AppendTattachPkt(nil, t, SFID, AFID, Uname, Aname)
+if err = c.fits(len(b)); err != nil {
+	return QID,  err
+}
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return QID,  err
+}
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return QID,  err
+}
+QID,  _, err = DecodeRattachPkt(bb[5:])
+putFrame(bb)
+return QID,  err
+}
+
+type TattachReply struct {
+QID QID
+}
+
+func (c *Client)GoTattach (ctx context.Context, SFID FID, AFID FID, Uname string, Aname string, done chan *Call) *Call {
+b := AppendTattachPkt(nil, Tag(0), SFID, AFID, Uname, Aname)
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	QID,  _, err := DecodeRattachPkt(bb[5:])
+	putFrame(bb)
+	return &TattachReply{ QID: QID }, err
+})
+}
+// AppendTattachDotuPkt appends a Tattach with tag t, from its size on, to b.
+func AppendTattachDotuPkt (b []byte, t Tag, SFID FID, AFID FID, Uname string, Aname string, NUname uint32) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Tattach),
+byte(t), byte(t>>8),
+	uint8(SFID>>0),
+	uint8(SFID>>8),
+	uint8(SFID>>16),
+	uint8(SFID>>24),
+	uint8(AFID>>0),
+	uint8(AFID>>8),
+	uint8(AFID>>16),
+	uint8(AFID>>24),
+	uint8(len(Uname)),uint8(len(Uname)>>8),
+	)
+	b = append(b, Uname...)
+	b = append(b,	uint8(len(Aname)),uint8(len(Aname)>>8),
+	)
+	b = append(b, Aname...)
+	b = append(b,	uint8(NUname>>0),
+	uint8(NUname>>8),
+	uint8(NUname>>16),
+	uint8(NUname>>24),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalTattachDotuPkt (b *bytes.Buffer, t Tag, SFID FID, AFID FID, Uname string, Aname string, NUname uint32) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendTattachDotuPkt(b.Bytes(), t, SFID, AFID, Uname, Aname, NUname))
+}
+// DecodeTattachDotuPkt decodes a Tattach from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeTattachDotuPkt (b []byte) (SFID FID, AFID FID, Uname string, Aname string, NUname uint32,  t Tag, err error) {
 var l uint64
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	SFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	AFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
+	return
+	}
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
+	return
+	}
+	Uname = string(b[:l])
+	b = b[l:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
+	return
+	}
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
+	return
+	}
+	Aname = string(b[:l])
+	b = b[l:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	NUname = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalTattachDotuPkt (b *bytes.Buffer) (SFID FID, AFID FID, Uname string, Aname string, NUname uint32,  t Tag, err error) {
+SFID, AFID, Uname, Aname, NUname,  t, err = DecodeTattachDotuPkt(b.Bytes())
+b.Reset()
+return
+}
+
+// EncodeDotu appends m, with tag t, to b, with its 9P2000.u fields.
+func (m TattachPkt) EncodeDotu(b []byte, t Tag) []byte {
+	return AppendTattachDotuPkt(b, t, m.SFID, m.AFID, m.Uname, m.Aname, m.NUname)
+}
+
+// DecodeDotu is Decode for a Tattach with its 9P2000.u fields.
+func (m *TattachPkt) DecodeDotu(b []byte) (Tag, error) {
+	if err := checkMsg(b, Tattach); err != nil {
+		return 0, err
+	}
+	SFID, AFID, Uname, Aname, NUname, t, err := DecodeTattachDotuPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = TattachPkt{ SFID: SFID, AFID: AFID, Uname: Uname, Aname: Aname, NUname: NUname }
+	return t, nil
+}
+func (s *Server) SrvRattachDotu(ctx context.Context, b*bytes.Buffer) (err error) {
+	SFID, AFID, Uname, Aname, NUname,  t, err := UnmarshalTattachDotuPkt(b)
+	//if err != nil {
+	//}
+	if QID,  err := s.session(ctx).dotu.RattachDotu(ctx, SFID, AFID, Uname, Aname, NUname); err != nil {
+	s.rerror(ctx, b, t, err)
+} else {
+	MarshalRattachPkt(b, t, QID)
+}
+	return nil
+}
+
+func (c *Client)CallTattachDotu (SFID FID, AFID FID, Uname string, Aname string, NUname uint32) (QID QID,  err error) {
+return c.CallTattachDotuContext(context.Background(), SFID, AFID, Uname, Aname, NUname)
+}
+
+func (c *Client)CallTattachDotuContext (ctx context.Context, SFID FID, AFID FID, Uname string, Aname string, NUname uint32) (QID QID,  err error) {
+if c.Trace != nil {c.Trace("%v", Tattach)}
+t := Tag(0)
+if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
+b := AppendTattachDotuPkt(nil, t, SFID, AFID, Uname, Aname, NUname)
+if err = c.fits(len(b)); err != nil {
+	return QID,  err
+}
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return QID,  err
+}
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return QID,  err
+}
+QID,  _, err = DecodeRattachPkt(bb[5:])
+putFrame(bb)
+return QID,  err
+}
+
+type TattachDotuReply struct {
+QID QID
+}
+
+func (c *Client)GoTattachDotu (ctx context.Context, SFID FID, AFID FID, Uname string, Aname string, NUname uint32, done chan *Call) *Call {
+b := AppendTattachDotuPkt(nil, Tag(0), SFID, AFID, Uname, Aname, NUname)
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	QID,  _, err := DecodeRattachPkt(bb[5:])
+	putFrame(bb)
+	return &TattachDotuReply{ QID: QID }, err
+})
+}
+// AppendRflushPkt appends a Rflush with tag t, from its size on, to b.
+func AppendRflushPkt (b []byte, t Tag, ) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Rflush),
+byte(t), byte(t>>8),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalRflushPkt (b *bytes.Buffer, t Tag, ) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendRflushPkt(b.Bytes(), t, ))
+}
+// DecodeRflushPkt decodes a Rflush from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeRflushPkt (b []byte) ( t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalRflushPkt (b *bytes.Buffer) ( t Tag, err error) {
+ t, err = DecodeRflushPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (RflushPkt) Type() MType {
+	return Rflush
+}
+
+// Encode appends m, with tag t, to b.
+func (m RflushPkt) Encode(b []byte, t Tag) []byte {
+	return AppendRflushPkt(b, t, )
+}
+
+// Decode decodes m from b, which holds all of a Rflush, and returns its tag.
+func (m *RflushPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Rflush); err != nil {
+		return 0, err
+	}
+	t, err := DecodeRflushPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = RflushPkt{  }
+	return t, nil
+}
+
+func (m RflushPkt) String() string {
+	return fmt.Sprintf("Rflush")
+}
+// AppendTflushPkt appends a Tflush with tag t, from its size on, to b.
+func AppendTflushPkt (b []byte, t Tag, OTag Tag) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Tflush),
+byte(t), byte(t>>8),
+	uint8(OTag>>0),
+	uint8(OTag>>8),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalTflushPkt (b *bytes.Buffer, t Tag, OTag Tag) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendTflushPkt(b.Bytes(), t, OTag))
+}
+// DecodeTflushPkt decodes a Tflush from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeTflushPkt (b []byte) (OTag Tag,  t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
+	return
+	}
+	OTag = Tag(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalTflushPkt (b *bytes.Buffer) (OTag Tag,  t Tag, err error) {
+OTag,  t, err = DecodeTflushPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (TflushPkt) Type() MType {
+	return Tflush
+}
+
+// Encode appends m, with tag t, to b.
+func (m TflushPkt) Encode(b []byte, t Tag) []byte {
+	return AppendTflushPkt(b, t, m.OTag)
+}
+
+// Decode decodes m from b, which holds all of a Tflush, and returns its tag.
+func (m *TflushPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Tflush); err != nil {
+		return 0, err
+	}
+	OTag, t, err := DecodeTflushPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = TflushPkt{ OTag: OTag }
+	return t, nil
+}
+
+func (m TflushPkt) String() string {
+	return fmt.Sprintf("Tflush OTag %v", m.OTag)
+}
+
+func (c *Client)CallTflush (OTag Tag) ( err error) {
+return c.CallTflushContext(context.Background(), OTag)
+}
+
+func (c *Client)CallTflushContext (ctx context.Context, OTag Tag) ( err error) {
+if c.Trace != nil {c.Trace("%v", Tflush)}
+t := Tag(0)
+if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
+b := AppendTflushPkt(nil, t, OTag)
+if err = c.fits(len(b)); err != nil {
+	return  err
+}
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return  err
+}
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return  err
+}
+ _, err = DecodeRflushPkt(bb[5:])
+putFrame(bb)
+return  err
+}
+
+type TflushReply struct {
+
+}
+
+func (c *Client)GoTflush (ctx context.Context, OTag Tag, done chan *Call) *Call {
+b := AppendTflushPkt(nil, Tag(0), OTag)
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	 _, err := DecodeRflushPkt(bb[5:])
+	putFrame(bb)
+	return &TflushReply{  }, err
+})
+}
+// AppendRwalkPkt appends a Rwalk with tag t, from its size on, to b.
+func AppendRwalkPkt (b []byte, t Tag, QIDs []QID) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Rwalk),
+byte(t), byte(t>>8),
+	uint8(len(QIDs)>>0),
+	uint8(len(QIDs)>>8),
+	)
+for i := range QIDs {
+	b = append(b,	uint8(QIDs[i].Type>>0),
+	uint8(QIDs[i].Version>>0),
+	uint8(QIDs[i].Version>>8),
+	uint8(QIDs[i].Version>>16),
+	uint8(QIDs[i].Version>>24),
+	uint8(QIDs[i].Path>>0),
+	uint8(QIDs[i].Path>>8),
+	uint8(QIDs[i].Path>>16),
+	uint8(QIDs[i].Path>>24),
+	uint8(QIDs[i].Path>>32),
+	uint8(QIDs[i].Path>>40),
+	uint8(QIDs[i].Path>>48),
+	uint8(QIDs[i].Path>>56),
+	)
+}
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalRwalkPkt (b *bytes.Buffer, t Tag, QIDs []QID) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendRwalkPkt(b.Bytes(), t, QIDs))
+}
+// DecodeRwalkPkt decodes a Rwalk from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeRwalkPkt (b []byte) (QIDs []QID,  t Tag, err error) {
+var l uint64
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
+	return
+	}
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	QIDs = make([]QID, l)
+for i := range QIDs {
+	if len(b) < 1 {
+		err = fmt.Errorf("pkt too short for uint8: need 1, have %d", len(b))
+	return
+	}
+	QIDs[i].Type = uint8(b[0])
+	b = b[1:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	QIDs[i].Version = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	QIDs[i].Path = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+}
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalRwalkPkt (b *bytes.Buffer) (QIDs []QID,  t Tag, err error) {
+QIDs,  t, err = DecodeRwalkPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (RwalkPkt) Type() MType {
+	return Rwalk
+}
+
+// Encode appends m, with tag t, to b.
+func (m RwalkPkt) Encode(b []byte, t Tag) []byte {
+	return AppendRwalkPkt(b, t, m.QIDs)
+}
+
+// Decode decodes m from b, which holds all of a Rwalk, and returns its tag.
+func (m *RwalkPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Rwalk); err != nil {
+		return 0, err
+	}
+	QIDs, t, err := DecodeRwalkPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = RwalkPkt{ QIDs: QIDs }
+	return t, nil
+}
+
+func (m RwalkPkt) String() string {
+	return fmt.Sprintf("Rwalk QIDs %v", m.QIDs)
+}
+// AppendTwalkPkt appends a Twalk with tag t, from its size on, to b.
+func AppendTwalkPkt (b []byte, t Tag, SFID FID, NewFID FID, Paths []string) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Twalk),
+byte(t), byte(t>>8),
+	uint8(SFID>>0),
+	uint8(SFID>>8),
+	uint8(SFID>>16),
+	uint8(SFID>>24),
+	uint8(NewFID>>0),
+	uint8(NewFID>>8),
+	uint8(NewFID>>16),
+	uint8(NewFID>>24),
+	uint8(len(Paths)>>0),
+	uint8(len(Paths)>>8),
+	)
+for i := range Paths {
+	b = append(b,	uint8(len(Paths[i])),uint8(len(Paths[i])>>8),
+	)
+	b = append(b, Paths[i]...)
+}
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalTwalkPkt (b *bytes.Buffer, t Tag, SFID FID, NewFID FID, Paths []string) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendTwalkPkt(b.Bytes(), t, SFID, NewFID, Paths))
+}
+// DecodeTwalkPkt decodes a Twalk from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeTwalkPkt (b []byte) (SFID FID, NewFID FID, Paths []string,  t Tag, err error) {
+var l uint64
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	SFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	NewFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
+	return
+	}
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	Paths = make([]string, l)
+for i := range Paths {
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
+	return
+	}
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
+	return
+	}
+	Paths[i] = string(b[:l])
+	b = b[l:]
+}
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalTwalkPkt (b *bytes.Buffer) (SFID FID, NewFID FID, Paths []string,  t Tag, err error) {
+SFID, NewFID, Paths,  t, err = DecodeTwalkPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (TwalkPkt) Type() MType {
+	return Twalk
+}
+
+// Encode appends m, with tag t, to b.
+func (m TwalkPkt) Encode(b []byte, t Tag) []byte {
+	return AppendTwalkPkt(b, t, m.SFID, m.NewFID, m.Paths)
+}
+
+// Decode decodes m from b, which holds all of a Twalk, and returns its tag.
+func (m *TwalkPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Twalk); err != nil {
+		return 0, err
+	}
+	SFID, NewFID, Paths, t, err := DecodeTwalkPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = TwalkPkt{ SFID: SFID, NewFID: NewFID, Paths: Paths }
+	return t, nil
+}
+
+func (m TwalkPkt) String() string {
+	return fmt.Sprintf("Twalk SFID %v NewFID %v Paths %q", m.SFID, m.NewFID, m.Paths)
+}
+func (s *Server) SrvRwalk(ctx context.Context, b*bytes.Buffer) (err error) {
+	SFID, NewFID, Paths,  t, err := UnmarshalTwalkPkt(b)
+	//if err != nil {
+	//}
+	if QIDs,  err := s.session(ctx).nsc.Rwalk(ctx, SFID, NewFID, Paths); err != nil {
+	s.rerror(ctx, b, t, err)
+} else {
+	MarshalRwalkPkt(b, t, QIDs)
+}
+	return nil
+}
+
+func (c *Client)CallTwalk (SFID FID, NewFID FID, Paths []string) (QIDs []QID,  err error) {
+return c.CallTwalkContext(context.Background(), SFID, NewFID, Paths)
+}
+
+func (c *Client)CallTwalkContext (ctx context.Context, SFID FID, NewFID FID, Paths []string) (QIDs []QID,  err error) {
+if c.Trace != nil {c.Trace("%v", Twalk)}
+t := Tag(0)
+if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
+b := AppendTwalkPkt(nil, t, SFID, NewFID, Paths)
+if err = c.fits(len(b)); err != nil {
+	return QIDs,  err
+}
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return QIDs,  err
+}
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return QIDs,  err
+}
+QIDs,  _, err = DecodeRwalkPkt(bb[5:])
+putFrame(bb)
+return QIDs,  err
+}
+
+type TwalkReply struct {
+QIDs []QID
+}
+
+func (c *Client)GoTwalk (ctx context.Context, SFID FID, NewFID FID, Paths []string, done chan *Call) *Call {
+b := AppendTwalkPkt(nil, Tag(0), SFID, NewFID, Paths)
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	QIDs,  _, err := DecodeRwalkPkt(bb[5:])
+	putFrame(bb)
+	return &TwalkReply{ QIDs: QIDs }, err
+})
+}
+// AppendRopenPkt appends a Ropen with tag t, from its size on, to b.
+func AppendRopenPkt (b []byte, t Tag, OQID QID, IOUnit MaxSize) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Ropen),
+byte(t), byte(t>>8),
+	uint8(OQID.Type>>0),
+	uint8(OQID.Version>>0),
+	uint8(OQID.Version>>8),
+	uint8(OQID.Version>>16),
+	uint8(OQID.Version>>24),
+	uint8(OQID.Path>>0),
+	uint8(OQID.Path>>8),
+	uint8(OQID.Path>>16),
+	uint8(OQID.Path>>24),
+	uint8(OQID.Path>>32),
+	uint8(OQID.Path>>40),
+	uint8(OQID.Path>>48),
+	uint8(OQID.Path>>56),
+	uint8(IOUnit>>0),
+	uint8(IOUnit>>8),
+	uint8(IOUnit>>16),
+	uint8(IOUnit>>24),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalRopenPkt (b *bytes.Buffer, t Tag, OQID QID, IOUnit MaxSize) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendRopenPkt(b.Bytes(), t, OQID, IOUnit))
+}
+// DecodeRopenPkt decodes a Ropen from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeRopenPkt (b []byte) (OQID QID, IOUnit MaxSize,  t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 1 {
+		err = fmt.Errorf("pkt too short for uint8: need 1, have %d", len(b))
+	return
+	}
+	OQID.Type = uint8(b[0])
+	b = b[1:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	OQID.Version = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	OQID.Path = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	IOUnit = MaxSize(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalRopenPkt (b *bytes.Buffer) (OQID QID, IOUnit MaxSize,  t Tag, err error) {
+OQID, IOUnit,  t, err = DecodeRopenPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (RopenPkt) Type() MType {
+	return Ropen
+}
+
+// Encode appends m, with tag t, to b.
+func (m RopenPkt) Encode(b []byte, t Tag) []byte {
+	return AppendRopenPkt(b, t, m.OQID, m.IOUnit)
+}
+
+// Decode decodes m from b, which holds all of a Ropen, and returns its tag.
+func (m *RopenPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Ropen); err != nil {
+		return 0, err
+	}
+	OQID, IOUnit, t, err := DecodeRopenPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = RopenPkt{ OQID: OQID, IOUnit: IOUnit }
+	return t, nil
+}
+
+func (m RopenPkt) String() string {
+	return fmt.Sprintf("Ropen OQID %v IOUnit %v", m.OQID, m.IOUnit)
+}
+// AppendTopenPkt appends a Topen with tag t, from its size on, to b.
+func AppendTopenPkt (b []byte, t Tag, OFID FID, Omode Mode) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Topen),
+byte(t), byte(t>>8),
+	uint8(OFID>>0),
+	uint8(OFID>>8),
+	uint8(OFID>>16),
+	uint8(OFID>>24),
+	uint8(Omode>>0),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalTopenPkt (b *bytes.Buffer, t Tag, OFID FID, Omode Mode) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendTopenPkt(b.Bytes(), t, OFID, Omode))
+}
+// DecodeTopenPkt decodes a Topen from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeTopenPkt (b []byte) (OFID FID, Omode Mode,  t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	OFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 1 {
+		err = fmt.Errorf("pkt too short for uint8: need 1, have %d", len(b))
+	return
+	}
+	Omode = Mode(b[0])
+	b = b[1:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalTopenPkt (b *bytes.Buffer) (OFID FID, Omode Mode,  t Tag, err error) {
+OFID, Omode,  t, err = DecodeTopenPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (TopenPkt) Type() MType {
+	return Topen
+}
+
+// Encode appends m, with tag t, to b.
+func (m TopenPkt) Encode(b []byte, t Tag) []byte {
+	return AppendTopenPkt(b, t, m.OFID, m.Omode)
+}
+
+// Decode decodes m from b, which holds all of a Topen, and returns its tag.
+func (m *TopenPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Topen); err != nil {
+		return 0, err
+	}
+	OFID, Omode, t, err := DecodeTopenPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = TopenPkt{ OFID: OFID, Omode: Omode }
+	return t, nil
+}
+
+func (m TopenPkt) String() string {
+	return fmt.Sprintf("Topen OFID %v Omode %v", m.OFID, m.Omode)
+}
+func (s *Server) SrvRopen(ctx context.Context, b*bytes.Buffer) (err error) {
+	OFID, Omode,  t, err := UnmarshalTopenPkt(b)
+	//if err != nil {
+	//}
+	if OQID, IOUnit,  err := s.session(ctx).nsc.Ropen(ctx, OFID, Omode); err != nil {
+	s.rerror(ctx, b, t, err)
+} else {
+	MarshalRopenPkt(b, t, OQID, IOUnit)
+}
+	return nil
+}
+
+func (c *Client)CallTopen (OFID FID, Omode Mode) (OQID QID, IOUnit MaxSize,  err error) {
+return c.CallTopenContext(context.Background(), OFID, Omode)
+}
+
+func (c *Client)CallTopenContext (ctx context.Context, OFID FID, Omode Mode) (OQID QID, IOUnit MaxSize,  err error) {
+if c.Trace != nil {c.Trace("%v", Topen)}
+t := Tag(0)
+if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
+b := AppendTopenPkt(nil, t, OFID, Omode)
+if err = c.fits(len(b)); err != nil {
+	return OQID, IOUnit,  err
+}
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return OQID, IOUnit,  err
+}
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return OQID, IOUnit,  err
+}
+OQID, IOUnit,  _, err = DecodeRopenPkt(bb[5:])
+putFrame(bb)
+return OQID, IOUnit,  err
+}
+
+type TopenReply struct {
+OQID QID
+IOUnit MaxSize
+}
+
+func (c *Client)GoTopen (ctx context.Context, OFID FID, Omode Mode, done chan *Call) *Call {
+b := AppendTopenPkt(nil, Tag(0), OFID, Omode)
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	OQID, IOUnit,  _, err := DecodeRopenPkt(bb[5:])
+	putFrame(bb)
+	return &TopenReply{ OQID: OQID, IOUnit: IOUnit }, err
+})
+}
+// AppendRcreatePkt appends a Rcreate with tag t, from its size on, to b.
+func AppendRcreatePkt (b []byte, t Tag, OQID QID, IOUnit MaxSize) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Rcreate),
+byte(t), byte(t>>8),
+	uint8(OQID.Type>>0),
+	uint8(OQID.Version>>0),
+	uint8(OQID.Version>>8),
+	uint8(OQID.Version>>16),
+	uint8(OQID.Version>>24),
+	uint8(OQID.Path>>0),
+	uint8(OQID.Path>>8),
+	uint8(OQID.Path>>16),
+	uint8(OQID.Path>>24),
+	uint8(OQID.Path>>32),
+	uint8(OQID.Path>>40),
+	uint8(OQID.Path>>48),
+	uint8(OQID.Path>>56),
+	uint8(IOUnit>>0),
+	uint8(IOUnit>>8),
+	uint8(IOUnit>>16),
+	uint8(IOUnit>>24),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalRcreatePkt (b *bytes.Buffer, t Tag, OQID QID, IOUnit MaxSize) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendRcreatePkt(b.Bytes(), t, OQID, IOUnit))
+}
+// DecodeRcreatePkt decodes a Rcreate from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeRcreatePkt (b []byte) (OQID QID, IOUnit MaxSize,  t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 1 {
+		err = fmt.Errorf("pkt too short for uint8: need 1, have %d", len(b))
+	return
+	}
+	OQID.Type = uint8(b[0])
+	b = b[1:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	OQID.Version = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	OQID.Path = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	IOUnit = MaxSize(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalRcreatePkt (b *bytes.Buffer) (OQID QID, IOUnit MaxSize,  t Tag, err error) {
+OQID, IOUnit,  t, err = DecodeRcreatePkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (RcreatePkt) Type() MType {
+	return Rcreate
+}
+
+// Encode appends m, with tag t, to b.
+func (m RcreatePkt) Encode(b []byte, t Tag) []byte {
+	return AppendRcreatePkt(b, t, m.OQID, m.IOUnit)
+}
+
+// Decode decodes m from b, which holds all of a Rcreate, and returns its tag.
+func (m *RcreatePkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Rcreate); err != nil {
+		return 0, err
+	}
+	OQID, IOUnit, t, err := DecodeRcreatePkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = RcreatePkt{ OQID: OQID, IOUnit: IOUnit }
+	return t, nil
+}
+
+func (m RcreatePkt) String() string {
+	return fmt.Sprintf("Rcreate OQID %v IOUnit %v", m.OQID, m.IOUnit)
+}
+// AppendTcreatePkt appends a Tcreate with tag t, from its size on, to b.
+func AppendTcreatePkt (b []byte, t Tag, OFID FID, Name string, CreatePerm Perm, Omode Mode) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Tcreate),
+byte(t), byte(t>>8),
+	uint8(OFID>>0),
+	uint8(OFID>>8),
+	uint8(OFID>>16),
+	uint8(OFID>>24),
+	uint8(len(Name)),uint8(len(Name)>>8),
+	)
+	b = append(b, Name...)
+	b = append(b,	uint8(CreatePerm>>0),
+	uint8(CreatePerm>>8),
+	uint8(CreatePerm>>16),
+	uint8(CreatePerm>>24),
+	uint8(Omode>>0),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalTcreatePkt (b *bytes.Buffer, t Tag, OFID FID, Name string, CreatePerm Perm, Omode Mode) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendTcreatePkt(b.Bytes(), t, OFID, Name, CreatePerm, Omode))
+}
+// DecodeTcreatePkt decodes a Tcreate from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeTcreatePkt (b []byte) (OFID FID, Name string, CreatePerm Perm, Omode Mode,  t Tag, err error) {
+var l uint64
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	OFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
+	return
+	}
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
+	return
+	}
+	Name = string(b[:l])
+	b = b[l:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	CreatePerm = Perm(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 1 {
+		err = fmt.Errorf("pkt too short for uint8: need 1, have %d", len(b))
+	return
+	}
+	Omode = Mode(b[0])
+	b = b[1:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalTcreatePkt (b *bytes.Buffer) (OFID FID, Name string, CreatePerm Perm, Omode Mode,  t Tag, err error) {
+OFID, Name, CreatePerm, Omode,  t, err = DecodeTcreatePkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (TcreatePkt) Type() MType {
+	return Tcreate
+}
+
+// Encode appends m, with tag t, to b.
+func (m TcreatePkt) Encode(b []byte, t Tag) []byte {
+	return AppendTcreatePkt(b, t, m.OFID, m.Name, m.CreatePerm, m.Omode)
+}
+
+// Decode decodes m from b, which holds all of a Tcreate, and returns its tag.
+func (m *TcreatePkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Tcreate); err != nil {
+		return 0, err
+	}
+	OFID, Name, CreatePerm, Omode, t, err := DecodeTcreatePkt(b[5:])
+	if err != nil {
+		if t, derr := m.DecodeDotu(b); derr == nil {
+			return t, nil
+		}
+		return t, err
+	}
+	*m = TcreatePkt{ OFID: OFID, Name: Name, CreatePerm: CreatePerm, Omode: Omode }
+	return t, nil
+}
+
+func (m TcreatePkt) String() string {
+	return fmt.Sprintf("Tcreate OFID %v Name %q CreatePerm %v Omode %v Extension %q", m.OFID, m.Name, m.CreatePerm, m.Omode, m.Extension)
+}
+func (s *Server) SrvRcreate(ctx context.Context, b*bytes.Buffer) (err error) {
+	OFID, Name, CreatePerm, Omode,  t, err := UnmarshalTcreatePkt(b)
+	//if err != nil {
+	//}
+	if OQID, IOUnit,  err := s.session(ctx).nsc.Rcreate(ctx, OFID, Name, CreatePerm, Omode); err != nil {
+	s.rerror(ctx, b, t, err)
+} else {
+	MarshalRcreatePkt(b, t, OQID, IOUnit)
+}
+	return nil
+}
+
+func (c *Client)CallTcreate (OFID FID, Name string, CreatePerm Perm, Omode Mode) (OQID QID, IOUnit MaxSize,  err error) {
+return c.CallTcreateContext(context.Background(), OFID, Name, CreatePerm, Omode)
+}
+
+func (c *Client)CallTcreateContext (ctx context.Context, OFID FID, Name string, CreatePerm Perm, Omode Mode) (OQID QID, IOUnit MaxSize,  err error) {
+if c.Trace != nil {c.Trace("%v", Tcreate)}
+t := Tag(0)
+if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
+b := AppendTcreatePkt(nil, t, OFID, Name, CreatePerm, Omode)
+if err = c.fits(len(b)); err != nil {
+	return OQID, IOUnit,  err
+}
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return OQID, IOUnit,  err
+}
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return OQID, IOUnit,  err
+}
+OQID, IOUnit,  _, err = DecodeRcreatePkt(bb[5:])
+putFrame(bb)
+return OQID, IOUnit,  err
+}
+
+type TcreateReply struct {
+OQID QID
+IOUnit MaxSize
+}
+
+func (c *Client)GoTcreate (ctx context.Context, OFID FID, Name string, CreatePerm Perm, Omode Mode, done chan *Call) *Call {
+b := AppendTcreatePkt(nil, Tag(0), OFID, Name, CreatePerm, Omode)
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	OQID, IOUnit,  _, err := DecodeRcreatePkt(bb[5:])
+	putFrame(bb)
+	return &TcreateReply{ OQID: OQID, IOUnit: IOUnit }, err
+})
+}
+// AppendTcreateDotuPkt appends a Tcreate with tag t, from its size on, to b.
+func AppendTcreateDotuPkt (b []byte, t Tag, OFID FID, Name string, CreatePerm Perm, Omode Mode, Extension string) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Tcreate),
+byte(t), byte(t>>8),
+	uint8(OFID>>0),
+	uint8(OFID>>8),
+	uint8(OFID>>16),
+	uint8(OFID>>24),
+	uint8(len(Name)),uint8(len(Name)>>8),
+	)
+	b = append(b, Name...)
+	b = append(b,	uint8(CreatePerm>>0),
+	uint8(CreatePerm>>8),
+	uint8(CreatePerm>>16),
+	uint8(CreatePerm>>24),
+	uint8(Omode>>0),
+	uint8(len(Extension)),uint8(len(Extension)>>8),
+	)
+	b = append(b, Extension...)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalTcreateDotuPkt (b *bytes.Buffer, t Tag, OFID FID, Name string, CreatePerm Perm, Omode Mode, Extension string) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendTcreateDotuPkt(b.Bytes(), t, OFID, Name, CreatePerm, Omode, Extension))
+}
+// DecodeTcreateDotuPkt decodes a Tcreate from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeTcreateDotuPkt (b []byte) (OFID FID, Name string, CreatePerm Perm, Omode Mode, Extension string,  t Tag, err error) {
+var l uint64
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	OFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
+	return
+	}
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
+	return
+	}
+	Name = string(b[:l])
+	b = b[l:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	CreatePerm = Perm(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 1 {
+		err = fmt.Errorf("pkt too short for uint8: need 1, have %d", len(b))
+	return
+	}
+	Omode = Mode(b[0])
+	b = b[1:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
+	return
+	}
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
+	return
+	}
+	Extension = string(b[:l])
+	b = b[l:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalTcreateDotuPkt (b *bytes.Buffer) (OFID FID, Name string, CreatePerm Perm, Omode Mode, Extension string,  t Tag, err error) {
+OFID, Name, CreatePerm, Omode, Extension,  t, err = DecodeTcreateDotuPkt(b.Bytes())
+b.Reset()
+return
+}
+
+// EncodeDotu appends m, with tag t, to b, with its 9P2000.u fields.
+func (m TcreatePkt) EncodeDotu(b []byte, t Tag) []byte {
+	return AppendTcreateDotuPkt(b, t, m.OFID, m.Name, m.CreatePerm, m.Omode, m.Extension)
+}
+
+// DecodeDotu is Decode for a Tcreate with its 9P2000.u fields.
+func (m *TcreatePkt) DecodeDotu(b []byte) (Tag, error) {
+	if err := checkMsg(b, Tcreate); err != nil {
+		return 0, err
+	}
+	OFID, Name, CreatePerm, Omode, Extension, t, err := DecodeTcreateDotuPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = TcreatePkt{ OFID: OFID, Name: Name, CreatePerm: CreatePerm, Omode: Omode, Extension: Extension }
+	return t, nil
+}
+func (s *Server) SrvRcreateDotu(ctx context.Context, b*bytes.Buffer) (err error) {
+	OFID, Name, CreatePerm, Omode, Extension,  t, err := UnmarshalTcreateDotuPkt(b)
+	//if err != nil {
+	//}
+	if OQID, IOUnit,  err := s.session(ctx).dotu.RcreateDotu(ctx, OFID, Name, CreatePerm, Omode, Extension); err != nil {
+	s.rerror(ctx, b, t, err)
+} else {
+	MarshalRcreatePkt(b, t, OQID, IOUnit)
+}
+	return nil
+}
+
+func (c *Client)CallTcreateDotu (OFID FID, Name string, CreatePerm Perm, Omode Mode, Extension string) (OQID QID, IOUnit MaxSize,  err error) {
+return c.CallTcreateDotuContext(context.Background(), OFID, Name, CreatePerm, Omode, Extension)
+}
+
+func (c *Client)CallTcreateDotuContext (ctx context.Context, OFID FID, Name string, CreatePerm Perm, Omode Mode, Extension string) (OQID QID, IOUnit MaxSize,  err error) {
+if c.Trace != nil {c.Trace("%v", Tcreate)}
+t := Tag(0)
+if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
+b := AppendTcreateDotuPkt(nil, t, OFID, Name, CreatePerm, Omode, Extension)
+if err = c.fits(len(b)); err != nil {
+	return OQID, IOUnit,  err
+}
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return OQID, IOUnit,  err
+}
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return OQID, IOUnit,  err
+}
+OQID, IOUnit,  _, err = DecodeRcreatePkt(bb[5:])
+putFrame(bb)
+return OQID, IOUnit,  err
+}
+
+type TcreateDotuReply struct {
+OQID QID
+IOUnit MaxSize
+}
+
+func (c *Client)GoTcreateDotu (ctx context.Context, OFID FID, Name string, CreatePerm Perm, Omode Mode, Extension string, done chan *Call) *Call {
+b := AppendTcreateDotuPkt(nil, Tag(0), OFID, Name, CreatePerm, Omode, Extension)
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	OQID, IOUnit,  _, err := DecodeRcreatePkt(bb[5:])
+	putFrame(bb)
+	return &TcreateDotuReply{ OQID: OQID, IOUnit: IOUnit }, err
+})
+}
+// AppendRstatPkt appends a Rstat with tag t, from its size on, to b.
+func AppendRstatPkt (b []byte, t Tag, B []byte) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Rstat),
+byte(t), byte(t>>8),
+	uint8(len(B)>>0),
+	uint8(len(B)>>8),
+	)
+	b = append(b, B...)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalRstatPkt (b *bytes.Buffer, t Tag, B []byte) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendRstatPkt(b.Bytes(), t, B))
+}
+// DecodeRstatPkt decodes a Rstat from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeRstatPkt (b []byte) (B []byte,  t Tag, err error) {
+var l uint64
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
+	return
+	}
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for data: need %d, have %d", l, len(b))
+	return
+	}
+	B = b[:l:l]
+	b = b[l:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalRstatPkt (b *bytes.Buffer) (B []byte,  t Tag, err error) {
+B,  t, err = DecodeRstatPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (RstatPkt) Type() MType {
+	return Rstat
+}
+
+// Encode appends m, with tag t, to b.
+func (m RstatPkt) Encode(b []byte, t Tag) []byte {
+	return AppendRstatPkt(b, t, m.B)
+}
+
+// Decode decodes m from b, which holds all of a Rstat, and returns its tag.
+func (m *RstatPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Rstat); err != nil {
+		return 0, err
+	}
+	B, t, err := DecodeRstatPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = RstatPkt{ B: B }
+	return t, nil
+}
+
+func (m RstatPkt) String() string {
+	return fmt.Sprintf("Rstat B <%d bytes>", len(m.B))
+}
+// AppendTstatPkt appends a Tstat with tag t, from its size on, to b.
+func AppendTstatPkt (b []byte, t Tag, OFID FID) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Tstat),
+byte(t), byte(t>>8),
+	uint8(OFID>>0),
+	uint8(OFID>>8),
+	uint8(OFID>>16),
+	uint8(OFID>>24),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalTstatPkt (b *bytes.Buffer, t Tag, OFID FID) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendTstatPkt(b.Bytes(), t, OFID))
+}
+// DecodeTstatPkt decodes a Tstat from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeTstatPkt (b []byte) (OFID FID,  t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	OFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalTstatPkt (b *bytes.Buffer) (OFID FID,  t Tag, err error) {
+OFID,  t, err = DecodeTstatPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (TstatPkt) Type() MType {
+	return Tstat
+}
+
+// Encode appends m, with tag t, to b.
+func (m TstatPkt) Encode(b []byte, t Tag) []byte {
+	return AppendTstatPkt(b, t, m.OFID)
+}
+
+// Decode decodes m from b, which holds all of a Tstat, and returns its tag.
+func (m *TstatPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Tstat); err != nil {
+		return 0, err
+	}
+	OFID, t, err := DecodeTstatPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = TstatPkt{ OFID: OFID }
+	return t, nil
+}
+
+func (m TstatPkt) String() string {
+	return fmt.Sprintf("Tstat OFID %v", m.OFID)
+}
+func (s *Server) SrvRstat(ctx context.Context, b*bytes.Buffer) (err error) {
+	OFID,  t, err := UnmarshalTstatPkt(b)
+	//if err != nil {
+	//}
+	if B,  err := s.session(ctx).nsc.Rstat(ctx, OFID); err != nil {
+	s.rerror(ctx, b, t, err)
+} else {
+	MarshalRstatPkt(b, t, B)
+}
+	return nil
+}
+
+func (c *Client)CallTstat (OFID FID) (B []byte,  err error) {
+return c.CallTstatContext(context.Background(), OFID)
+}
+
+func (c *Client)CallTstatContext (ctx context.Context, OFID FID) (B []byte,  err error) {
+if c.Trace != nil {c.Trace("%v", Tstat)}
+t := Tag(0)
+if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
+b := AppendTstatPkt(nil, t, OFID)
+if err = c.fits(len(b)); err != nil {
+	return B,  err
+}
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return B,  err
+}
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return B,  err
+}
+B,  _, err = DecodeRstatPkt(bb[5:])
+return B,  err
+}
+
+type TstatReply struct {
+B []byte
+}
+
+func (c *Client)GoTstat (ctx context.Context, OFID FID, done chan *Call) *Call {
+b := AppendTstatPkt(nil, Tag(0), OFID)
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	B,  _, err := DecodeRstatPkt(bb[5:])
+	return &TstatReply{ B: B }, err
+})
+}
+// AppendRwstatPkt appends a Rwstat with tag t, from its size on, to b.
+func AppendRwstatPkt (b []byte, t Tag, ) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Rwstat),
+byte(t), byte(t>>8),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalRwstatPkt (b *bytes.Buffer, t Tag, ) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendRwstatPkt(b.Bytes(), t, ))
+}
+// DecodeRwstatPkt decodes a Rwstat from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeRwstatPkt (b []byte) ( t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalRwstatPkt (b *bytes.Buffer) ( t Tag, err error) {
+ t, err = DecodeRwstatPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (RwstatPkt) Type() MType {
+	return Rwstat
+}
+
+// Encode appends m, with tag t, to b.
+func (m RwstatPkt) Encode(b []byte, t Tag) []byte {
+	return AppendRwstatPkt(b, t, )
+}
+
+// Decode decodes m from b, which holds all of a Rwstat, and returns its tag.
+func (m *RwstatPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Rwstat); err != nil {
+		return 0, err
+	}
+	t, err := DecodeRwstatPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = RwstatPkt{  }
+	return t, nil
+}
+
+func (m RwstatPkt) String() string {
+	return fmt.Sprintf("Rwstat")
+}
+// AppendTwstatPkt appends a Twstat with tag t, from its size on, to b.
+func AppendTwstatPkt (b []byte, t Tag, OFID FID, B []byte) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Twstat),
+byte(t), byte(t>>8),
+	uint8(OFID>>0),
+	uint8(OFID>>8),
+	uint8(OFID>>16),
+	uint8(OFID>>24),
+	uint8(len(B)>>0),
+	uint8(len(B)>>8),
+	)
+	b = append(b, B...)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalTwstatPkt (b *bytes.Buffer, t Tag, OFID FID, B []byte) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendTwstatPkt(b.Bytes(), t, OFID, B))
+}
+// DecodeTwstatPkt decodes a Twstat from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeTwstatPkt (b []byte) (OFID FID, B []byte,  t Tag, err error) {
+var l uint64
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	OFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
+	return
+	}
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for data: need %d, have %d", l, len(b))
+	return
+	}
+	B = b[:l:l]
+	b = b[l:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalTwstatPkt (b *bytes.Buffer) (OFID FID, B []byte,  t Tag, err error) {
+OFID, B,  t, err = DecodeTwstatPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (TwstatPkt) Type() MType {
+	return Twstat
+}
+
+// Encode appends m, with tag t, to b.
+func (m TwstatPkt) Encode(b []byte, t Tag) []byte {
+	return AppendTwstatPkt(b, t, m.OFID, m.B)
+}
+
+// Decode decodes m from b, which holds all of a Twstat, and returns its tag.
+func (m *TwstatPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Twstat); err != nil {
+		return 0, err
+	}
+	OFID, B, t, err := DecodeTwstatPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = TwstatPkt{ OFID: OFID, B: B }
+	return t, nil
+}
+
+func (m TwstatPkt) String() string {
+	return fmt.Sprintf("Twstat OFID %v B <%d bytes>", m.OFID, len(m.B))
+}
+func (s *Server) SrvRwstat(ctx context.Context, b*bytes.Buffer) (err error) {
+	OFID, B,  t, err := UnmarshalTwstatPkt(b)
+	//if err != nil {
+	//}
+	if  err := s.session(ctx).nsc.Rwstat(ctx, OFID, B); err != nil {
+	s.rerror(ctx, b, t, err)
+} else {
+	MarshalRwstatPkt(b, t, )
+}
+	return nil
+}
+
+func (c *Client)CallTwstat (OFID FID, B []byte) ( err error) {
+return c.CallTwstatContext(context.Background(), OFID, B)
+}
+
+func (c *Client)CallTwstatContext (ctx context.Context, OFID FID, B []byte) ( err error) {
+if c.Trace != nil {c.Trace("%v", Twstat)}
+t := Tag(0)
+if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
+b := AppendTwstatPkt(nil, t, OFID, B)
+if err = c.fits(len(b)); err != nil {
+	return  err
+}
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return  err
+}
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return  err
+}
+ _, err = DecodeRwstatPkt(bb[5:])
+putFrame(bb)
+return  err
+}
+
+type TwstatReply struct {
+
+}
+
+func (c *Client)GoTwstat (ctx context.Context, OFID FID, B []byte, done chan *Call) *Call {
+b := AppendTwstatPkt(nil, Tag(0), OFID, B)
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	 _, err := DecodeRwstatPkt(bb[5:])
+	putFrame(bb)
+	return &TwstatReply{  }, err
+})
+}
+// AppendRclunkPkt appends a Rclunk with tag t, from its size on, to b.
+func AppendRclunkPkt (b []byte, t Tag, ) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Rclunk),
+byte(t), byte(t>>8),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalRclunkPkt (b *bytes.Buffer, t Tag, ) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendRclunkPkt(b.Bytes(), t, ))
+}
+// DecodeRclunkPkt decodes a Rclunk from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeRclunkPkt (b []byte) ( t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalRclunkPkt (b *bytes.Buffer) ( t Tag, err error) {
+ t, err = DecodeRclunkPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (RclunkPkt) Type() MType {
+	return Rclunk
+}
+
+// Encode appends m, with tag t, to b.
+func (m RclunkPkt) Encode(b []byte, t Tag) []byte {
+	return AppendRclunkPkt(b, t, )
+}
+
+// Decode decodes m from b, which holds all of a Rclunk, and returns its tag.
+func (m *RclunkPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Rclunk); err != nil {
+		return 0, err
+	}
+	t, err := DecodeRclunkPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = RclunkPkt{  }
+	return t, nil
+}
+
+func (m RclunkPkt) String() string {
+	return fmt.Sprintf("Rclunk")
+}
+// AppendTclunkPkt appends a Tclunk with tag t, from its size on, to b.
+func AppendTclunkPkt (b []byte, t Tag, OFID FID) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Tclunk),
+byte(t), byte(t>>8),
+	uint8(OFID>>0),
+	uint8(OFID>>8),
+	uint8(OFID>>16),
+	uint8(OFID>>24),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalTclunkPkt (b *bytes.Buffer, t Tag, OFID FID) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendTclunkPkt(b.Bytes(), t, OFID))
+}
+// DecodeTclunkPkt decodes a Tclunk from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeTclunkPkt (b []byte) (OFID FID,  t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	OFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalTclunkPkt (b *bytes.Buffer) (OFID FID,  t Tag, err error) {
+OFID,  t, err = DecodeTclunkPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (TclunkPkt) Type() MType {
+	return Tclunk
+}
+
+// Encode appends m, with tag t, to b.
+func (m TclunkPkt) Encode(b []byte, t Tag) []byte {
+	return AppendTclunkPkt(b, t, m.OFID)
+}
+
+// Decode decodes m from b, which holds all of a Tclunk, and returns its tag.
+func (m *TclunkPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Tclunk); err != nil {
+		return 0, err
+	}
+	OFID, t, err := DecodeTclunkPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = TclunkPkt{ OFID: OFID }
+	return t, nil
+}
+
+func (m TclunkPkt) String() string {
+	return fmt.Sprintf("Tclunk OFID %v", m.OFID)
+}
+func (s *Server) SrvRclunk(ctx context.Context, b*bytes.Buffer) (err error) {
+	OFID,  t, err := UnmarshalTclunkPkt(b)
+	//if err != nil {
+	//}
+	if  err := s.session(ctx).nsc.Rclunk(ctx, OFID); err != nil {
+	s.rerror(ctx, b, t, err)
+} else {
+	MarshalRclunkPkt(b, t, )
+}
+	return nil
+}
+
+func (c *Client)CallTclunk (OFID FID) ( err error) {
+return c.CallTclunkContext(context.Background(), OFID)
+}
+
+func (c *Client)CallTclunkContext (ctx context.Context, OFID FID) ( err error) {
+if c.Trace != nil {c.Trace("%v", Tclunk)}
+t := Tag(0)
+if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
+b := AppendTclunkPkt(nil, t, OFID)
+if err = c.fits(len(b)); err != nil {
+	return  err
+}
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return  err
+}
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return  err
+}
+ _, err = DecodeRclunkPkt(bb[5:])
+putFrame(bb)
+return  err
+}
+
+type TclunkReply struct {
+
+}
+
+func (c *Client)GoTclunk (ctx context.Context, OFID FID, done chan *Call) *Call {
+b := AppendTclunkPkt(nil, Tag(0), OFID)
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	 _, err := DecodeRclunkPkt(bb[5:])
+	putFrame(bb)
+	return &TclunkReply{  }, err
+})
+}
+// AppendRremovePkt appends a Rremove with tag t, from its size on, to b.
+func AppendRremovePkt (b []byte, t Tag, ) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Rremove),
+byte(t), byte(t>>8),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalRremovePkt (b *bytes.Buffer, t Tag, ) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendRremovePkt(b.Bytes(), t, ))
+}
+// DecodeRremovePkt decodes a Rremove from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeRremovePkt (b []byte) ( t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalRremovePkt (b *bytes.Buffer) ( t Tag, err error) {
+ t, err = DecodeRremovePkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (RremovePkt) Type() MType {
+	return Rremove
+}
+
+// Encode appends m, with tag t, to b.
+func (m RremovePkt) Encode(b []byte, t Tag) []byte {
+	return AppendRremovePkt(b, t, )
+}
+
+// Decode decodes m from b, which holds all of a Rremove, and returns its tag.
+func (m *RremovePkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Rremove); err != nil {
+		return 0, err
+	}
+	t, err := DecodeRremovePkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = RremovePkt{  }
+	return t, nil
+}
+
+func (m RremovePkt) String() string {
+	return fmt.Sprintf("Rremove")
+}
+// AppendTremovePkt appends a Tremove with tag t, from its size on, to b.
+func AppendTremovePkt (b []byte, t Tag, OFID FID) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Tremove),
+byte(t), byte(t>>8),
+	uint8(OFID>>0),
+	uint8(OFID>>8),
+	uint8(OFID>>16),
+	uint8(OFID>>24),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalTremovePkt (b *bytes.Buffer, t Tag, OFID FID) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendTremovePkt(b.Bytes(), t, OFID))
+}
+// DecodeTremovePkt decodes a Tremove from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeTremovePkt (b []byte) (OFID FID,  t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	OFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalTremovePkt (b *bytes.Buffer) (OFID FID,  t Tag, err error) {
+OFID,  t, err = DecodeTremovePkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (TremovePkt) Type() MType {
+	return Tremove
+}
+
+// Encode appends m, with tag t, to b.
+func (m TremovePkt) Encode(b []byte, t Tag) []byte {
+	return AppendTremovePkt(b, t, m.OFID)
+}
+
+// Decode decodes m from b, which holds all of a Tremove, and returns its tag.
+func (m *TremovePkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Tremove); err != nil {
+		return 0, err
+	}
+	OFID, t, err := DecodeTremovePkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = TremovePkt{ OFID: OFID }
+	return t, nil
+}
+
+func (m TremovePkt) String() string {
+	return fmt.Sprintf("Tremove OFID %v", m.OFID)
+}
+func (s *Server) SrvRremove(ctx context.Context, b*bytes.Buffer) (err error) {
+	OFID,  t, err := UnmarshalTremovePkt(b)
+	//if err != nil {
+	//}
+	if  err := s.session(ctx).nsc.Rremove(ctx, OFID); err != nil {
+	s.rerror(ctx, b, t, err)
+} else {
+	MarshalRremovePkt(b, t, )
+}
+	return nil
+}
+
+func (c *Client)CallTremove (OFID FID) ( err error) {
+return c.CallTremoveContext(context.Background(), OFID)
+}
+
+func (c *Client)CallTremoveContext (ctx context.Context, OFID FID) ( err error) {
+if c.Trace != nil {c.Trace("%v", Tremove)}
+t := Tag(0)
+if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
+b := AppendTremovePkt(nil, t, OFID)
+if err = c.fits(len(b)); err != nil {
+	return  err
+}
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return  err
+}
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return  err
+}
+ _, err = DecodeRremovePkt(bb[5:])
+putFrame(bb)
+return  err
+}
+
+type TremoveReply struct {
+
+}
+
+func (c *Client)GoTremove (ctx context.Context, OFID FID, done chan *Call) *Call {
+b := AppendTremovePkt(nil, Tag(0), OFID)
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	 _, err := DecodeRremovePkt(bb[5:])
+	putFrame(bb)
+	return &TremoveReply{  }, err
+})
+}
+// AppendRreadPkt appends a Rread with tag t, from its size on, to b.
+func AppendRreadPkt (b []byte, t Tag, Data []uint8) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Rread),
+byte(t), byte(t>>8),
+	uint8(len(Data)>>0),
+	uint8(len(Data)>>8),
+	uint8(len(Data)>>16),
+	uint8(len(Data)>>24),
+	)
+	b = append(b, Data...)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalRreadPkt (b *bytes.Buffer, t Tag, Data []uint8) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendRreadPkt(b.Bytes(), t, Data))
+}
+// DecodeRreadPkt decodes a Rread from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeRreadPkt (b []byte) (Data []uint8,  t Tag, err error) {
+var l uint64
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	l = uint64(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for data: need %d, have %d", l, len(b))
+	return
+	}
+	Data = b[:l:l]
+	b = b[l:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalRreadPkt (b *bytes.Buffer) (Data []uint8,  t Tag, err error) {
+Data,  t, err = DecodeRreadPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (RreadPkt) Type() MType {
+	return Rread
+}
+
+// Encode appends m, with tag t, to b.
+func (m RreadPkt) Encode(b []byte, t Tag) []byte {
+	return AppendRreadPkt(b, t, m.Data)
+}
+
+// Decode decodes m from b, which holds all of a Rread, and returns its tag.
+func (m *RreadPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Rread); err != nil {
+		return 0, err
+	}
+	Data, t, err := DecodeRreadPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = RreadPkt{ Data: Data }
+	return t, nil
+}
+
+func (m RreadPkt) String() string {
+	return fmt.Sprintf("Rread Data <%d bytes>", len(m.Data))
+}
+// AppendTreadPkt appends a Tread with tag t, from its size on, to b.
+func AppendTreadPkt (b []byte, t Tag, OFID FID, Off Offset, Len Count) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Tread),
+byte(t), byte(t>>8),
+	uint8(OFID>>0),
+	uint8(OFID>>8),
+	uint8(OFID>>16),
+	uint8(OFID>>24),
+	uint8(Off>>0),
+	uint8(Off>>8),
+	uint8(Off>>16),
+	uint8(Off>>24),
+	uint8(Off>>32),
+	uint8(Off>>40),
+	uint8(Off>>48),
+	uint8(Off>>56),
+	uint8(Len>>0),
+	uint8(Len>>8),
+	uint8(Len>>16),
+	uint8(Len>>24),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalTreadPkt (b *bytes.Buffer, t Tag, OFID FID, Off Offset, Len Count) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendTreadPkt(b.Bytes(), t, OFID, Off, Len))
+}
+// DecodeTreadPkt decodes a Tread from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeTreadPkt (b []byte) (OFID FID, Off Offset, Len Count,  t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	OFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	Off = Offset(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	Len = Count(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalTreadPkt (b *bytes.Buffer) (OFID FID, Off Offset, Len Count,  t Tag, err error) {
+OFID, Off, Len,  t, err = DecodeTreadPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (TreadPkt) Type() MType {
+	return Tread
+}
+
+// Encode appends m, with tag t, to b.
+func (m TreadPkt) Encode(b []byte, t Tag) []byte {
+	return AppendTreadPkt(b, t, m.OFID, m.Off, m.Len)
+}
+
+// Decode decodes m from b, which holds all of a Tread, and returns its tag.
+func (m *TreadPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Tread); err != nil {
+		return 0, err
+	}
+	OFID, Off, Len, t, err := DecodeTreadPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = TreadPkt{ OFID: OFID, Off: Off, Len: Len }
+	return t, nil
+}
+
+func (m TreadPkt) String() string {
+	return fmt.Sprintf("Tread OFID %v Off %v Len %v", m.OFID, m.Off, m.Len)
+}
+
+func (c *Client)CallTread (OFID FID, Off Offset, Len Count) (Data []uint8,  err error) {
+return c.CallTreadContext(context.Background(), OFID, Off, Len)
+}
+
+func (c *Client)CallTreadContext (ctx context.Context, OFID FID, Off Offset, Len Count) (Data []uint8,  err error) {
+if c.Trace != nil {c.Trace("%v", Tread)}
+t := Tag(0)
+if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
+b := AppendTreadPkt(nil, t, OFID, Off, Len)
+if err = c.fits(len(b)); err != nil {
+	return Data,  err
+}
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return Data,  err
+}
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return Data,  err
+}
+Data,  _, err = DecodeRreadPkt(bb[5:])
+return Data,  err
+}
+
+type TreadReply struct {
+Data []uint8
+}
+
+func (c *Client)GoTread (ctx context.Context, OFID FID, Off Offset, Len Count, done chan *Call) *Call {
+b := AppendTreadPkt(nil, Tag(0), OFID, Off, Len)
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	Data,  _, err := DecodeRreadPkt(bb[5:])
+	return &TreadReply{ Data: Data }, err
+})
+}
+// AppendRwritePkt appends a Rwrite with tag t, from its size on, to b.
+func AppendRwritePkt (b []byte, t Tag, RLen Count) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Rwrite),
+byte(t), byte(t>>8),
+	uint8(RLen>>0),
+	uint8(RLen>>8),
+	uint8(RLen>>16),
+	uint8(RLen>>24),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalRwritePkt (b *bytes.Buffer, t Tag, RLen Count) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendRwritePkt(b.Bytes(), t, RLen))
+}
+// DecodeRwritePkt decodes a Rwrite from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeRwritePkt (b []byte) (RLen Count,  t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	RLen = Count(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalRwritePkt (b *bytes.Buffer) (RLen Count,  t Tag, err error) {
+RLen,  t, err = DecodeRwritePkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (RwritePkt) Type() MType {
+	return Rwrite
+}
+
+// Encode appends m, with tag t, to b.
+func (m RwritePkt) Encode(b []byte, t Tag) []byte {
+	return AppendRwritePkt(b, t, m.RLen)
+}
+
+// Decode decodes m from b, which holds all of a Rwrite, and returns its tag.
+func (m *RwritePkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Rwrite); err != nil {
+		return 0, err
+	}
+	RLen, t, err := DecodeRwritePkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = RwritePkt{ RLen: RLen }
+	return t, nil
+}
+
+func (m RwritePkt) String() string {
+	return fmt.Sprintf("Rwrite RLen %v", m.RLen)
+}
+// AppendTwritePkt appends a Twrite with tag t, from its size on, to b.
+func AppendTwritePkt (b []byte, t Tag, OFID FID, Off Offset, Data []uint8) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Twrite),
+byte(t), byte(t>>8),
+	uint8(OFID>>0),
+	uint8(OFID>>8),
+	uint8(OFID>>16),
+	uint8(OFID>>24),
+	uint8(Off>>0),
+	uint8(Off>>8),
+	uint8(Off>>16),
+	uint8(Off>>24),
+	uint8(Off>>32),
+	uint8(Off>>40),
+	uint8(Off>>48),
+	uint8(Off>>56),
+	uint8(len(Data)>>0),
+	uint8(len(Data)>>8),
+	uint8(len(Data)>>16),
+	uint8(len(Data)>>24),
+	)
+	b = append(b, Data...)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalTwritePkt (b *bytes.Buffer, t Tag, OFID FID, Off Offset, Data []uint8) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendTwritePkt(b.Bytes(), t, OFID, Off, Data))
+}
+// DecodeTwritePkt decodes a Twrite from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeTwritePkt (b []byte) (OFID FID, Off Offset, Data []uint8,  t Tag, err error) {
+var l uint64
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	OFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	Off = Offset(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	l = uint64(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for data: need %d, have %d", l, len(b))
+	return
+	}
+	Data = b[:l:l]
+	b = b[l:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalTwritePkt (b *bytes.Buffer) (OFID FID, Off Offset, Data []uint8,  t Tag, err error) {
+OFID, Off, Data,  t, err = DecodeTwritePkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (TwritePkt) Type() MType {
+	return Twrite
+}
+
+// Encode appends m, with tag t, to b.
+func (m TwritePkt) Encode(b []byte, t Tag) []byte {
+	return AppendTwritePkt(b, t, m.OFID, m.Off, m.Data)
+}
+
+// Decode decodes m from b, which holds all of a Twrite, and returns its tag.
+func (m *TwritePkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Twrite); err != nil {
+		return 0, err
+	}
+	OFID, Off, Data, t, err := DecodeTwritePkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = TwritePkt{ OFID: OFID, Off: Off, Data: Data }
+	return t, nil
+}
+
+func (m TwritePkt) String() string {
+	return fmt.Sprintf("Twrite OFID %v Off %v Data <%d bytes>", m.OFID, m.Off, len(m.Data))
+}
+
+func (c *Client)CallTwrite (OFID FID, Off Offset, Data []uint8) (RLen Count,  err error) {
+return c.CallTwriteContext(context.Background(), OFID, Off, Data)
+}
+
+func (c *Client)CallTwriteContext (ctx context.Context, OFID FID, Off Offset, Data []uint8) (RLen Count,  err error) {
+if c.Trace != nil {c.Trace("%v", Twrite)}
+t := Tag(0)
+if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
+b := AppendTwritePkt(nil, t, OFID, Off, Data)
+if err = c.fits(len(b)); err != nil {
+	return RLen,  err
+}
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return RLen,  err
+}
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return RLen,  err
+}
+RLen,  _, err = DecodeRwritePkt(bb[5:])
+putFrame(bb)
+return RLen,  err
+}
+
+type TwriteReply struct {
+RLen Count
+}
+
+func (c *Client)GoTwrite (ctx context.Context, OFID FID, Off Offset, Data []uint8, done chan *Call) *Call {
+b := AppendTwritePkt(nil, Tag(0), OFID, Off, Data)
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	RLen,  _, err := DecodeRwritePkt(bb[5:])
+	putFrame(bb)
+	return &TwriteReply{ RLen: RLen }, err
+})
+}
+// AppendRlerrorPkt appends a Rlerror with tag t, from its size on, to b.
+func AppendRlerrorPkt (b []byte, t Tag, Ecode uint32) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Rlerror),
+byte(t), byte(t>>8),
+	uint8(Ecode>>0),
+	uint8(Ecode>>8),
+	uint8(Ecode>>16),
+	uint8(Ecode>>24),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalRlerrorPkt (b *bytes.Buffer, t Tag, Ecode uint32) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendRlerrorPkt(b.Bytes(), t, Ecode))
+}
+// DecodeRlerrorPkt decodes a Rlerror from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeRlerrorPkt (b []byte) (Ecode uint32,  t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	Ecode = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalRlerrorPkt (b *bytes.Buffer) (Ecode uint32,  t Tag, err error) {
+Ecode,  t, err = DecodeRlerrorPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (RlerrorPkt) Type() MType {
+	return Rlerror
+}
+
+// Encode appends m, with tag t, to b.
+func (m RlerrorPkt) Encode(b []byte, t Tag) []byte {
+	return AppendRlerrorPkt(b, t, m.Ecode)
+}
+
+// Decode decodes m from b, which holds all of a Rlerror, and returns its tag.
+func (m *RlerrorPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Rlerror); err != nil {
+		return 0, err
+	}
+	Ecode, t, err := DecodeRlerrorPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = RlerrorPkt{ Ecode: Ecode }
+	return t, nil
+}
+
+func (m RlerrorPkt) String() string {
+	return fmt.Sprintf("Rlerror Ecode %v", m.Ecode)
+}
+// AppendRstatfsPkt appends a Rstatfs with tag t, from its size on, to b.
+func AppendRstatfsPkt (b []byte, t Tag, StatFS StatFS) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Rstatfs),
+byte(t), byte(t>>8),
+	uint8(StatFS.Type>>0),
+	uint8(StatFS.Type>>8),
+	uint8(StatFS.Type>>16),
+	uint8(StatFS.Type>>24),
+	uint8(StatFS.BSize>>0),
+	uint8(StatFS.BSize>>8),
+	uint8(StatFS.BSize>>16),
+	uint8(StatFS.BSize>>24),
+	uint8(StatFS.Blocks>>0),
+	uint8(StatFS.Blocks>>8),
+	uint8(StatFS.Blocks>>16),
+	uint8(StatFS.Blocks>>24),
+	uint8(StatFS.Blocks>>32),
+	uint8(StatFS.Blocks>>40),
+	uint8(StatFS.Blocks>>48),
+	uint8(StatFS.Blocks>>56),
+	uint8(StatFS.BFree>>0),
+	uint8(StatFS.BFree>>8),
+	uint8(StatFS.BFree>>16),
+	uint8(StatFS.BFree>>24),
+	uint8(StatFS.BFree>>32),
+	uint8(StatFS.BFree>>40),
+	uint8(StatFS.BFree>>48),
+	uint8(StatFS.BFree>>56),
+	uint8(StatFS.BAvail>>0),
+	uint8(StatFS.BAvail>>8),
+	uint8(StatFS.BAvail>>16),
+	uint8(StatFS.BAvail>>24),
+	uint8(StatFS.BAvail>>32),
+	uint8(StatFS.BAvail>>40),
+	uint8(StatFS.BAvail>>48),
+	uint8(StatFS.BAvail>>56),
+	uint8(StatFS.Files>>0),
+	uint8(StatFS.Files>>8),
+	uint8(StatFS.Files>>16),
+	uint8(StatFS.Files>>24),
+	uint8(StatFS.Files>>32),
+	uint8(StatFS.Files>>40),
+	uint8(StatFS.Files>>48),
+	uint8(StatFS.Files>>56),
+	uint8(StatFS.FFree>>0),
+	uint8(StatFS.FFree>>8),
+	uint8(StatFS.FFree>>16),
+	uint8(StatFS.FFree>>24),
+	uint8(StatFS.FFree>>32),
+	uint8(StatFS.FFree>>40),
+	uint8(StatFS.FFree>>48),
+	uint8(StatFS.FFree>>56),
+	uint8(StatFS.FSID>>0),
+	uint8(StatFS.FSID>>8),
+	uint8(StatFS.FSID>>16),
+	uint8(StatFS.FSID>>24),
+	uint8(StatFS.FSID>>32),
+	uint8(StatFS.FSID>>40),
+	uint8(StatFS.FSID>>48),
+	uint8(StatFS.FSID>>56),
+	uint8(StatFS.NameLen>>0),
+	uint8(StatFS.NameLen>>8),
+	uint8(StatFS.NameLen>>16),
+	uint8(StatFS.NameLen>>24),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalRstatfsPkt (b *bytes.Buffer, t Tag, StatFS StatFS) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendRstatfsPkt(b.Bytes(), t, StatFS))
+}
+// DecodeRstatfsPkt decodes a Rstatfs from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeRstatfsPkt (b []byte) (StatFS StatFS,  t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	StatFS.Type = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	StatFS.BSize = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	StatFS.Blocks = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	StatFS.BFree = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	StatFS.BAvail = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	StatFS.Files = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	StatFS.FFree = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	StatFS.FSID = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	StatFS.NameLen = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalRstatfsPkt (b *bytes.Buffer) (StatFS StatFS,  t Tag, err error) {
+StatFS,  t, err = DecodeRstatfsPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (RstatfsPkt) Type() MType {
+	return Rstatfs
+}
+
+// Encode appends m, with tag t, to b.
+func (m RstatfsPkt) Encode(b []byte, t Tag) []byte {
+	return AppendRstatfsPkt(b, t, m.StatFS)
+}
+
+// Decode decodes m from b, which holds all of a Rstatfs, and returns its tag.
+func (m *RstatfsPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Rstatfs); err != nil {
+		return 0, err
+	}
+	StatFS, t, err := DecodeRstatfsPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = RstatfsPkt{ StatFS: StatFS }
+	return t, nil
+}
+
+func (m RstatfsPkt) String() string {
+	return fmt.Sprintf("Rstatfs StatFS %v", m.StatFS)
+}
+// AppendTstatfsPkt appends a Tstatfs with tag t, from its size on, to b.
+func AppendTstatfsPkt (b []byte, t Tag, OFID FID) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Tstatfs),
+byte(t), byte(t>>8),
+	uint8(OFID>>0),
+	uint8(OFID>>8),
+	uint8(OFID>>16),
+	uint8(OFID>>24),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalTstatfsPkt (b *bytes.Buffer, t Tag, OFID FID) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendTstatfsPkt(b.Bytes(), t, OFID))
+}
+// DecodeTstatfsPkt decodes a Tstatfs from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeTstatfsPkt (b []byte) (OFID FID,  t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	OFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalTstatfsPkt (b *bytes.Buffer) (OFID FID,  t Tag, err error) {
+OFID,  t, err = DecodeTstatfsPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (TstatfsPkt) Type() MType {
+	return Tstatfs
+}
+
+// Encode appends m, with tag t, to b.
+func (m TstatfsPkt) Encode(b []byte, t Tag) []byte {
+	return AppendTstatfsPkt(b, t, m.OFID)
+}
+
+// Decode decodes m from b, which holds all of a Tstatfs, and returns its tag.
+func (m *TstatfsPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Tstatfs); err != nil {
+		return 0, err
+	}
+	OFID, t, err := DecodeTstatfsPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = TstatfsPkt{ OFID: OFID }
+	return t, nil
+}
+
+func (m TstatfsPkt) String() string {
+	return fmt.Sprintf("Tstatfs OFID %v", m.OFID)
+}
+func (s *Server) SrvRstatfs(ctx context.Context, b*bytes.Buffer) (err error) {
+	OFID,  t, err := UnmarshalTstatfsPkt(b)
+	//if err != nil {
+	//}
+	if StatFS,  err := s.session(ctx).dotl.Rstatfs(ctx, OFID); err != nil {
+	s.rerror(ctx, b, t, err)
+} else {
+	MarshalRstatfsPkt(b, t, StatFS)
+}
+	return nil
+}
+
+func (c *Client)CallTstatfs (OFID FID) (StatFS StatFS,  err error) {
+return c.CallTstatfsContext(context.Background(), OFID)
+}
+
+func (c *Client)CallTstatfsContext (ctx context.Context, OFID FID) (StatFS StatFS,  err error) {
+if c.Trace != nil {c.Trace("%v", Tstatfs)}
+t := Tag(0)
+if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
+b := AppendTstatfsPkt(nil, t, OFID)
+if err = c.fits(len(b)); err != nil {
+	return StatFS,  err
+}
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return StatFS,  err
+}
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return StatFS,  err
+}
+StatFS,  _, err = DecodeRstatfsPkt(bb[5:])
+putFrame(bb)
+return StatFS,  err
+}
+
+type TstatfsReply struct {
+StatFS StatFS
+}
+
+func (c *Client)GoTstatfs (ctx context.Context, OFID FID, done chan *Call) *Call {
+b := AppendTstatfsPkt(nil, Tag(0), OFID)
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	StatFS,  _, err := DecodeRstatfsPkt(bb[5:])
+	putFrame(bb)
+	return &TstatfsReply{ StatFS: StatFS }, err
+})
+}
+// AppendRlopenPkt appends a Rlopen with tag t, from its size on, to b.
+func AppendRlopenPkt (b []byte, t Tag, OQID QID, IOUnit MaxSize) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Rlopen),
+byte(t), byte(t>>8),
+	uint8(OQID.Type>>0),
+	uint8(OQID.Version>>0),
+	uint8(OQID.Version>>8),
+	uint8(OQID.Version>>16),
+	uint8(OQID.Version>>24),
+	uint8(OQID.Path>>0),
+	uint8(OQID.Path>>8),
+	uint8(OQID.Path>>16),
+	uint8(OQID.Path>>24),
+	uint8(OQID.Path>>32),
+	uint8(OQID.Path>>40),
+	uint8(OQID.Path>>48),
+	uint8(OQID.Path>>56),
+	uint8(IOUnit>>0),
+	uint8(IOUnit>>8),
+	uint8(IOUnit>>16),
+	uint8(IOUnit>>24),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalRlopenPkt (b *bytes.Buffer, t Tag, OQID QID, IOUnit MaxSize) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendRlopenPkt(b.Bytes(), t, OQID, IOUnit))
+}
+// DecodeRlopenPkt decodes a Rlopen from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeRlopenPkt (b []byte) (OQID QID, IOUnit MaxSize,  t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 1 {
+		err = fmt.Errorf("pkt too short for uint8: need 1, have %d", len(b))
+	return
+	}
+	OQID.Type = uint8(b[0])
+	b = b[1:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	OQID.Version = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	OQID.Path = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	IOUnit = MaxSize(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalRlopenPkt (b *bytes.Buffer) (OQID QID, IOUnit MaxSize,  t Tag, err error) {
+OQID, IOUnit,  t, err = DecodeRlopenPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (RlopenPkt) Type() MType {
+	return Rlopen
+}
+
+// Encode appends m, with tag t, to b.
+func (m RlopenPkt) Encode(b []byte, t Tag) []byte {
+	return AppendRlopenPkt(b, t, m.OQID, m.IOUnit)
+}
+
+// Decode decodes m from b, which holds all of a Rlopen, and returns its tag.
+func (m *RlopenPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Rlopen); err != nil {
+		return 0, err
+	}
+	OQID, IOUnit, t, err := DecodeRlopenPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = RlopenPkt{ OQID: OQID, IOUnit: IOUnit }
+	return t, nil
+}
+
+func (m RlopenPkt) String() string {
+	return fmt.Sprintf("Rlopen OQID %v IOUnit %v", m.OQID, m.IOUnit)
+}
+// AppendTlopenPkt appends a Tlopen with tag t, from its size on, to b.
+func AppendTlopenPkt (b []byte, t Tag, OFID FID, LFlags uint32) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Tlopen),
+byte(t), byte(t>>8),
+	uint8(OFID>>0),
+	uint8(OFID>>8),
+	uint8(OFID>>16),
+	uint8(OFID>>24),
+	uint8(LFlags>>0),
+	uint8(LFlags>>8),
+	uint8(LFlags>>16),
+	uint8(LFlags>>24),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalTlopenPkt (b *bytes.Buffer, t Tag, OFID FID, LFlags uint32) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendTlopenPkt(b.Bytes(), t, OFID, LFlags))
+}
+// DecodeTlopenPkt decodes a Tlopen from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeTlopenPkt (b []byte) (OFID FID, LFlags uint32,  t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	OFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	LFlags = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalTlopenPkt (b *bytes.Buffer) (OFID FID, LFlags uint32,  t Tag, err error) {
+OFID, LFlags,  t, err = DecodeTlopenPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (TlopenPkt) Type() MType {
+	return Tlopen
+}
+
+// Encode appends m, with tag t, to b.
+func (m TlopenPkt) Encode(b []byte, t Tag) []byte {
+	return AppendTlopenPkt(b, t, m.OFID, m.LFlags)
+}
+
+// Decode decodes m from b, which holds all of a Tlopen, and returns its tag.
+func (m *TlopenPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Tlopen); err != nil {
+		return 0, err
+	}
+	OFID, LFlags, t, err := DecodeTlopenPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = TlopenPkt{ OFID: OFID, LFlags: LFlags }
+	return t, nil
+}
+
+func (m TlopenPkt) String() string {
+	return fmt.Sprintf("Tlopen OFID %v LFlags %v", m.OFID, m.LFlags)
+}
+func (s *Server) SrvRlopen(ctx context.Context, b*bytes.Buffer) (err error) {
+	OFID, LFlags,  t, err := UnmarshalTlopenPkt(b)
+	//if err != nil {
+	//}
+	if OQID, IOUnit,  err := s.session(ctx).dotl.Rlopen(ctx, OFID, LFlags); err != nil {
+	s.rerror(ctx, b, t, err)
+} else {
+	MarshalRlopenPkt(b, t, OQID, IOUnit)
+}
+	return nil
+}
+
+func (c *Client)CallTlopen (OFID FID, LFlags uint32) (OQID QID, IOUnit MaxSize,  err error) {
+return c.CallTlopenContext(context.Background(), OFID, LFlags)
+}
+
+func (c *Client)CallTlopenContext (ctx context.Context, OFID FID, LFlags uint32) (OQID QID, IOUnit MaxSize,  err error) {
+if c.Trace != nil {c.Trace("%v", Tlopen)}
+t := Tag(0)
+if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
+b := AppendTlopenPkt(nil, t, OFID, LFlags)
+if err = c.fits(len(b)); err != nil {
+	return OQID, IOUnit,  err
+}
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return OQID, IOUnit,  err
+}
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return OQID, IOUnit,  err
+}
+OQID, IOUnit,  _, err = DecodeRlopenPkt(bb[5:])
+putFrame(bb)
+return OQID, IOUnit,  err
+}
+
+type TlopenReply struct {
+OQID QID
+IOUnit MaxSize
+}
+
+func (c *Client)GoTlopen (ctx context.Context, OFID FID, LFlags uint32, done chan *Call) *Call {
+b := AppendTlopenPkt(nil, Tag(0), OFID, LFlags)
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	OQID, IOUnit,  _, err := DecodeRlopenPkt(bb[5:])
+	putFrame(bb)
+	return &TlopenReply{ OQID: OQID, IOUnit: IOUnit }, err
+})
+}
+// AppendRlcreatePkt appends a Rlcreate with tag t, from its size on, to b.
+func AppendRlcreatePkt (b []byte, t Tag, OQID QID, IOUnit MaxSize) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Rlcreate),
+byte(t), byte(t>>8),
+	uint8(OQID.Type>>0),
+	uint8(OQID.Version>>0),
+	uint8(OQID.Version>>8),
+	uint8(OQID.Version>>16),
+	uint8(OQID.Version>>24),
+	uint8(OQID.Path>>0),
+	uint8(OQID.Path>>8),
+	uint8(OQID.Path>>16),
+	uint8(OQID.Path>>24),
+	uint8(OQID.Path>>32),
+	uint8(OQID.Path>>40),
+	uint8(OQID.Path>>48),
+	uint8(OQID.Path>>56),
+	uint8(IOUnit>>0),
+	uint8(IOUnit>>8),
+	uint8(IOUnit>>16),
+	uint8(IOUnit>>24),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalRlcreatePkt (b *bytes.Buffer, t Tag, OQID QID, IOUnit MaxSize) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendRlcreatePkt(b.Bytes(), t, OQID, IOUnit))
+}
+// DecodeRlcreatePkt decodes a Rlcreate from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeRlcreatePkt (b []byte) (OQID QID, IOUnit MaxSize,  t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 1 {
+		err = fmt.Errorf("pkt too short for uint8: need 1, have %d", len(b))
+	return
+	}
+	OQID.Type = uint8(b[0])
+	b = b[1:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	OQID.Version = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	OQID.Path = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	IOUnit = MaxSize(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalRlcreatePkt (b *bytes.Buffer) (OQID QID, IOUnit MaxSize,  t Tag, err error) {
+OQID, IOUnit,  t, err = DecodeRlcreatePkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (RlcreatePkt) Type() MType {
+	return Rlcreate
+}
+
+// Encode appends m, with tag t, to b.
+func (m RlcreatePkt) Encode(b []byte, t Tag) []byte {
+	return AppendRlcreatePkt(b, t, m.OQID, m.IOUnit)
+}
+
+// Decode decodes m from b, which holds all of a Rlcreate, and returns its tag.
+func (m *RlcreatePkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Rlcreate); err != nil {
+		return 0, err
+	}
+	OQID, IOUnit, t, err := DecodeRlcreatePkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = RlcreatePkt{ OQID: OQID, IOUnit: IOUnit }
+	return t, nil
+}
+
+func (m RlcreatePkt) String() string {
+	return fmt.Sprintf("Rlcreate OQID %v IOUnit %v", m.OQID, m.IOUnit)
+}
+// AppendTlcreatePkt appends a Tlcreate with tag t, from its size on, to b.
+func AppendTlcreatePkt (b []byte, t Tag, OFID FID, Name string, LFlags uint32, CreateMode uint32, GID uint32) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Tlcreate),
+byte(t), byte(t>>8),
+	uint8(OFID>>0),
+	uint8(OFID>>8),
+	uint8(OFID>>16),
+	uint8(OFID>>24),
+	uint8(len(Name)),uint8(len(Name)>>8),
+	)
+	b = append(b, Name...)
+	b = append(b,	uint8(LFlags>>0),
+	uint8(LFlags>>8),
+	uint8(LFlags>>16),
+	uint8(LFlags>>24),
+	uint8(CreateMode>>0),
+	uint8(CreateMode>>8),
+	uint8(CreateMode>>16),
+	uint8(CreateMode>>24),
+	uint8(GID>>0),
+	uint8(GID>>8),
+	uint8(GID>>16),
+	uint8(GID>>24),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalTlcreatePkt (b *bytes.Buffer, t Tag, OFID FID, Name string, LFlags uint32, CreateMode uint32, GID uint32) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendTlcreatePkt(b.Bytes(), t, OFID, Name, LFlags, CreateMode, GID))
+}
+// DecodeTlcreatePkt decodes a Tlcreate from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeTlcreatePkt (b []byte) (OFID FID, Name string, LFlags uint32, CreateMode uint32, GID uint32,  t Tag, err error) {
+var l uint64
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	OFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
+	return
+	}
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
+	return
+	}
+	Name = string(b[:l])
+	b = b[l:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	LFlags = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	CreateMode = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	GID = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalTlcreatePkt (b *bytes.Buffer) (OFID FID, Name string, LFlags uint32, CreateMode uint32, GID uint32,  t Tag, err error) {
+OFID, Name, LFlags, CreateMode, GID,  t, err = DecodeTlcreatePkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (TlcreatePkt) Type() MType {
+	return Tlcreate
+}
+
+// Encode appends m, with tag t, to b.
+func (m TlcreatePkt) Encode(b []byte, t Tag) []byte {
+	return AppendTlcreatePkt(b, t, m.OFID, m.Name, m.LFlags, m.CreateMode, m.GID)
+}
+
+// Decode decodes m from b, which holds all of a Tlcreate, and returns its tag.
+func (m *TlcreatePkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Tlcreate); err != nil {
+		return 0, err
+	}
+	OFID, Name, LFlags, CreateMode, GID, t, err := DecodeTlcreatePkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = TlcreatePkt{ OFID: OFID, Name: Name, LFlags: LFlags, CreateMode: CreateMode, GID: GID }
+	return t, nil
+}
+
+func (m TlcreatePkt) String() string {
+	return fmt.Sprintf("Tlcreate OFID %v Name %q LFlags %v CreateMode %v GID %v", m.OFID, m.Name, m.LFlags, m.CreateMode, m.GID)
+}
+func (s *Server) SrvRlcreate(ctx context.Context, b*bytes.Buffer) (err error) {
+	OFID, Name, LFlags, CreateMode, GID,  t, err := UnmarshalTlcreatePkt(b)
+	//if err != nil {
+	//}
+	if OQID, IOUnit,  err := s.session(ctx).dotl.Rlcreate(ctx, OFID, Name, LFlags, CreateMode, GID); err != nil {
+	s.rerror(ctx, b, t, err)
+} else {
+	MarshalRlcreatePkt(b, t, OQID, IOUnit)
+}
+	return nil
+}
+
+func (c *Client)CallTlcreate (OFID FID, Name string, LFlags uint32, CreateMode uint32, GID uint32) (OQID QID, IOUnit MaxSize,  err error) {
+return c.CallTlcreateContext(context.Background(), OFID, Name, LFlags, CreateMode, GID)
+}
+
+func (c *Client)CallTlcreateContext (ctx context.Context, OFID FID, Name string, LFlags uint32, CreateMode uint32, GID uint32) (OQID QID, IOUnit MaxSize,  err error) {
+if c.Trace != nil {c.Trace("%v", Tlcreate)}
+t := Tag(0)
+if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
+b := AppendTlcreatePkt(nil, t, OFID, Name, LFlags, CreateMode, GID)
+if err = c.fits(len(b)); err != nil {
+	return OQID, IOUnit,  err
+}
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return OQID, IOUnit,  err
+}
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return OQID, IOUnit,  err
+}
+OQID, IOUnit,  _, err = DecodeRlcreatePkt(bb[5:])
+putFrame(bb)
+return OQID, IOUnit,  err
+}
+
+type TlcreateReply struct {
+OQID QID
+IOUnit MaxSize
+}
+
+func (c *Client)GoTlcreate (ctx context.Context, OFID FID, Name string, LFlags uint32, CreateMode uint32, GID uint32, done chan *Call) *Call {
+b := AppendTlcreatePkt(nil, Tag(0), OFID, Name, LFlags, CreateMode, GID)
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	OQID, IOUnit,  _, err := DecodeRlcreatePkt(bb[5:])
+	putFrame(bb)
+	return &TlcreateReply{ OQID: OQID, IOUnit: IOUnit }, err
+})
+}
+// AppendRsymlinkPkt appends a Rsymlink with tag t, from its size on, to b.
+func AppendRsymlinkPkt (b []byte, t Tag, OQID QID) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Rsymlink),
+byte(t), byte(t>>8),
+	uint8(OQID.Type>>0),
+	uint8(OQID.Version>>0),
+	uint8(OQID.Version>>8),
+	uint8(OQID.Version>>16),
+	uint8(OQID.Version>>24),
+	uint8(OQID.Path>>0),
+	uint8(OQID.Path>>8),
+	uint8(OQID.Path>>16),
+	uint8(OQID.Path>>24),
+	uint8(OQID.Path>>32),
+	uint8(OQID.Path>>40),
+	uint8(OQID.Path>>48),
+	uint8(OQID.Path>>56),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalRsymlinkPkt (b *bytes.Buffer, t Tag, OQID QID) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendRsymlinkPkt(b.Bytes(), t, OQID))
+}
+// DecodeRsymlinkPkt decodes a Rsymlink from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeRsymlinkPkt (b []byte) (OQID QID,  t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 1 {
+		err = fmt.Errorf("pkt too short for uint8: need 1, have %d", len(b))
+	return
+	}
+	OQID.Type = uint8(b[0])
+	b = b[1:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	OQID.Version = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	OQID.Path = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalRsymlinkPkt (b *bytes.Buffer) (OQID QID,  t Tag, err error) {
+OQID,  t, err = DecodeRsymlinkPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (RsymlinkPkt) Type() MType {
+	return Rsymlink
+}
+
+// Encode appends m, with tag t, to b.
+func (m RsymlinkPkt) Encode(b []byte, t Tag) []byte {
+	return AppendRsymlinkPkt(b, t, m.OQID)
+}
+
+// Decode decodes m from b, which holds all of a Rsymlink, and returns its tag.
+func (m *RsymlinkPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Rsymlink); err != nil {
+		return 0, err
+	}
+	OQID, t, err := DecodeRsymlinkPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = RsymlinkPkt{ OQID: OQID }
+	return t, nil
+}
+
+func (m RsymlinkPkt) String() string {
+	return fmt.Sprintf("Rsymlink OQID %v", m.OQID)
+}
+// AppendTsymlinkPkt appends a Tsymlink with tag t, from its size on, to b.
+func AppendTsymlinkPkt (b []byte, t Tag, OFID FID, Name string, Target string, GID uint32) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Tsymlink),
+byte(t), byte(t>>8),
+	uint8(OFID>>0),
+	uint8(OFID>>8),
+	uint8(OFID>>16),
+	uint8(OFID>>24),
+	uint8(len(Name)),uint8(len(Name)>>8),
+	)
+	b = append(b, Name...)
+	b = append(b,	uint8(len(Target)),uint8(len(Target)>>8),
+	)
+	b = append(b, Target...)
+	b = append(b,	uint8(GID>>0),
+	uint8(GID>>8),
+	uint8(GID>>16),
+	uint8(GID>>24),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalTsymlinkPkt (b *bytes.Buffer, t Tag, OFID FID, Name string, Target string, GID uint32) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendTsymlinkPkt(b.Bytes(), t, OFID, Name, Target, GID))
+}
+// DecodeTsymlinkPkt decodes a Tsymlink from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeTsymlinkPkt (b []byte) (OFID FID, Name string, Target string, GID uint32,  t Tag, err error) {
+var l uint64
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	OFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
+	return
+	}
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
+	return
+	}
+	Name = string(b[:l])
+	b = b[l:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
+	return
+	}
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
+	return
+	}
+	Target = string(b[:l])
+	b = b[l:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	GID = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalTsymlinkPkt (b *bytes.Buffer) (OFID FID, Name string, Target string, GID uint32,  t Tag, err error) {
+OFID, Name, Target, GID,  t, err = DecodeTsymlinkPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (TsymlinkPkt) Type() MType {
+	return Tsymlink
+}
+
+// Encode appends m, with tag t, to b.
+func (m TsymlinkPkt) Encode(b []byte, t Tag) []byte {
+	return AppendTsymlinkPkt(b, t, m.OFID, m.Name, m.Target, m.GID)
+}
+
+// Decode decodes m from b, which holds all of a Tsymlink, and returns its tag.
+func (m *TsymlinkPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Tsymlink); err != nil {
+		return 0, err
+	}
+	OFID, Name, Target, GID, t, err := DecodeTsymlinkPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = TsymlinkPkt{ OFID: OFID, Name: Name, Target: Target, GID: GID }
+	return t, nil
+}
+
+func (m TsymlinkPkt) String() string {
+	return fmt.Sprintf("Tsymlink OFID %v Name %q Target %q GID %v", m.OFID, m.Name, m.Target, m.GID)
+}
+func (s *Server) SrvRsymlink(ctx context.Context, b*bytes.Buffer) (err error) {
+	OFID, Name, Target, GID,  t, err := UnmarshalTsymlinkPkt(b)
+	//if err != nil {
+	//}
+	if OQID,  err := s.session(ctx).dotl.Rsymlink(ctx, OFID, Name, Target, GID); err != nil {
+	s.rerror(ctx, b, t, err)
+} else {
+	MarshalRsymlinkPkt(b, t, OQID)
+}
+	return nil
+}
+
+func (c *Client)CallTsymlink (OFID FID, Name string, Target string, GID uint32) (OQID QID,  err error) {
+return c.CallTsymlinkContext(context.Background(), OFID, Name, Target, GID)
+}
+
+func (c *Client)CallTsymlinkContext (ctx context.Context, OFID FID, Name string, Target string, GID uint32) (OQID QID,  err error) {
+if c.Trace != nil {c.Trace("%v", Tsymlink)}
+t := Tag(0)
+if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
+b := AppendTsymlinkPkt(nil, t, OFID, Name, Target, GID)
+if err = c.fits(len(b)); err != nil {
+	return OQID,  err
+}
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return OQID,  err
+}
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return OQID,  err
+}
+OQID,  _, err = DecodeRsymlinkPkt(bb[5:])
+putFrame(bb)
+return OQID,  err
+}
+
+type TsymlinkReply struct {
+OQID QID
+}
+
+func (c *Client)GoTsymlink (ctx context.Context, OFID FID, Name string, Target string, GID uint32, done chan *Call) *Call {
+b := AppendTsymlinkPkt(nil, Tag(0), OFID, Name, Target, GID)
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	OQID,  _, err := DecodeRsymlinkPkt(bb[5:])
+	putFrame(bb)
+	return &TsymlinkReply{ OQID: OQID }, err
+})
+}
+// AppendRmknodPkt appends a Rmknod with tag t, from its size on, to b.
+func AppendRmknodPkt (b []byte, t Tag, OQID QID) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Rmknod),
+byte(t), byte(t>>8),
+	uint8(OQID.Type>>0),
+	uint8(OQID.Version>>0),
+	uint8(OQID.Version>>8),
+	uint8(OQID.Version>>16),
+	uint8(OQID.Version>>24),
+	uint8(OQID.Path>>0),
+	uint8(OQID.Path>>8),
+	uint8(OQID.Path>>16),
+	uint8(OQID.Path>>24),
+	uint8(OQID.Path>>32),
+	uint8(OQID.Path>>40),
+	uint8(OQID.Path>>48),
+	uint8(OQID.Path>>56),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalRmknodPkt (b *bytes.Buffer, t Tag, OQID QID) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendRmknodPkt(b.Bytes(), t, OQID))
+}
+// DecodeRmknodPkt decodes a Rmknod from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeRmknodPkt (b []byte) (OQID QID,  t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 1 {
+		err = fmt.Errorf("pkt too short for uint8: need 1, have %d", len(b))
+	return
+	}
+	OQID.Type = uint8(b[0])
+	b = b[1:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	OQID.Version = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	OQID.Path = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalRmknodPkt (b *bytes.Buffer) (OQID QID,  t Tag, err error) {
+OQID,  t, err = DecodeRmknodPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (RmknodPkt) Type() MType {
+	return Rmknod
+}
+
+// Encode appends m, with tag t, to b.
+func (m RmknodPkt) Encode(b []byte, t Tag) []byte {
+	return AppendRmknodPkt(b, t, m.OQID)
+}
+
+// Decode decodes m from b, which holds all of a Rmknod, and returns its tag.
+func (m *RmknodPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Rmknod); err != nil {
+		return 0, err
+	}
+	OQID, t, err := DecodeRmknodPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = RmknodPkt{ OQID: OQID }
+	return t, nil
+}
+
+func (m RmknodPkt) String() string {
+	return fmt.Sprintf("Rmknod OQID %v", m.OQID)
+}
+// AppendTmknodPkt appends a Tmknod with tag t, from its size on, to b.
+func AppendTmknodPkt (b []byte, t Tag, DFID FID, Name string, CreateMode uint32, Major uint32, Minor uint32, GID uint32) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Tmknod),
+byte(t), byte(t>>8),
+	uint8(DFID>>0),
+	uint8(DFID>>8),
+	uint8(DFID>>16),
+	uint8(DFID>>24),
+	uint8(len(Name)),uint8(len(Name)>>8),
+	)
+	b = append(b, Name...)
+	b = append(b,	uint8(CreateMode>>0),
+	uint8(CreateMode>>8),
+	uint8(CreateMode>>16),
+	uint8(CreateMode>>24),
+	uint8(Major>>0),
+	uint8(Major>>8),
+	uint8(Major>>16),
+	uint8(Major>>24),
+	uint8(Minor>>0),
+	uint8(Minor>>8),
+	uint8(Minor>>16),
+	uint8(Minor>>24),
+	uint8(GID>>0),
+	uint8(GID>>8),
+	uint8(GID>>16),
+	uint8(GID>>24),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalTmknodPkt (b *bytes.Buffer, t Tag, DFID FID, Name string, CreateMode uint32, Major uint32, Minor uint32, GID uint32) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendTmknodPkt(b.Bytes(), t, DFID, Name, CreateMode, Major, Minor, GID))
+}
+// DecodeTmknodPkt decodes a Tmknod from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeTmknodPkt (b []byte) (DFID FID, Name string, CreateMode uint32, Major uint32, Minor uint32, GID uint32,  t Tag, err error) {
+var l uint64
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	DFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
+	return
+	}
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
+	return
+	}
+	Name = string(b[:l])
+	b = b[l:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	CreateMode = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	Major = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	Minor = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	GID = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalTmknodPkt (b *bytes.Buffer) (DFID FID, Name string, CreateMode uint32, Major uint32, Minor uint32, GID uint32,  t Tag, err error) {
+DFID, Name, CreateMode, Major, Minor, GID,  t, err = DecodeTmknodPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (TmknodPkt) Type() MType {
+	return Tmknod
+}
+
+// Encode appends m, with tag t, to b.
+func (m TmknodPkt) Encode(b []byte, t Tag) []byte {
+	return AppendTmknodPkt(b, t, m.DFID, m.Name, m.CreateMode, m.Major, m.Minor, m.GID)
+}
+
+// Decode decodes m from b, which holds all of a Tmknod, and returns its tag.
+func (m *TmknodPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Tmknod); err != nil {
+		return 0, err
+	}
+	DFID, Name, CreateMode, Major, Minor, GID, t, err := DecodeTmknodPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = TmknodPkt{ DFID: DFID, Name: Name, CreateMode: CreateMode, Major: Major, Minor: Minor, GID: GID }
+	return t, nil
+}
+
+func (m TmknodPkt) String() string {
+	return fmt.Sprintf("Tmknod DFID %v Name %q CreateMode %v Major %v Minor %v GID %v", m.DFID, m.Name, m.CreateMode, m.Major, m.Minor, m.GID)
+}
+func (s *Server) SrvRmknod(ctx context.Context, b*bytes.Buffer) (err error) {
+	DFID, Name, CreateMode, Major, Minor, GID,  t, err := UnmarshalTmknodPkt(b)
+	//if err != nil {
+	//}
+	if OQID,  err := s.session(ctx).dotl.Rmknod(ctx, DFID, Name, CreateMode, Major, Minor, GID); err != nil {
+	s.rerror(ctx, b, t, err)
+} else {
+	MarshalRmknodPkt(b, t, OQID)
+}
+	return nil
+}
+
+func (c *Client)CallTmknod (DFID FID, Name string, CreateMode uint32, Major uint32, Minor uint32, GID uint32) (OQID QID,  err error) {
+return c.CallTmknodContext(context.Background(), DFID, Name, CreateMode, Major, Minor, GID)
+}
+
+func (c *Client)CallTmknodContext (ctx context.Context, DFID FID, Name string, CreateMode uint32, Major uint32, Minor uint32, GID uint32) (OQID QID,  err error) {
+if c.Trace != nil {c.Trace("%v", Tmknod)}
+t := Tag(0)
+if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
+b := AppendTmknodPkt(nil, t, DFID, Name, CreateMode, Major, Minor, GID)
+if err = c.fits(len(b)); err != nil {
+	return OQID,  err
+}
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return OQID,  err
+}
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return OQID,  err
+}
+OQID,  _, err = DecodeRmknodPkt(bb[5:])
+putFrame(bb)
+return OQID,  err
+}
+
+type TmknodReply struct {
+OQID QID
+}
+
+func (c *Client)GoTmknod (ctx context.Context, DFID FID, Name string, CreateMode uint32, Major uint32, Minor uint32, GID uint32, done chan *Call) *Call {
+b := AppendTmknodPkt(nil, Tag(0), DFID, Name, CreateMode, Major, Minor, GID)
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	OQID,  _, err := DecodeRmknodPkt(bb[5:])
+	putFrame(bb)
+	return &TmknodReply{ OQID: OQID }, err
+})
+}
+// AppendRrenamePkt appends a Rrename with tag t, from its size on, to b.
+func AppendRrenamePkt (b []byte, t Tag, ) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Rrename),
+byte(t), byte(t>>8),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalRrenamePkt (b *bytes.Buffer, t Tag, ) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendRrenamePkt(b.Bytes(), t, ))
+}
+// DecodeRrenamePkt decodes a Rrename from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeRrenamePkt (b []byte) ( t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalRrenamePkt (b *bytes.Buffer) ( t Tag, err error) {
+ t, err = DecodeRrenamePkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (RrenamePkt) Type() MType {
+	return Rrename
+}
+
+// Encode appends m, with tag t, to b.
+func (m RrenamePkt) Encode(b []byte, t Tag) []byte {
+	return AppendRrenamePkt(b, t, )
+}
+
+// Decode decodes m from b, which holds all of a Rrename, and returns its tag.
+func (m *RrenamePkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Rrename); err != nil {
+		return 0, err
+	}
+	t, err := DecodeRrenamePkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = RrenamePkt{  }
+	return t, nil
+}
+
+func (m RrenamePkt) String() string {
+	return fmt.Sprintf("Rrename")
+}
+// AppendTrenamePkt appends a Trename with tag t, from its size on, to b.
+func AppendTrenamePkt (b []byte, t Tag, OFID FID, DFID FID, Name string) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Trename),
+byte(t), byte(t>>8),
+	uint8(OFID>>0),
+	uint8(OFID>>8),
+	uint8(OFID>>16),
+	uint8(OFID>>24),
+	uint8(DFID>>0),
+	uint8(DFID>>8),
+	uint8(DFID>>16),
+	uint8(DFID>>24),
+	uint8(len(Name)),uint8(len(Name)>>8),
+	)
+	b = append(b, Name...)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalTrenamePkt (b *bytes.Buffer, t Tag, OFID FID, DFID FID, Name string) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendTrenamePkt(b.Bytes(), t, OFID, DFID, Name))
+}
+// DecodeTrenamePkt decodes a Trename from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeTrenamePkt (b []byte) (OFID FID, DFID FID, Name string,  t Tag, err error) {
+var l uint64
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	OFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	DFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
+	return
+	}
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
+	return
+	}
+	Name = string(b[:l])
+	b = b[l:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalTrenamePkt (b *bytes.Buffer) (OFID FID, DFID FID, Name string,  t Tag, err error) {
+OFID, DFID, Name,  t, err = DecodeTrenamePkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (TrenamePkt) Type() MType {
+	return Trename
+}
+
+// Encode appends m, with tag t, to b.
+func (m TrenamePkt) Encode(b []byte, t Tag) []byte {
+	return AppendTrenamePkt(b, t, m.OFID, m.DFID, m.Name)
+}
+
+// Decode decodes m from b, which holds all of a Trename, and returns its tag.
+func (m *TrenamePkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Trename); err != nil {
+		return 0, err
+	}
+	OFID, DFID, Name, t, err := DecodeTrenamePkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = TrenamePkt{ OFID: OFID, DFID: DFID, Name: Name }
+	return t, nil
+}
+
+func (m TrenamePkt) String() string {
+	return fmt.Sprintf("Trename OFID %v DFID %v Name %q", m.OFID, m.DFID, m.Name)
+}
+func (s *Server) SrvRrename(ctx context.Context, b*bytes.Buffer) (err error) {
+	OFID, DFID, Name,  t, err := UnmarshalTrenamePkt(b)
+	//if err != nil {
+	//}
+	if  err := s.session(ctx).dotl.Rrename(ctx, OFID, DFID, Name); err != nil {
+	s.rerror(ctx, b, t, err)
+} else {
+	MarshalRrenamePkt(b, t, )
+}
+	return nil
+}
+
+func (c *Client)CallTrename (OFID FID, DFID FID, Name string) ( err error) {
+return c.CallTrenameContext(context.Background(), OFID, DFID, Name)
+}
+
+func (c *Client)CallTrenameContext (ctx context.Context, OFID FID, DFID FID, Name string) ( err error) {
+if c.Trace != nil {c.Trace("%v", Trename)}
+t := Tag(0)
+if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
+b := AppendTrenamePkt(nil, t, OFID, DFID, Name)
+if err = c.fits(len(b)); err != nil {
+	return  err
+}
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return  err
+}
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return  err
+}
+ _, err = DecodeRrenamePkt(bb[5:])
+putFrame(bb)
+return  err
+}
+
+type TrenameReply struct {
+
+}
+
+func (c *Client)GoTrename (ctx context.Context, OFID FID, DFID FID, Name string, done chan *Call) *Call {
+b := AppendTrenamePkt(nil, Tag(0), OFID, DFID, Name)
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	 _, err := DecodeRrenamePkt(bb[5:])
+	putFrame(bb)
+	return &TrenameReply{  }, err
+})
+}
+// AppendRreadlinkPkt appends a Rreadlink with tag t, from its size on, to b.
+func AppendRreadlinkPkt (b []byte, t Tag, Target string) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Rreadlink),
+byte(t), byte(t>>8),
+	uint8(len(Target)),uint8(len(Target)>>8),
+	)
+	b = append(b, Target...)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalRreadlinkPkt (b *bytes.Buffer, t Tag, Target string) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendRreadlinkPkt(b.Bytes(), t, Target))
+}
+// DecodeRreadlinkPkt decodes a Rreadlink from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeRreadlinkPkt (b []byte) (Target string,  t Tag, err error) {
+var l uint64
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
+	return
+	}
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
+	return
+	}
+	Target = string(b[:l])
+	b = b[l:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalRreadlinkPkt (b *bytes.Buffer) (Target string,  t Tag, err error) {
+Target,  t, err = DecodeRreadlinkPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (RreadlinkPkt) Type() MType {
+	return Rreadlink
+}
+
+// Encode appends m, with tag t, to b.
+func (m RreadlinkPkt) Encode(b []byte, t Tag) []byte {
+	return AppendRreadlinkPkt(b, t, m.Target)
+}
+
+// Decode decodes m from b, which holds all of a Rreadlink, and returns its tag.
+func (m *RreadlinkPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Rreadlink); err != nil {
+		return 0, err
+	}
+	Target, t, err := DecodeRreadlinkPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = RreadlinkPkt{ Target: Target }
+	return t, nil
+}
+
+func (m RreadlinkPkt) String() string {
+	return fmt.Sprintf("Rreadlink Target %q", m.Target)
+}
+// AppendTreadlinkPkt appends a Treadlink with tag t, from its size on, to b.
+func AppendTreadlinkPkt (b []byte, t Tag, OFID FID) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Treadlink),
+byte(t), byte(t>>8),
+	uint8(OFID>>0),
+	uint8(OFID>>8),
+	uint8(OFID>>16),
+	uint8(OFID>>24),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalTreadlinkPkt (b *bytes.Buffer, t Tag, OFID FID) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendTreadlinkPkt(b.Bytes(), t, OFID))
+}
+// DecodeTreadlinkPkt decodes a Treadlink from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeTreadlinkPkt (b []byte) (OFID FID,  t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	OFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalTreadlinkPkt (b *bytes.Buffer) (OFID FID,  t Tag, err error) {
+OFID,  t, err = DecodeTreadlinkPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (TreadlinkPkt) Type() MType {
+	return Treadlink
+}
+
+// Encode appends m, with tag t, to b.
+func (m TreadlinkPkt) Encode(b []byte, t Tag) []byte {
+	return AppendTreadlinkPkt(b, t, m.OFID)
+}
+
+// Decode decodes m from b, which holds all of a Treadlink, and returns its tag.
+func (m *TreadlinkPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Treadlink); err != nil {
+		return 0, err
+	}
+	OFID, t, err := DecodeTreadlinkPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = TreadlinkPkt{ OFID: OFID }
+	return t, nil
+}
+
+func (m TreadlinkPkt) String() string {
+	return fmt.Sprintf("Treadlink OFID %v", m.OFID)
+}
+func (s *Server) SrvRreadlink(ctx context.Context, b*bytes.Buffer) (err error) {
+	OFID,  t, err := UnmarshalTreadlinkPkt(b)
+	//if err != nil {
+	//}
+	if Target,  err := s.session(ctx).dotl.Rreadlink(ctx, OFID); err != nil {
+	s.rerror(ctx, b, t, err)
+} else {
+	MarshalRreadlinkPkt(b, t, Target)
+}
+	return nil
+}
+
+func (c *Client)CallTreadlink (OFID FID) (Target string,  err error) {
+return c.CallTreadlinkContext(context.Background(), OFID)
+}
+
+func (c *Client)CallTreadlinkContext (ctx context.Context, OFID FID) (Target string,  err error) {
+if c.Trace != nil {c.Trace("%v", Treadlink)}
+t := Tag(0)
+if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
+b := AppendTreadlinkPkt(nil, t, OFID)
+if err = c.fits(len(b)); err != nil {
+	return Target,  err
+}
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return Target,  err
+}
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return Target,  err
+}
+Target,  _, err = DecodeRreadlinkPkt(bb[5:])
+putFrame(bb)
+return Target,  err
+}
+
+type TreadlinkReply struct {
+Target string
+}
+
+func (c *Client)GoTreadlink (ctx context.Context, OFID FID, done chan *Call) *Call {
+b := AppendTreadlinkPkt(nil, Tag(0), OFID)
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	Target,  _, err := DecodeRreadlinkPkt(bb[5:])
+	putFrame(bb)
+	return &TreadlinkReply{ Target: Target }, err
+})
+}
+// AppendRgetattrPkt appends a Rgetattr with tag t, from its size on, to b.
+func AppendRgetattrPkt (b []byte, t Tag, Attr Attr) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Rgetattr),
+byte(t), byte(t>>8),
+	uint8(Attr.Valid>>0),
+	uint8(Attr.Valid>>8),
+	uint8(Attr.Valid>>16),
+	uint8(Attr.Valid>>24),
+	uint8(Attr.Valid>>32),
+	uint8(Attr.Valid>>40),
+	uint8(Attr.Valid>>48),
+	uint8(Attr.Valid>>56),
+	uint8(Attr.QID.Type>>0),
+	uint8(Attr.QID.Version>>0),
+	uint8(Attr.QID.Version>>8),
+	uint8(Attr.QID.Version>>16),
+	uint8(Attr.QID.Version>>24),
+	uint8(Attr.QID.Path>>0),
+	uint8(Attr.QID.Path>>8),
+	uint8(Attr.QID.Path>>16),
+	uint8(Attr.QID.Path>>24),
+	uint8(Attr.QID.Path>>32),
+	uint8(Attr.QID.Path>>40),
+	uint8(Attr.QID.Path>>48),
+	uint8(Attr.QID.Path>>56),
+	uint8(Attr.Mode>>0),
+	uint8(Attr.Mode>>8),
+	uint8(Attr.Mode>>16),
+	uint8(Attr.Mode>>24),
+	uint8(Attr.UID>>0),
+	uint8(Attr.UID>>8),
+	uint8(Attr.UID>>16),
+	uint8(Attr.UID>>24),
+	uint8(Attr.GID>>0),
+	uint8(Attr.GID>>8),
+	uint8(Attr.GID>>16),
+	uint8(Attr.GID>>24),
+	uint8(Attr.NLink>>0),
+	uint8(Attr.NLink>>8),
+	uint8(Attr.NLink>>16),
+	uint8(Attr.NLink>>24),
+	uint8(Attr.NLink>>32),
+	uint8(Attr.NLink>>40),
+	uint8(Attr.NLink>>48),
+	uint8(Attr.NLink>>56),
+	uint8(Attr.RDev>>0),
+	uint8(Attr.RDev>>8),
+	uint8(Attr.RDev>>16),
+	uint8(Attr.RDev>>24),
+	uint8(Attr.RDev>>32),
+	uint8(Attr.RDev>>40),
+	uint8(Attr.RDev>>48),
+	uint8(Attr.RDev>>56),
+	uint8(Attr.Size>>0),
+	uint8(Attr.Size>>8),
+	uint8(Attr.Size>>16),
+	uint8(Attr.Size>>24),
+	uint8(Attr.Size>>32),
+	uint8(Attr.Size>>40),
+	uint8(Attr.Size>>48),
+	uint8(Attr.Size>>56),
+	uint8(Attr.BlkSize>>0),
+	uint8(Attr.BlkSize>>8),
+	uint8(Attr.BlkSize>>16),
+	uint8(Attr.BlkSize>>24),
+	uint8(Attr.BlkSize>>32),
+	uint8(Attr.BlkSize>>40),
+	uint8(Attr.BlkSize>>48),
+	uint8(Attr.BlkSize>>56),
+	uint8(Attr.Blocks>>0),
+	uint8(Attr.Blocks>>8),
+	uint8(Attr.Blocks>>16),
+	uint8(Attr.Blocks>>24),
+	uint8(Attr.Blocks>>32),
+	uint8(Attr.Blocks>>40),
+	uint8(Attr.Blocks>>48),
+	uint8(Attr.Blocks>>56),
+	uint8(Attr.ATimeSec>>0),
+	uint8(Attr.ATimeSec>>8),
+	uint8(Attr.ATimeSec>>16),
+	uint8(Attr.ATimeSec>>24),
+	uint8(Attr.ATimeSec>>32),
+	uint8(Attr.ATimeSec>>40),
+	uint8(Attr.ATimeSec>>48),
+	uint8(Attr.ATimeSec>>56),
+	uint8(Attr.ATimeNSec>>0),
+	uint8(Attr.ATimeNSec>>8),
+	uint8(Attr.ATimeNSec>>16),
+	uint8(Attr.ATimeNSec>>24),
+	uint8(Attr.ATimeNSec>>32),
+	uint8(Attr.ATimeNSec>>40),
+	uint8(Attr.ATimeNSec>>48),
+	uint8(Attr.ATimeNSec>>56),
+	uint8(Attr.MTimeSec>>0),
+	uint8(Attr.MTimeSec>>8),
+	uint8(Attr.MTimeSec>>16),
+	uint8(Attr.MTimeSec>>24),
+	uint8(Attr.MTimeSec>>32),
+	uint8(Attr.MTimeSec>>40),
+	uint8(Attr.MTimeSec>>48),
+	uint8(Attr.MTimeSec>>56),
+	uint8(Attr.MTimeNSec>>0),
+	uint8(Attr.MTimeNSec>>8),
+	uint8(Attr.MTimeNSec>>16),
+	uint8(Attr.MTimeNSec>>24),
+	uint8(Attr.MTimeNSec>>32),
+	uint8(Attr.MTimeNSec>>40),
+	uint8(Attr.MTimeNSec>>48),
+	uint8(Attr.MTimeNSec>>56),
+	uint8(Attr.CTimeSec>>0),
+	uint8(Attr.CTimeSec>>8),
+	uint8(Attr.CTimeSec>>16),
+	uint8(Attr.CTimeSec>>24),
+	uint8(Attr.CTimeSec>>32),
+	uint8(Attr.CTimeSec>>40),
+	uint8(Attr.CTimeSec>>48),
+	uint8(Attr.CTimeSec>>56),
+	uint8(Attr.CTimeNSec>>0),
+	uint8(Attr.CTimeNSec>>8),
+	uint8(Attr.CTimeNSec>>16),
+	uint8(Attr.CTimeNSec>>24),
+	uint8(Attr.CTimeNSec>>32),
+	uint8(Attr.CTimeNSec>>40),
+	uint8(Attr.CTimeNSec>>48),
+	uint8(Attr.CTimeNSec>>56),
+	uint8(Attr.BTimeSec>>0),
+	uint8(Attr.BTimeSec>>8),
+	uint8(Attr.BTimeSec>>16),
+	uint8(Attr.BTimeSec>>24),
+	uint8(Attr.BTimeSec>>32),
+	uint8(Attr.BTimeSec>>40),
+	uint8(Attr.BTimeSec>>48),
+	uint8(Attr.BTimeSec>>56),
+	uint8(Attr.BTimeNSec>>0),
+	uint8(Attr.BTimeNSec>>8),
+	uint8(Attr.BTimeNSec>>16),
+	uint8(Attr.BTimeNSec>>24),
+	uint8(Attr.BTimeNSec>>32),
+	uint8(Attr.BTimeNSec>>40),
+	uint8(Attr.BTimeNSec>>48),
+	uint8(Attr.BTimeNSec>>56),
+	uint8(Attr.Gen>>0),
+	uint8(Attr.Gen>>8),
+	uint8(Attr.Gen>>16),
+	uint8(Attr.Gen>>24),
+	uint8(Attr.Gen>>32),
+	uint8(Attr.Gen>>40),
+	uint8(Attr.Gen>>48),
+	uint8(Attr.Gen>>56),
+	uint8(Attr.DataVersion>>0),
+	uint8(Attr.DataVersion>>8),
+	uint8(Attr.DataVersion>>16),
+	uint8(Attr.DataVersion>>24),
+	uint8(Attr.DataVersion>>32),
+	uint8(Attr.DataVersion>>40),
+	uint8(Attr.DataVersion>>48),
+	uint8(Attr.DataVersion>>56),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalRgetattrPkt (b *bytes.Buffer, t Tag, Attr Attr) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendRgetattrPkt(b.Bytes(), t, Attr))
+}
+// DecodeRgetattrPkt decodes a Rgetattr from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeRgetattrPkt (b []byte) (Attr Attr,  t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	Attr.Valid = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 1 {
+		err = fmt.Errorf("pkt too short for uint8: need 1, have %d", len(b))
+	return
+	}
+	Attr.QID.Type = uint8(b[0])
+	b = b[1:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	Attr.QID.Version = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	Attr.QID.Path = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	Attr.Mode = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	Attr.UID = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	Attr.GID = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	Attr.NLink = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	Attr.RDev = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	Attr.Size = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	Attr.BlkSize = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	Attr.Blocks = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	Attr.ATimeSec = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	Attr.ATimeNSec = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	Attr.MTimeSec = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	Attr.MTimeNSec = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	Attr.CTimeSec = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	Attr.CTimeNSec = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	Attr.BTimeSec = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	Attr.BTimeNSec = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	Attr.Gen = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	Attr.DataVersion = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalRgetattrPkt (b *bytes.Buffer) (Attr Attr,  t Tag, err error) {
+Attr,  t, err = DecodeRgetattrPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (RgetattrPkt) Type() MType {
+	return Rgetattr
+}
+
+// Encode appends m, with tag t, to b.
+func (m RgetattrPkt) Encode(b []byte, t Tag) []byte {
+	return AppendRgetattrPkt(b, t, m.Attr)
+}
+
+// Decode decodes m from b, which holds all of a Rgetattr, and returns its tag.
+func (m *RgetattrPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Rgetattr); err != nil {
+		return 0, err
+	}
+	Attr, t, err := DecodeRgetattrPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = RgetattrPkt{ Attr: Attr }
+	return t, nil
+}
+
+func (m RgetattrPkt) String() string {
+	return fmt.Sprintf("Rgetattr Attr %v", m.Attr)
+}
+// AppendTgetattrPkt appends a Tgetattr with tag t, from its size on, to b.
+func AppendTgetattrPkt (b []byte, t Tag, OFID FID, Mask uint64) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Tgetattr),
+byte(t), byte(t>>8),
+	uint8(OFID>>0),
+	uint8(OFID>>8),
+	uint8(OFID>>16),
+	uint8(OFID>>24),
+	uint8(Mask>>0),
+	uint8(Mask>>8),
+	uint8(Mask>>16),
+	uint8(Mask>>24),
+	uint8(Mask>>32),
+	uint8(Mask>>40),
+	uint8(Mask>>48),
+	uint8(Mask>>56),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalTgetattrPkt (b *bytes.Buffer, t Tag, OFID FID, Mask uint64) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendTgetattrPkt(b.Bytes(), t, OFID, Mask))
+}
+// DecodeTgetattrPkt decodes a Tgetattr from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeTgetattrPkt (b []byte) (OFID FID, Mask uint64,  t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	OFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	Mask = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalTgetattrPkt (b *bytes.Buffer) (OFID FID, Mask uint64,  t Tag, err error) {
+OFID, Mask,  t, err = DecodeTgetattrPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (TgetattrPkt) Type() MType {
+	return Tgetattr
+}
+
+// Encode appends m, with tag t, to b.
+func (m TgetattrPkt) Encode(b []byte, t Tag) []byte {
+	return AppendTgetattrPkt(b, t, m.OFID, m.Mask)
+}
+
+// Decode decodes m from b, which holds all of a Tgetattr, and returns its tag.
+func (m *TgetattrPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Tgetattr); err != nil {
+		return 0, err
+	}
+	OFID, Mask, t, err := DecodeTgetattrPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = TgetattrPkt{ OFID: OFID, Mask: Mask }
+	return t, nil
+}
+
+func (m TgetattrPkt) String() string {
+	return fmt.Sprintf("Tgetattr OFID %v Mask %v", m.OFID, m.Mask)
+}
+func (s *Server) SrvRgetattr(ctx context.Context, b*bytes.Buffer) (err error) {
+	OFID, Mask,  t, err := UnmarshalTgetattrPkt(b)
+	//if err != nil {
+	//}
+	if Attr,  err := s.session(ctx).dotl.Rgetattr(ctx, OFID, Mask); err != nil {
+	s.rerror(ctx, b, t, err)
+} else {
+	MarshalRgetattrPkt(b, t, Attr)
+}
+	return nil
+}
+
+func (c *Client)CallTgetattr (OFID FID, Mask uint64) (Attr Attr,  err error) {
+return c.CallTgetattrContext(context.Background(), OFID, Mask)
+}
+
+func (c *Client)CallTgetattrContext (ctx context.Context, OFID FID, Mask uint64) (Attr Attr,  err error) {
+if c.Trace != nil {c.Trace("%v", Tgetattr)}
+t := Tag(0)
+if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
+b := AppendTgetattrPkt(nil, t, OFID, Mask)
+if err = c.fits(len(b)); err != nil {
+	return Attr,  err
+}
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return Attr,  err
+}
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return Attr,  err
+}
+Attr,  _, err = DecodeRgetattrPkt(bb[5:])
+putFrame(bb)
+return Attr,  err
+}
+
+type TgetattrReply struct {
+Attr Attr
+}
+
+func (c *Client)GoTgetattr (ctx context.Context, OFID FID, Mask uint64, done chan *Call) *Call {
+b := AppendTgetattrPkt(nil, Tag(0), OFID, Mask)
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	Attr,  _, err := DecodeRgetattrPkt(bb[5:])
+	putFrame(bb)
+	return &TgetattrReply{ Attr: Attr }, err
+})
+}
+// AppendRsetattrPkt appends a Rsetattr with tag t, from its size on, to b.
+func AppendRsetattrPkt (b []byte, t Tag, ) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Rsetattr),
+byte(t), byte(t>>8),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalRsetattrPkt (b *bytes.Buffer, t Tag, ) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendRsetattrPkt(b.Bytes(), t, ))
+}
+// DecodeRsetattrPkt decodes a Rsetattr from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeRsetattrPkt (b []byte) ( t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalRsetattrPkt (b *bytes.Buffer) ( t Tag, err error) {
+ t, err = DecodeRsetattrPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (RsetattrPkt) Type() MType {
+	return Rsetattr
+}
+
+// Encode appends m, with tag t, to b.
+func (m RsetattrPkt) Encode(b []byte, t Tag) []byte {
+	return AppendRsetattrPkt(b, t, )
+}
+
+// Decode decodes m from b, which holds all of a Rsetattr, and returns its tag.
+func (m *RsetattrPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Rsetattr); err != nil {
+		return 0, err
+	}
+	t, err := DecodeRsetattrPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = RsetattrPkt{  }
+	return t, nil
+}
+
+func (m RsetattrPkt) String() string {
+	return fmt.Sprintf("Rsetattr")
+}
+// AppendTsetattrPkt appends a Tsetattr with tag t, from its size on, to b.
+func AppendTsetattrPkt (b []byte, t Tag, OFID FID, SetAttr SetAttr) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Tsetattr),
+byte(t), byte(t>>8),
+	uint8(OFID>>0),
+	uint8(OFID>>8),
+	uint8(OFID>>16),
+	uint8(OFID>>24),
+	uint8(SetAttr.Valid>>0),
+	uint8(SetAttr.Valid>>8),
+	uint8(SetAttr.Valid>>16),
+	uint8(SetAttr.Valid>>24),
+	uint8(SetAttr.Mode>>0),
+	uint8(SetAttr.Mode>>8),
+	uint8(SetAttr.Mode>>16),
+	uint8(SetAttr.Mode>>24),
+	uint8(SetAttr.UID>>0),
+	uint8(SetAttr.UID>>8),
+	uint8(SetAttr.UID>>16),
+	uint8(SetAttr.UID>>24),
+	uint8(SetAttr.GID>>0),
+	uint8(SetAttr.GID>>8),
+	uint8(SetAttr.GID>>16),
+	uint8(SetAttr.GID>>24),
+	uint8(SetAttr.Size>>0),
+	uint8(SetAttr.Size>>8),
+	uint8(SetAttr.Size>>16),
+	uint8(SetAttr.Size>>24),
+	uint8(SetAttr.Size>>32),
+	uint8(SetAttr.Size>>40),
+	uint8(SetAttr.Size>>48),
+	uint8(SetAttr.Size>>56),
+	uint8(SetAttr.ATimeSec>>0),
+	uint8(SetAttr.ATimeSec>>8),
+	uint8(SetAttr.ATimeSec>>16),
+	uint8(SetAttr.ATimeSec>>24),
+	uint8(SetAttr.ATimeSec>>32),
+	uint8(SetAttr.ATimeSec>>40),
+	uint8(SetAttr.ATimeSec>>48),
+	uint8(SetAttr.ATimeSec>>56),
+	uint8(SetAttr.ATimeNSec>>0),
+	uint8(SetAttr.ATimeNSec>>8),
+	uint8(SetAttr.ATimeNSec>>16),
+	uint8(SetAttr.ATimeNSec>>24),
+	uint8(SetAttr.ATimeNSec>>32),
+	uint8(SetAttr.ATimeNSec>>40),
+	uint8(SetAttr.ATimeNSec>>48),
+	uint8(SetAttr.ATimeNSec>>56),
+	uint8(SetAttr.MTimeSec>>0),
+	uint8(SetAttr.MTimeSec>>8),
+	uint8(SetAttr.MTimeSec>>16),
+	uint8(SetAttr.MTimeSec>>24),
+	uint8(SetAttr.MTimeSec>>32),
+	uint8(SetAttr.MTimeSec>>40),
+	uint8(SetAttr.MTimeSec>>48),
+	uint8(SetAttr.MTimeSec>>56),
+	uint8(SetAttr.MTimeNSec>>0),
+	uint8(SetAttr.MTimeNSec>>8),
+	uint8(SetAttr.MTimeNSec>>16),
+	uint8(SetAttr.MTimeNSec>>24),
+	uint8(SetAttr.MTimeNSec>>32),
+	uint8(SetAttr.MTimeNSec>>40),
+	uint8(SetAttr.MTimeNSec>>48),
+	uint8(SetAttr.MTimeNSec>>56),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalTsetattrPkt (b *bytes.Buffer, t Tag, OFID FID, SetAttr SetAttr) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendTsetattrPkt(b.Bytes(), t, OFID, SetAttr))
+}
+// DecodeTsetattrPkt decodes a Tsetattr from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeTsetattrPkt (b []byte) (OFID FID, SetAttr SetAttr,  t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	OFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	SetAttr.Valid = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	SetAttr.Mode = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	SetAttr.UID = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	SetAttr.GID = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	SetAttr.Size = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	SetAttr.ATimeSec = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	SetAttr.ATimeNSec = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	SetAttr.MTimeSec = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	SetAttr.MTimeNSec = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalTsetattrPkt (b *bytes.Buffer) (OFID FID, SetAttr SetAttr,  t Tag, err error) {
+OFID, SetAttr,  t, err = DecodeTsetattrPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (TsetattrPkt) Type() MType {
+	return Tsetattr
+}
+
+// Encode appends m, with tag t, to b.
+func (m TsetattrPkt) Encode(b []byte, t Tag) []byte {
+	return AppendTsetattrPkt(b, t, m.OFID, m.SetAttr)
+}
+
+// Decode decodes m from b, which holds all of a Tsetattr, and returns its tag.
+func (m *TsetattrPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Tsetattr); err != nil {
+		return 0, err
+	}
+	OFID, SetAttr, t, err := DecodeTsetattrPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = TsetattrPkt{ OFID: OFID, SetAttr: SetAttr }
+	return t, nil
+}
+
+func (m TsetattrPkt) String() string {
+	return fmt.Sprintf("Tsetattr OFID %v SetAttr %v", m.OFID, m.SetAttr)
+}
+func (s *Server) SrvRsetattr(ctx context.Context, b*bytes.Buffer) (err error) {
+	OFID, SetAttr,  t, err := UnmarshalTsetattrPkt(b)
+	//if err != nil {
+	//}
+	if  err := s.session(ctx).dotl.Rsetattr(ctx, OFID, SetAttr); err != nil {
+	s.rerror(ctx, b, t, err)
+} else {
+	MarshalRsetattrPkt(b, t, )
+}
+	return nil
+}
+
+func (c *Client)CallTsetattr (OFID FID, SetAttr SetAttr) ( err error) {
+return c.CallTsetattrContext(context.Background(), OFID, SetAttr)
+}
+
+func (c *Client)CallTsetattrContext (ctx context.Context, OFID FID, SetAttr SetAttr) ( err error) {
+if c.Trace != nil {c.Trace("%v", Tsetattr)}
+t := Tag(0)
+if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
+b := AppendTsetattrPkt(nil, t, OFID, SetAttr)
+if err = c.fits(len(b)); err != nil {
+	return  err
+}
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return  err
+}
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return  err
+}
+ _, err = DecodeRsetattrPkt(bb[5:])
+putFrame(bb)
+return  err
+}
+
+type TsetattrReply struct {
+
+}
+
+func (c *Client)GoTsetattr (ctx context.Context, OFID FID, SetAttr SetAttr, done chan *Call) *Call {
+b := AppendTsetattrPkt(nil, Tag(0), OFID, SetAttr)
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	 _, err := DecodeRsetattrPkt(bb[5:])
+	putFrame(bb)
+	return &TsetattrReply{  }, err
+})
+}
+// AppendRxattrwalkPkt appends a Rxattrwalk with tag t, from its size on, to b.
+func AppendRxattrwalkPkt (b []byte, t Tag, Size uint64) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Rxattrwalk),
+byte(t), byte(t>>8),
+	uint8(Size>>0),
+	uint8(Size>>8),
+	uint8(Size>>16),
+	uint8(Size>>24),
+	uint8(Size>>32),
+	uint8(Size>>40),
+	uint8(Size>>48),
+	uint8(Size>>56),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalRxattrwalkPkt (b *bytes.Buffer, t Tag, Size uint64) {
 b.Reset()
-b.Write([]byte{0,0,0,0,
-uint8(Twalk),
+*b = *bytes.NewBuffer(AppendRxattrwalkPkt(b.Bytes(), t, Size))
+}
+// DecodeRxattrwalkPkt decodes a Rxattrwalk from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeRxattrwalkPkt (b []byte) (Size uint64,  t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	Size = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalRxattrwalkPkt (b *bytes.Buffer) (Size uint64,  t Tag, err error) {
+Size,  t, err = DecodeRxattrwalkPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (RxattrwalkPkt) Type() MType {
+	return Rxattrwalk
+}
+
+// Encode appends m, with tag t, to b.
+func (m RxattrwalkPkt) Encode(b []byte, t Tag) []byte {
+	return AppendRxattrwalkPkt(b, t, m.Size)
+}
+
+// Decode decodes m from b, which holds all of a Rxattrwalk, and returns its tag.
+func (m *RxattrwalkPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Rxattrwalk); err != nil {
+		return 0, err
+	}
+	Size, t, err := DecodeRxattrwalkPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = RxattrwalkPkt{ Size: Size }
+	return t, nil
+}
+
+func (m RxattrwalkPkt) String() string {
+	return fmt.Sprintf("Rxattrwalk Size %v", m.Size)
+}
+// AppendTxattrwalkPkt appends a Txattrwalk with tag t, from its size on, to b.
+func AppendTxattrwalkPkt (b []byte, t Tag, OFID FID, NewFID FID, Name string) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Txattrwalk),
 byte(t), byte(t>>8),
-	uint8(SFID>>0),
-	uint8(SFID>>8),
-	uint8(SFID>>16),
-	uint8(SFID>>24),
+	uint8(OFID>>0),
+	uint8(OFID>>8),
+	uint8(OFID>>16),
+	uint8(OFID>>24),
 	uint8(NewFID>>0),
 	uint8(NewFID>>8),
 	uint8(NewFID>>16),
 	uint8(NewFID>>24),
-	uint8(len(Paths)>>0),
-	uint8(len(Paths)>>8),
-	})
-for i := range Paths {
-	b.Write([]byte{	uint8(len(Paths[i])),uint8(len(Paths[i])>>8),
-	})
-	b.Write([]byte(Paths[i]))
+	uint8(len(Name)),uint8(len(Name)>>8),
+	)
+	b = append(b, Name...)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
 }
 
-{
-l = uint64(b.Len())
-copy(b.Bytes(), []byte{uint8(l), uint8(l>>8), uint8(l>>16), uint8(l>>24)})
+func MarshalTxattrwalkPkt (b *bytes.Buffer, t Tag, OFID FID, NewFID FID, Name string) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendTxattrwalkPkt(b.Bytes(), t, OFID, NewFID, Name))
 }
+// DecodeTxattrwalkPkt decodes a Txattrwalk from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeTxattrwalkPkt (b []byte) (OFID FID, NewFID FID, Name string,  t Tag, err error) {
+var l uint64
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
 return
 }
-func UnmarshalTwalkPkt (b *bytes.Buffer) (SFID FID, NewFID FID, Paths []string,  t Tag, err error) {
-var u [8]uint8
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	OFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	NewFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
+	return
+	}
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
+	return
+	}
+	Name = string(b[:l])
+	b = b[l:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalTxattrwalkPkt (b *bytes.Buffer) (OFID FID, NewFID FID, Name string,  t Tag, err error) {
+OFID, NewFID, Name,  t, err = DecodeTxattrwalkPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (TxattrwalkPkt) Type() MType {
+	return Txattrwalk
+}
+
+// Encode appends m, with tag t, to b.
+func (m TxattrwalkPkt) Encode(b []byte, t Tag) []byte {
+	return AppendTxattrwalkPkt(b, t, m.OFID, m.NewFID, m.Name)
+}
+
+// Decode decodes m from b, which holds all of a Txattrwalk, and returns its tag.
+func (m *TxattrwalkPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Txattrwalk); err != nil {
+		return 0, err
+	}
+	OFID, NewFID, Name, t, err := DecodeTxattrwalkPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = TxattrwalkPkt{ OFID: OFID, NewFID: NewFID, Name: Name }
+	return t, nil
+}
+
+func (m TxattrwalkPkt) String() string {
+	return fmt.Sprintf("Txattrwalk OFID %v NewFID %v Name %q", m.OFID, m.NewFID, m.Name)
+}
+func (s *Server) SrvRxattrwalk(ctx context.Context, b*bytes.Buffer) (err error) {
+	OFID, NewFID, Name,  t, err := UnmarshalTxattrwalkPkt(b)
+	//if err != nil {
+	//}
+	if Size,  err := s.session(ctx).dotl.Rxattrwalk(ctx, OFID, NewFID, Name); err != nil {
+	s.rerror(ctx, b, t, err)
+} else {
+	MarshalRxattrwalkPkt(b, t, Size)
+}
+	return nil
+}
+
+func (c *Client)CallTxattrwalk (OFID FID, NewFID FID, Name string) (Size uint64,  err error) {
+return c.CallTxattrwalkContext(context.Background(), OFID, NewFID, Name)
+}
+
+func (c *Client)CallTxattrwalkContext (ctx context.Context, OFID FID, NewFID FID, Name string) (Size uint64,  err error) {
+if c.Trace != nil {c.Trace("%v", Txattrwalk)}
+t := Tag(0)
+if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
+b := AppendTxattrwalkPkt(nil, t, OFID, NewFID, Name)
+if err = c.fits(len(b)); err != nil {
+	return Size,  err
+}
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return Size,  err
+}
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return Size,  err
+}
+Size,  _, err = DecodeRxattrwalkPkt(bb[5:])
+putFrame(bb)
+return Size,  err
+}
+
+type TxattrwalkReply struct {
+Size uint64
+}
+
+func (c *Client)GoTxattrwalk (ctx context.Context, OFID FID, NewFID FID, Name string, done chan *Call) *Call {
+b := AppendTxattrwalkPkt(nil, Tag(0), OFID, NewFID, Name)
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	Size,  _, err := DecodeRxattrwalkPkt(bb[5:])
+	putFrame(bb)
+	return &TxattrwalkReply{ Size: Size }, err
+})
+}
+// AppendRxattrcreatePkt appends a Rxattrcreate with tag t, from its size on, to b.
+func AppendRxattrcreatePkt (b []byte, t Tag, ) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Rxattrcreate),
+byte(t), byte(t>>8),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalRxattrcreatePkt (b *bytes.Buffer, t Tag, ) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendRxattrcreatePkt(b.Bytes(), t, ))
+}
+// DecodeRxattrcreatePkt decodes a Rxattrcreate from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeRxattrcreatePkt (b []byte) ( t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalRxattrcreatePkt (b *bytes.Buffer) ( t Tag, err error) {
+ t, err = DecodeRxattrcreatePkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (RxattrcreatePkt) Type() MType {
+	return Rxattrcreate
+}
+
+// Encode appends m, with tag t, to b.
+func (m RxattrcreatePkt) Encode(b []byte, t Tag) []byte {
+	return AppendRxattrcreatePkt(b, t, )
+}
+
+// Decode decodes m from b, which holds all of a Rxattrcreate, and returns its tag.
+func (m *RxattrcreatePkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Rxattrcreate); err != nil {
+		return 0, err
+	}
+	t, err := DecodeRxattrcreatePkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = RxattrcreatePkt{  }
+	return t, nil
+}
+
+func (m RxattrcreatePkt) String() string {
+	return fmt.Sprintf("Rxattrcreate")
+}
+// AppendTxattrcreatePkt appends a Txattrcreate with tag t, from its size on, to b.
+func AppendTxattrcreatePkt (b []byte, t Tag, OFID FID, Name string, AttrSize uint64, XFlags uint32) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Txattrcreate),
+byte(t), byte(t>>8),
+	uint8(OFID>>0),
+	uint8(OFID>>8),
+	uint8(OFID>>16),
+	uint8(OFID>>24),
+	uint8(len(Name)),uint8(len(Name)>>8),
+	)
+	b = append(b, Name...)
+	b = append(b,	uint8(AttrSize>>0),
+	uint8(AttrSize>>8),
+	uint8(AttrSize>>16),
+	uint8(AttrSize>>24),
+	uint8(AttrSize>>32),
+	uint8(AttrSize>>40),
+	uint8(AttrSize>>48),
+	uint8(AttrSize>>56),
+	uint8(XFlags>>0),
+	uint8(XFlags>>8),
+	uint8(XFlags>>16),
+	uint8(XFlags>>24),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalTxattrcreatePkt (b *bytes.Buffer, t Tag, OFID FID, Name string, AttrSize uint64, XFlags uint32) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendTxattrcreatePkt(b.Bytes(), t, OFID, Name, AttrSize, XFlags))
+}
+// DecodeTxattrcreatePkt decodes a Txattrcreate from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeTxattrcreatePkt (b []byte) (OFID FID, Name string, AttrSize uint64, XFlags uint32,  t Tag, err error) {
 var l uint64
-if _, err = b.Read(u[:2]); err != nil {
-err = fmt.Errorf("pkt too short for Tag; need 2, have %d", b.Len())
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
 return
 }
-l = uint64(u[0]) | uint64(u[1])<<8
-t = Tag(l)
-	if _, err = b.Read(u[:4]); err != nil {
-		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", b.Len())
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
 	return
 	}
-	SFID = FID(u[0])
-	SFID |= FID(u[1])<<8
-	SFID |= FID(u[2])<<16
-	SFID |= FID(u[3])<<24
-	if _, err = b.Read(u[:4]); err != nil {
-		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", b.Len())
+	OFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
 	return
 	}
-	NewFID = FID(u[0])
-	NewFID |= FID(u[1])<<8
-	NewFID |= FID(u[2])<<16
-	NewFID |= FID(u[3])<<24
-	if _, err = b.Read(u[:2]); err != nil {
-		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", b.Len())
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
 	return
 	}
-	l = uint64(u[0])
-	l |= uint64(u[1])<<8
-	Paths = make([]string, l)
-for i := range Paths {
-	if _, err = b.Read(u[:2]); err != nil {
-		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", b.Len())
+	Name = string(b[:l])
+	b = b[l:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	AttrSize = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	XFlags = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalTxattrcreatePkt (b *bytes.Buffer) (OFID FID, Name string, AttrSize uint64, XFlags uint32,  t Tag, err error) {
+OFID, Name, AttrSize, XFlags,  t, err = DecodeTxattrcreatePkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (TxattrcreatePkt) Type() MType {
+	return Txattrcreate
+}
+
+// Encode appends m, with tag t, to b.
+func (m TxattrcreatePkt) Encode(b []byte, t Tag) []byte {
+	return AppendTxattrcreatePkt(b, t, m.OFID, m.Name, m.AttrSize, m.XFlags)
+}
+
+// Decode decodes m from b, which holds all of a Txattrcreate, and returns its tag.
+func (m *TxattrcreatePkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Txattrcreate); err != nil {
+		return 0, err
+	}
+	OFID, Name, AttrSize, XFlags, t, err := DecodeTxattrcreatePkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = TxattrcreatePkt{ OFID: OFID, Name: Name, AttrSize: AttrSize, XFlags: XFlags }
+	return t, nil
+}
+
+func (m TxattrcreatePkt) String() string {
+	return fmt.Sprintf("Txattrcreate OFID %v Name %q AttrSize %v XFlags %v", m.OFID, m.Name, m.AttrSize, m.XFlags)
+}
+func (s *Server) SrvRxattrcreate(ctx context.Context, b*bytes.Buffer) (err error) {
+	OFID, Name, AttrSize, XFlags,  t, err := UnmarshalTxattrcreatePkt(b)
+	//if err != nil {
+	//}
+	if  err := s.session(ctx).dotl.Rxattrcreate(ctx, OFID, Name, AttrSize, XFlags); err != nil {
+	s.rerror(ctx, b, t, err)
+} else {
+	MarshalRxattrcreatePkt(b, t, )
+}
+	return nil
+}
+
+func (c *Client)CallTxattrcreate (OFID FID, Name string, AttrSize uint64, XFlags uint32) ( err error) {
+return c.CallTxattrcreateContext(context.Background(), OFID, Name, AttrSize, XFlags)
+}
+
+func (c *Client)CallTxattrcreateContext (ctx context.Context, OFID FID, Name string, AttrSize uint64, XFlags uint32) ( err error) {
+if c.Trace != nil {c.Trace("%v", Txattrcreate)}
+t := Tag(0)
+if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
+b := AppendTxattrcreatePkt(nil, t, OFID, Name, AttrSize, XFlags)
+if err = c.fits(len(b)); err != nil {
+	return  err
+}
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return  err
+}
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return  err
+}
+ _, err = DecodeRxattrcreatePkt(bb[5:])
+putFrame(bb)
+return  err
+}
+
+type TxattrcreateReply struct {
+
+}
+
+func (c *Client)GoTxattrcreate (ctx context.Context, OFID FID, Name string, AttrSize uint64, XFlags uint32, done chan *Call) *Call {
+b := AppendTxattrcreatePkt(nil, Tag(0), OFID, Name, AttrSize, XFlags)
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	 _, err := DecodeRxattrcreatePkt(bb[5:])
+	putFrame(bb)
+	return &TxattrcreateReply{  }, err
+})
+}
+// AppendRreaddirPkt appends a Rreaddir with tag t, from its size on, to b.
+func AppendRreaddirPkt (b []byte, t Tag, Data []uint8) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Rreaddir),
+byte(t), byte(t>>8),
+	uint8(len(Data)>>0),
+	uint8(len(Data)>>8),
+	uint8(len(Data)>>16),
+	uint8(len(Data)>>24),
+	)
+	b = append(b, Data...)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalRreaddirPkt (b *bytes.Buffer, t Tag, Data []uint8) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendRreaddirPkt(b.Bytes(), t, Data))
+}
+// DecodeRreaddirPkt decodes a Rreaddir from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeRreaddirPkt (b []byte) (Data []uint8,  t Tag, err error) {
+var l uint64
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
 	return
 	}
-	l = uint64(u[0])
-	l |= uint64(u[1])<<8
-	if b.Len() < int(l) {
-		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, b.Len())
+	l = uint64(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for data: need %d, have %d", l, len(b))
 	return
 	}
-	Paths[i] = string(b.Bytes()[:l])
-	_ = b.Next(int(l))
+	Data = b[:l:l]
+	b = b[l:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalRreaddirPkt (b *bytes.Buffer) (Data []uint8,  t Tag, err error) {
+Data,  t, err = DecodeRreaddirPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (RreaddirPkt) Type() MType {
+	return Rreaddir
+}
+
+// Encode appends m, with tag t, to b.
+func (m RreaddirPkt) Encode(b []byte, t Tag) []byte {
+	return AppendRreaddirPkt(b, t, m.Data)
+}
+
+// Decode decodes m from b, which holds all of a Rreaddir, and returns its tag.
+func (m *RreaddirPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Rreaddir); err != nil {
+		return 0, err
+	}
+	Data, t, err := DecodeRreaddirPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = RreaddirPkt{ Data: Data }
+	return t, nil
+}
+
+func (m RreaddirPkt) String() string {
+	return fmt.Sprintf("Rreaddir Data <%d bytes>", len(m.Data))
+}
+// AppendTreaddirPkt appends a Treaddir with tag t, from its size on, to b.
+func AppendTreaddirPkt (b []byte, t Tag, OFID FID, Off Offset, Len Count) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Treaddir),
+byte(t), byte(t>>8),
+	uint8(OFID>>0),
+	uint8(OFID>>8),
+	uint8(OFID>>16),
+	uint8(OFID>>24),
+	uint8(Off>>0),
+	uint8(Off>>8),
+	uint8(Off>>16),
+	uint8(Off>>24),
+	uint8(Off>>32),
+	uint8(Off>>40),
+	uint8(Off>>48),
+	uint8(Off>>56),
+	uint8(Len>>0),
+	uint8(Len>>8),
+	uint8(Len>>16),
+	uint8(Len>>24),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalTreaddirPkt (b *bytes.Buffer, t Tag, OFID FID, Off Offset, Len Count) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendTreaddirPkt(b.Bytes(), t, OFID, Off, Len))
+}
+// DecodeTreaddirPkt decodes a Treaddir from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeTreaddirPkt (b []byte) (OFID FID, Off Offset, Len Count,  t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	OFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	Off = Offset(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	Len = Count(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
+}
+return
+}
+
+func UnmarshalTreaddirPkt (b *bytes.Buffer) (OFID FID, Off Offset, Len Count,  t Tag, err error) {
+OFID, Off, Len,  t, err = DecodeTreaddirPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (TreaddirPkt) Type() MType {
+	return Treaddir
+}
+
+// Encode appends m, with tag t, to b.
+func (m TreaddirPkt) Encode(b []byte, t Tag) []byte {
+	return AppendTreaddirPkt(b, t, m.OFID, m.Off, m.Len)
+}
+
+// Decode decodes m from b, which holds all of a Treaddir, and returns its tag.
+func (m *TreaddirPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Treaddir); err != nil {
+		return 0, err
+	}
+	OFID, Off, Len, t, err := DecodeTreaddirPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = TreaddirPkt{ OFID: OFID, Off: Off, Len: Len }
+	return t, nil
 }
 
-if b.Len() > 0 {
-err = fmt.Errorf("Packet too long: %d bytes left over after decode", b.Len())
+func (m TreaddirPkt) String() string {
+	return fmt.Sprintf("Treaddir OFID %v Off %v Len %v", m.OFID, m.Off, m.Len)
 }
-return
-}
-func (s *Server) SrvRwalk(b*bytes.Buffer) (err error) {
-	SFID, NewFID, Paths,  t, err := UnmarshalTwalkPkt(b)
+func (s *Server) SrvRreaddir(ctx context.Context, b*bytes.Buffer) (err error) {
+	OFID, Off, Len,  t, err := UnmarshalTreaddirPkt(b)
 	//if err != nil {
 	//}
-	if QIDs,  err := s.NS.Rwalk(SFID, NewFID, Paths); err != nil {
-	MarshalRerrorPkt(b, t, fmt.Sprintf("%v", err))
+	if Data,  err := s.session(ctx).dotl.Rreaddir(ctx, OFID, Off, Len); err != nil {
+	s.rerror(ctx, b, t, err)
 } else {
-	MarshalRwalkPkt(b, t, QIDs)
+	MarshalRreaddirPkt(b, t, Data)
 }
 	return nil
 }
 
-func (c *Client)CallTwalk (SFID FID, NewFID FID, Paths []string) (QIDs []QID,  err error) {
-var b = bytes.Buffer{}
-if c.Trace != nil {c.Trace("%v", Twalk)}
+func (c *Client)CallTreaddir (OFID FID, Off Offset, Len Count) (Data []uint8,  err error) {
+return c.CallTreaddirContext(context.Background(), OFID, Off, Len)
+}
+
+func (c *Client)CallTreaddirContext (ctx context.Context, OFID FID, Off Offset, Len Count) (Data []uint8,  err error) {
+if c.Trace != nil {c.Trace("%v", Treaddir)}
 t := Tag(0)
-r := make (chan []byte)
 if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
-MarshalTwalkPkt(&b, t, SFID, NewFID, Paths)
-c.FromClient <- &RPCCall{b: b.Bytes(), Reply: r}
-bb := <-r
-if MType(bb[4]) == Rerror {
-	s, _, err := UnmarshalRerrorPkt(bytes.NewBuffer(bb[5:]))
-	if err != nil {
-		return QIDs,  err
-	}
-	return QIDs,  fmt.Errorf("%v", s)
-} else {
-	QIDs,  _, err = UnmarshalRwalkPkt(bytes.NewBuffer(bb[5:]))
+b := AppendTreaddirPkt(nil, t, OFID, Off, Len)
+if err = c.fits(len(b)); err != nil {
+	return Data,  err
 }
-return QIDs,  err
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return Data,  err
 }
-func MarshalRopenPkt (b *bytes.Buffer, t Tag, OQID QID, IOUnit MaxSize) {
-var l uint64
-b.Reset()
-b.Write([]byte{0,0,0,0,
-uint8(Ropen),
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return Data,  err
+}
+Data,  _, err = DecodeRreaddirPkt(bb[5:])
+return Data,  err
+}
+
+type TreaddirReply struct {
+Data []uint8
+}
+
+func (c *Client)GoTreaddir (ctx context.Context, OFID FID, Off Offset, Len Count, done chan *Call) *Call {
+b := AppendTreaddirPkt(nil, Tag(0), OFID, Off, Len)
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	Data,  _, err := DecodeRreaddirPkt(bb[5:])
+	return &TreaddirReply{ Data: Data }, err
+})
+}
+// AppendRfsyncPkt appends a Rfsync with tag t, from its size on, to b.
+func AppendRfsyncPkt (b []byte, t Tag, ) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Rfsync),
 byte(t), byte(t>>8),
-	uint8(OQID.Type>>0),
-	uint8(OQID.Version>>0),
-	uint8(OQID.Version>>8),
-	uint8(OQID.Version>>16),
-	uint8(OQID.Version>>24),
-	uint8(OQID.Path>>0),
-	uint8(OQID.Path>>8),
-	uint8(OQID.Path>>16),
-	uint8(OQID.Path>>24),
-	uint8(OQID.Path>>32),
-	uint8(OQID.Path>>40),
-	uint8(OQID.Path>>48),
-	uint8(OQID.Path>>56),
-	uint8(IOUnit>>0),
-	uint8(IOUnit>>8),
-	uint8(IOUnit>>16),
-	uint8(IOUnit>>24),
-	})
+	)
 
-{
-l = uint64(b.Len())
-copy(b.Bytes(), []byte{uint8(l), uint8(l>>8), uint8(l>>16), uint8(l>>24)})
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
 }
-return
+
+func MarshalRfsyncPkt (b *bytes.Buffer, t Tag, ) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendRfsyncPkt(b.Bytes(), t, ))
 }
-func UnmarshalRopenPkt (b *bytes.Buffer) (OQID QID, IOUnit MaxSize,  t Tag, err error) {
-var u [8]uint8
-var l uint64
-if _, err = b.Read(u[:2]); err != nil {
-err = fmt.Errorf("pkt too short for Tag; need 2, have %d", b.Len())
+// DecodeRfsyncPkt decodes a Rfsync from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeRfsyncPkt (b []byte) ( t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
 return
 }
-l = uint64(u[0]) | uint64(u[1])<<8
-t = Tag(l)
-	if _, err = b.Read(u[:1]); err != nil {
-		err = fmt.Errorf("pkt too short for uint8: need 1, have %d", b.Len())
-	return
-	}
-	OQID.Type = uint8(u[0])
-	if _, err = b.Read(u[:4]); err != nil {
-		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", b.Len())
-	return
-	}
-	OQID.Version = uint32(u[0])
-	OQID.Version |= uint32(u[1])<<8
-	OQID.Version |= uint32(u[2])<<16
-	OQID.Version |= uint32(u[3])<<24
-	if _, err = b.Read(u[:8]); err != nil {
-		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", b.Len())
-	return
-	}
-	OQID.Path = uint64(u[0])
-	OQID.Path |= uint64(u[1])<<8
-	OQID.Path |= uint64(u[2])<<16
-	OQID.Path |= uint64(u[3])<<24
-	OQID.Path |= uint64(u[4])<<32
-	OQID.Path |= uint64(u[5])<<40
-	OQID.Path |= uint64(u[6])<<48
-	OQID.Path |= uint64(u[7])<<56
-	if _, err = b.Read(u[:4]); err != nil {
-		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", b.Len())
-	return
-	}
-	IOUnit = MaxSize(u[0])
-	IOUnit |= MaxSize(u[1])<<8
-	IOUnit |= MaxSize(u[2])<<16
-	IOUnit |= MaxSize(u[3])<<24
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
 
-if b.Len() > 0 {
-err = fmt.Errorf("Packet too long: %d bytes left over after decode", b.Len())
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
 }
 return
 }
-func MarshalTopenPkt (b *bytes.Buffer, t Tag, OFID FID, Omode Mode) {
-var l uint64
+
+func UnmarshalRfsyncPkt (b *bytes.Buffer) ( t Tag, err error) {
+ t, err = DecodeRfsyncPkt(b.Bytes())
 b.Reset()
-b.Write([]byte{0,0,0,0,
-uint8(Topen),
+return
+}
+
+func (RfsyncPkt) Type() MType {
+	return Rfsync
+}
+
+// Encode appends m, with tag t, to b.
+func (m RfsyncPkt) Encode(b []byte, t Tag) []byte {
+	return AppendRfsyncPkt(b, t, )
+}
+
+// Decode decodes m from b, which holds all of a Rfsync, and returns its tag.
+func (m *RfsyncPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Rfsync); err != nil {
+		return 0, err
+	}
+	t, err := DecodeRfsyncPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = RfsyncPkt{  }
+	return t, nil
+}
+
+func (m RfsyncPkt) String() string {
+	return fmt.Sprintf("Rfsync")
+}
+// AppendTfsyncPkt appends a Tfsync with tag t, from its size on, to b.
+func AppendTfsyncPkt (b []byte, t Tag, OFID FID, Datasync uint32) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Tfsync),
 byte(t), byte(t>>8),
 	uint8(OFID>>0),
 	uint8(OFID>>8),
 	uint8(OFID>>16),
 	uint8(OFID>>24),
-	uint8(Omode>>0),
-	})
+	uint8(Datasync>>0),
+	uint8(Datasync>>8),
+	uint8(Datasync>>16),
+	uint8(Datasync>>24),
+	)
 
-{
-l = uint64(b.Len())
-copy(b.Bytes(), []byte{uint8(l), uint8(l>>8), uint8(l>>16), uint8(l>>24)})
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
 }
-return
+
+func MarshalTfsyncPkt (b *bytes.Buffer, t Tag, OFID FID, Datasync uint32) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendTfsyncPkt(b.Bytes(), t, OFID, Datasync))
 }
-func UnmarshalTopenPkt (b *bytes.Buffer) (OFID FID, Omode Mode,  t Tag, err error) {
-var u [8]uint8
-var l uint64
-if _, err = b.Read(u[:2]); err != nil {
-err = fmt.Errorf("pkt too short for Tag; need 2, have %d", b.Len())
+// DecodeTfsyncPkt decodes a Tfsync from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeTfsyncPkt (b []byte) (OFID FID, Datasync uint32,  t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
 return
 }
-l = uint64(u[0]) | uint64(u[1])<<8
-t = Tag(l)
-	if _, err = b.Read(u[:4]); err != nil {
-		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", b.Len())
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
 	return
 	}
-	OFID = FID(u[0])
-	OFID |= FID(u[1])<<8
-	OFID |= FID(u[2])<<16
-	OFID |= FID(u[3])<<24
-	if _, err = b.Read(u[:1]); err != nil {
-		err = fmt.Errorf("pkt too short for uint8: need 1, have %d", b.Len())
+	OFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
 	return
 	}
-	Omode = Mode(u[0])
+	Datasync = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
 
-if b.Len() > 0 {
-err = fmt.Errorf("Packet too long: %d bytes left over after decode", b.Len())
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
 }
 return
 }
-func (s *Server) SrvRopen(b*bytes.Buffer) (err error) {
-	OFID, Omode,  t, err := UnmarshalTopenPkt(b)
+
+func UnmarshalTfsyncPkt (b *bytes.Buffer) (OFID FID, Datasync uint32,  t Tag, err error) {
+OFID, Datasync,  t, err = DecodeTfsyncPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (TfsyncPkt) Type() MType {
+	return Tfsync
+}
+
+// Encode appends m, with tag t, to b.
+func (m TfsyncPkt) Encode(b []byte, t Tag) []byte {
+	return AppendTfsyncPkt(b, t, m.OFID, m.Datasync)
+}
+
+// Decode decodes m from b, which holds all of a Tfsync, and returns its tag.
+func (m *TfsyncPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Tfsync); err != nil {
+		return 0, err
+	}
+	OFID, Datasync, t, err := DecodeTfsyncPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = TfsyncPkt{ OFID: OFID, Datasync: Datasync }
+	return t, nil
+}
+
+func (m TfsyncPkt) String() string {
+	return fmt.Sprintf("Tfsync OFID %v Datasync %v", m.OFID, m.Datasync)
+}
+func (s *Server) SrvRfsync(ctx context.Context, b*bytes.Buffer) (err error) {
+	OFID, Datasync,  t, err := UnmarshalTfsyncPkt(b)
 	//if err != nil {
 	//}
-	if OQID, IOUnit,  err := s.NS.Ropen(OFID, Omode); err != nil {
-	MarshalRerrorPkt(b, t, fmt.Sprintf("%v", err))
+	if  err := s.session(ctx).dotl.Rfsync(ctx, OFID, Datasync); err != nil {
+	s.rerror(ctx, b, t, err)
 } else {
-	MarshalRopenPkt(b, t, OQID, IOUnit)
+	MarshalRfsyncPkt(b, t, )
 }
 	return nil
 }
 
-func (c *Client)CallTopen (OFID FID, Omode Mode) (OQID QID, IOUnit MaxSize,  err error) {
-var b = bytes.Buffer{}
-if c.Trace != nil {c.Trace("%v", Topen)}
+func (c *Client)CallTfsync (OFID FID, Datasync uint32) ( err error) {
+return c.CallTfsyncContext(context.Background(), OFID, Datasync)
+}
+
+func (c *Client)CallTfsyncContext (ctx context.Context, OFID FID, Datasync uint32) ( err error) {
+if c.Trace != nil {c.Trace("%v", Tfsync)}
 t := Tag(0)
-r := make (chan []byte)
 if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
-MarshalTopenPkt(&b, t, OFID, Omode)
-c.FromClient <- &RPCCall{b: b.Bytes(), Reply: r}
-bb := <-r
-if MType(bb[4]) == Rerror {
-	s, _, err := UnmarshalRerrorPkt(bytes.NewBuffer(bb[5:]))
-	if err != nil {
-		return OQID, IOUnit,  err
-	}
-	return OQID, IOUnit,  fmt.Errorf("%v", s)
-} else {
-	OQID, IOUnit,  _, err = UnmarshalRopenPkt(bytes.NewBuffer(bb[5:]))
+b := AppendTfsyncPkt(nil, t, OFID, Datasync)
+if err = c.fits(len(b)); err != nil {
+	return  err
 }
-return OQID, IOUnit,  err
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return  err
 }
-func MarshalRcreatePkt (b *bytes.Buffer, t Tag, OQID QID, IOUnit MaxSize) {
-var l uint64
-b.Reset()
-b.Write([]byte{0,0,0,0,
-uint8(Rcreate),
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return  err
+}
+ _, err = DecodeRfsyncPkt(bb[5:])
+putFrame(bb)
+return  err
+}
+
+type TfsyncReply struct {
+
+}
+
+func (c *Client)GoTfsync (ctx context.Context, OFID FID, Datasync uint32, done chan *Call) *Call {
+b := AppendTfsyncPkt(nil, Tag(0), OFID, Datasync)
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	 _, err := DecodeRfsyncPkt(bb[5:])
+	putFrame(bb)
+	return &TfsyncReply{  }, err
+})
+}
+// AppendRlockPkt appends a Rlock with tag t, from its size on, to b.
+func AppendRlockPkt (b []byte, t Tag, Status uint8) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Rlock),
 byte(t), byte(t>>8),
-	uint8(OQID.Type>>0),
-	uint8(OQID.Version>>0),
-	uint8(OQID.Version>>8),
-	uint8(OQID.Version>>16),
-	uint8(OQID.Version>>24),
-	uint8(OQID.Path>>0),
-	uint8(OQID.Path>>8),
-	uint8(OQID.Path>>16),
-	uint8(OQID.Path>>24),
-	uint8(OQID.Path>>32),
-	uint8(OQID.Path>>40),
-	uint8(OQID.Path>>48),
-	uint8(OQID.Path>>56),
-	uint8(IOUnit>>0),
-	uint8(IOUnit>>8),
-	uint8(IOUnit>>16),
-	uint8(IOUnit>>24),
-	})
+	uint8(Status>>0),
+	)
 
-{
-l = uint64(b.Len())
-copy(b.Bytes(), []byte{uint8(l), uint8(l>>8), uint8(l>>16), uint8(l>>24)})
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
 }
-return
+
+func MarshalRlockPkt (b *bytes.Buffer, t Tag, Status uint8) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendRlockPkt(b.Bytes(), t, Status))
 }
-func UnmarshalRcreatePkt (b *bytes.Buffer) (OQID QID, IOUnit MaxSize,  t Tag, err error) {
-var u [8]uint8
-var l uint64
-if _, err = b.Read(u[:2]); err != nil {
-err = fmt.Errorf("pkt too short for Tag; need 2, have %d", b.Len())
+// DecodeRlockPkt decodes a Rlock from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeRlockPkt (b []byte) (Status uint8,  t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
 return
 }
-l = uint64(u[0]) | uint64(u[1])<<8
-t = Tag(l)
-	if _, err = b.Read(u[:1]); err != nil {
-		err = fmt.Errorf("pkt too short for uint8: need 1, have %d", b.Len())
-	return
-	}
-	OQID.Type = uint8(u[0])
-	if _, err = b.Read(u[:4]); err != nil {
-		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", b.Len())
-	return
-	}
-	OQID.Version = uint32(u[0])
-	OQID.Version |= uint32(u[1])<<8
-	OQID.Version |= uint32(u[2])<<16
-	OQID.Version |= uint32(u[3])<<24
-	if _, err = b.Read(u[:8]); err != nil {
-		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", b.Len())
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 1 {
+		err = fmt.Errorf("pkt too short for uint8: need 1, have %d", len(b))
 	return
 	}
-	OQID.Path = uint64(u[0])
-	OQID.Path |= uint64(u[1])<<8
-	OQID.Path |= uint64(u[2])<<16
-	OQID.Path |= uint64(u[3])<<24
-	OQID.Path |= uint64(u[4])<<32
-	OQID.Path |= uint64(u[5])<<40
-	OQID.Path |= uint64(u[6])<<48
-	OQID.Path |= uint64(u[7])<<56
-	if _, err = b.Read(u[:4]); err != nil {
-		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", b.Len())
-	return
-	}
-	IOUnit = MaxSize(u[0])
-	IOUnit |= MaxSize(u[1])<<8
-	IOUnit |= MaxSize(u[2])<<16
-	IOUnit |= MaxSize(u[3])<<24
+	Status = uint8(b[0])
+	b = b[1:]
 
-if b.Len() > 0 {
-err = fmt.Errorf("Packet too long: %d bytes left over after decode", b.Len())
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
 }
 return
 }
-func MarshalTcreatePkt (b *bytes.Buffer, t Tag, OFID FID, Name string, CreatePerm Perm, Omode Mode) {
-var l uint64
+
+func UnmarshalRlockPkt (b *bytes.Buffer) (Status uint8,  t Tag, err error) {
+Status,  t, err = DecodeRlockPkt(b.Bytes())
 b.Reset()
-b.Write([]byte{0,0,0,0,
-uint8(Tcreate),
+return
+}
+
+func (RlockPkt) Type() MType {
+	return Rlock
+}
+
+// Encode appends m, with tag t, to b.
+func (m RlockPkt) Encode(b []byte, t Tag) []byte {
+	return AppendRlockPkt(b, t, m.Status)
+}
+
+// Decode decodes m from b, which holds all of a Rlock, and returns its tag.
+func (m *RlockPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Rlock); err != nil {
+		return 0, err
+	}
+	Status, t, err := DecodeRlockPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = RlockPkt{ Status: Status }
+	return t, nil
+}
+
+func (m RlockPkt) String() string {
+	return fmt.Sprintf("Rlock Status %v", m.Status)
+}
+// AppendTlockPkt appends a Tlock with tag t, from its size on, to b.
+func AppendTlockPkt (b []byte, t Tag, OFID FID, LType uint8, LFlags uint32, Start uint64, Length uint64, ProcID uint32, ClientID string) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Tlock),
 byte(t), byte(t>>8),
 	uint8(OFID>>0),
 	uint8(OFID>>8),
 	uint8(OFID>>16),
 	uint8(OFID>>24),
-	uint8(len(Name)),uint8(len(Name)>>8),
-	})
-	b.Write([]byte(Name))
-	b.Write([]byte{	uint8(CreatePerm>>0),
-	uint8(CreatePerm>>8),
-	uint8(CreatePerm>>16),
-	uint8(CreatePerm>>24),
-	uint8(Omode>>0),
-	})
+	uint8(LType>>0),
+	uint8(LFlags>>0),
+	uint8(LFlags>>8),
+	uint8(LFlags>>16),
+	uint8(LFlags>>24),
+	uint8(Start>>0),
+	uint8(Start>>8),
+	uint8(Start>>16),
+	uint8(Start>>24),
+	uint8(Start>>32),
+	uint8(Start>>40),
+	uint8(Start>>48),
+	uint8(Start>>56),
+	uint8(Length>>0),
+	uint8(Length>>8),
+	uint8(Length>>16),
+	uint8(Length>>24),
+	uint8(Length>>32),
+	uint8(Length>>40),
+	uint8(Length>>48),
+	uint8(Length>>56),
+	uint8(ProcID>>0),
+	uint8(ProcID>>8),
+	uint8(ProcID>>16),
+	uint8(ProcID>>24),
+	uint8(len(ClientID)),uint8(len(ClientID)>>8),
+	)
+	b = append(b, ClientID...)
 
-{
-l = uint64(b.Len())
-copy(b.Bytes(), []byte{uint8(l), uint8(l>>8), uint8(l>>16), uint8(l>>24)})
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
 }
-return
+
+func MarshalTlockPkt (b *bytes.Buffer, t Tag, OFID FID, LType uint8, LFlags uint32, Start uint64, Length uint64, ProcID uint32, ClientID string) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendTlockPkt(b.Bytes(), t, OFID, LType, LFlags, Start, Length, ProcID, ClientID))
 }
-func UnmarshalTcreatePkt (b *bytes.Buffer) (OFID FID, Name string, CreatePerm Perm, Omode Mode,  t Tag, err error) {
-var u [8]uint8
+// DecodeTlockPkt decodes a Tlock from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeTlockPkt (b []byte) (OFID FID, LType uint8, LFlags uint32, Start uint64, Length uint64, ProcID uint32, ClientID string,  t Tag, err error) {
 var l uint64
-if _, err = b.Read(u[:2]); err != nil {
-err = fmt.Errorf("pkt too short for Tag; need 2, have %d", b.Len())
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
 return
 }
-l = uint64(u[0]) | uint64(u[1])<<8
-t = Tag(l)
-	if _, err = b.Read(u[:4]); err != nil {
-		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", b.Len())
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	OFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 1 {
+		err = fmt.Errorf("pkt too short for uint8: need 1, have %d", len(b))
+	return
+	}
+	LType = uint8(b[0])
+	b = b[1:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	LFlags = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
 	return
 	}
-	OFID = FID(u[0])
-	OFID |= FID(u[1])<<8
-	OFID |= FID(u[2])<<16
-	OFID |= FID(u[3])<<24
-	if _, err = b.Read(u[:2]); err != nil {
-		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", b.Len())
+	Start = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
 	return
 	}
-	l = uint64(u[0])
-	l |= uint64(u[1])<<8
-	if b.Len() < int(l) {
-		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, b.Len())
+	Length = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
 	return
 	}
-	Name = string(b.Bytes()[:l])
-	_ = b.Next(int(l))
-	if _, err = b.Read(u[:4]); err != nil {
-		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", b.Len())
+	ProcID = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
 	return
 	}
-	CreatePerm = Perm(u[0])
-	CreatePerm |= Perm(u[1])<<8
-	CreatePerm |= Perm(u[2])<<16
-	CreatePerm |= Perm(u[3])<<24
-	if _, err = b.Read(u[:1]); err != nil {
-		err = fmt.Errorf("pkt too short for uint8: need 1, have %d", b.Len())
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
 	return
 	}
-	Omode = Mode(u[0])
+	ClientID = string(b[:l])
+	b = b[l:]
 
-if b.Len() > 0 {
-err = fmt.Errorf("Packet too long: %d bytes left over after decode", b.Len())
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
 }
 return
 }
-func (s *Server) SrvRcreate(b*bytes.Buffer) (err error) {
-	OFID, Name, CreatePerm, Omode,  t, err := UnmarshalTcreatePkt(b)
+
+func UnmarshalTlockPkt (b *bytes.Buffer) (OFID FID, LType uint8, LFlags uint32, Start uint64, Length uint64, ProcID uint32, ClientID string,  t Tag, err error) {
+OFID, LType, LFlags, Start, Length, ProcID, ClientID,  t, err = DecodeTlockPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (TlockPkt) Type() MType {
+	return Tlock
+}
+
+// Encode appends m, with tag t, to b.
+func (m TlockPkt) Encode(b []byte, t Tag) []byte {
+	return AppendTlockPkt(b, t, m.OFID, m.LType, m.LFlags, m.Start, m.Length, m.ProcID, m.ClientID)
+}
+
+// Decode decodes m from b, which holds all of a Tlock, and returns its tag.
+func (m *TlockPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Tlock); err != nil {
+		return 0, err
+	}
+	OFID, LType, LFlags, Start, Length, ProcID, ClientID, t, err := DecodeTlockPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = TlockPkt{ OFID: OFID, LType: LType, LFlags: LFlags, Start: Start, Length: Length, ProcID: ProcID, ClientID: ClientID }
+	return t, nil
+}
+
+func (m TlockPkt) String() string {
+	return fmt.Sprintf("Tlock OFID %v LType %v LFlags %v Start %v Length %v ProcID %v ClientID %q", m.OFID, m.LType, m.LFlags, m.Start, m.Length, m.ProcID, m.ClientID)
+}
+func (s *Server) SrvRlock(ctx context.Context, b*bytes.Buffer) (err error) {
+	OFID, LType, LFlags, Start, Length, ProcID, ClientID,  t, err := UnmarshalTlockPkt(b)
 	//if err != nil {
 	//}
-	if OQID, IOUnit,  err := s.NS.Rcreate(OFID, Name, CreatePerm, Omode); err != nil {
-	MarshalRerrorPkt(b, t, fmt.Sprintf("%v", err))
+	if Status,  err := s.session(ctx).dotl.Rlock(ctx, OFID, LType, LFlags, Start, Length, ProcID, ClientID); err != nil {
+	s.rerror(ctx, b, t, err)
 } else {
-	MarshalRcreatePkt(b, t, OQID, IOUnit)
+	MarshalRlockPkt(b, t, Status)
 }
 	return nil
 }
 
-func (c *Client)CallTcreate (OFID FID, Name string, CreatePerm Perm, Omode Mode) (OQID QID, IOUnit MaxSize,  err error) {
-var b = bytes.Buffer{}
-if c.Trace != nil {c.Trace("%v", Tcreate)}
+func (c *Client)CallTlock (OFID FID, LType uint8, LFlags uint32, Start uint64, Length uint64, ProcID uint32, ClientID string) (Status uint8,  err error) {
+return c.CallTlockContext(context.Background(), OFID, LType, LFlags, Start, Length, ProcID, ClientID)
+}
+
+func (c *Client)CallTlockContext (ctx context.Context, OFID FID, LType uint8, LFlags uint32, Start uint64, Length uint64, ProcID uint32, ClientID string) (Status uint8,  err error) {
+if c.Trace != nil {c.Trace("%v", Tlock)}
 t := Tag(0)
-r := make (chan []byte)
 if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
-MarshalTcreatePkt(&b, t, OFID, Name, CreatePerm, Omode)
-c.FromClient <- &RPCCall{b: b.Bytes(), Reply: r}
-bb := <-r
-if MType(bb[4]) == Rerror {
-	s, _, err := UnmarshalRerrorPkt(bytes.NewBuffer(bb[5:]))
-	if err != nil {
-		return OQID, IOUnit,  err
-	}
-	return OQID, IOUnit,  fmt.Errorf("%v", s)
-} else {
-	OQID, IOUnit,  _, err = UnmarshalRcreatePkt(bytes.NewBuffer(bb[5:]))
+b := AppendTlockPkt(nil, t, OFID, LType, LFlags, Start, Length, ProcID, ClientID)
+if err = c.fits(len(b)); err != nil {
+	return Status,  err
 }
-return OQID, IOUnit,  err
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return Status,  err
 }
-func MarshalRstatPkt (b *bytes.Buffer, t Tag, B []byte) {
-var l uint64
-b.Reset()
-b.Write([]byte{0,0,0,0,
-uint8(Rstat),
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return Status,  err
+}
+Status,  _, err = DecodeRlockPkt(bb[5:])
+putFrame(bb)
+return Status,  err
+}
+
+type TlockReply struct {
+Status uint8
+}
+
+func (c *Client)GoTlock (ctx context.Context, OFID FID, LType uint8, LFlags uint32, Start uint64, Length uint64, ProcID uint32, ClientID string, done chan *Call) *Call {
+b := AppendTlockPkt(nil, Tag(0), OFID, LType, LFlags, Start, Length, ProcID, ClientID)
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	Status,  _, err := DecodeRlockPkt(bb[5:])
+	putFrame(bb)
+	return &TlockReply{ Status: Status }, err
+})
+}
+// AppendRgetlockPkt appends a Rgetlock with tag t, from its size on, to b.
+func AppendRgetlockPkt (b []byte, t Tag, RType uint8, RStart uint64, RLength uint64, RProcID uint32, RClientID string) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Rgetlock),
 byte(t), byte(t>>8),
-	uint8(len(B)>>0),
-	uint8(len(B)>>8),
-	})
-	b.Write(B)
+	uint8(RType>>0),
+	uint8(RStart>>0),
+	uint8(RStart>>8),
+	uint8(RStart>>16),
+	uint8(RStart>>24),
+	uint8(RStart>>32),
+	uint8(RStart>>40),
+	uint8(RStart>>48),
+	uint8(RStart>>56),
+	uint8(RLength>>0),
+	uint8(RLength>>8),
+	uint8(RLength>>16),
+	uint8(RLength>>24),
+	uint8(RLength>>32),
+	uint8(RLength>>40),
+	uint8(RLength>>48),
+	uint8(RLength>>56),
+	uint8(RProcID>>0),
+	uint8(RProcID>>8),
+	uint8(RProcID>>16),
+	uint8(RProcID>>24),
+	uint8(len(RClientID)),uint8(len(RClientID)>>8),
+	)
+	b = append(b, RClientID...)
 
-{
-l = uint64(b.Len())
-copy(b.Bytes(), []byte{uint8(l), uint8(l>>8), uint8(l>>16), uint8(l>>24)})
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
 }
-return
+
+func MarshalRgetlockPkt (b *bytes.Buffer, t Tag, RType uint8, RStart uint64, RLength uint64, RProcID uint32, RClientID string) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendRgetlockPkt(b.Bytes(), t, RType, RStart, RLength, RProcID, RClientID))
 }
-func UnmarshalRstatPkt (b *bytes.Buffer) (B []byte,  t Tag, err error) {
-var u [8]uint8
+// DecodeRgetlockPkt decodes a Rgetlock from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeRgetlockPkt (b []byte) (RType uint8, RStart uint64, RLength uint64, RProcID uint32, RClientID string,  t Tag, err error) {
 var l uint64
-if _, err = b.Read(u[:2]); err != nil {
-err = fmt.Errorf("pkt too short for Tag; need 2, have %d", b.Len())
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
 return
 }
-l = uint64(u[0]) | uint64(u[1])<<8
-t = Tag(l)
-	if _, err = b.Read(u[:2]); err != nil {
-		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", b.Len())
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 1 {
+		err = fmt.Errorf("pkt too short for uint8: need 1, have %d", len(b))
+	return
+	}
+	RType = uint8(b[0])
+	b = b[1:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	RStart = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	RLength = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	RProcID = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
+	return
+	}
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
 	return
 	}
-	l = uint64(u[0])
-	l |= uint64(u[1])<<8
-	B = b.Bytes()[:l]
-	_ = b.Next(int(l))
+	RClientID = string(b[:l])
+	b = b[l:]
 
-if b.Len() > 0 {
-err = fmt.Errorf("Packet too long: %d bytes left over after decode", b.Len())
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
 }
 return
 }
-func MarshalTstatPkt (b *bytes.Buffer, t Tag, OFID FID) {
-var l uint64
+
+func UnmarshalRgetlockPkt (b *bytes.Buffer) (RType uint8, RStart uint64, RLength uint64, RProcID uint32, RClientID string,  t Tag, err error) {
+RType, RStart, RLength, RProcID, RClientID,  t, err = DecodeRgetlockPkt(b.Bytes())
 b.Reset()
-b.Write([]byte{0,0,0,0,
-uint8(Tstat),
+return
+}
+
+func (RgetlockPkt) Type() MType {
+	return Rgetlock
+}
+
+// Encode appends m, with tag t, to b.
+func (m RgetlockPkt) Encode(b []byte, t Tag) []byte {
+	return AppendRgetlockPkt(b, t, m.RType, m.RStart, m.RLength, m.RProcID, m.RClientID)
+}
+
+// Decode decodes m from b, which holds all of a Rgetlock, and returns its tag.
+func (m *RgetlockPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Rgetlock); err != nil {
+		return 0, err
+	}
+	RType, RStart, RLength, RProcID, RClientID, t, err := DecodeRgetlockPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = RgetlockPkt{ RType: RType, RStart: RStart, RLength: RLength, RProcID: RProcID, RClientID: RClientID }
+	return t, nil
+}
+
+func (m RgetlockPkt) String() string {
+	return fmt.Sprintf("Rgetlock RType %v RStart %v RLength %v RProcID %v RClientID %q", m.RType, m.RStart, m.RLength, m.RProcID, m.RClientID)
+}
+// AppendTgetlockPkt appends a Tgetlock with tag t, from its size on, to b.
+func AppendTgetlockPkt (b []byte, t Tag, OFID FID, LType uint8, Start uint64, Length uint64, ProcID uint32, ClientID string) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Tgetlock),
 byte(t), byte(t>>8),
 	uint8(OFID>>0),
 	uint8(OFID>>8),
 	uint8(OFID>>16),
 	uint8(OFID>>24),
-	})
+	uint8(LType>>0),
+	uint8(Start>>0),
+	uint8(Start>>8),
+	uint8(Start>>16),
+	uint8(Start>>24),
+	uint8(Start>>32),
+	uint8(Start>>40),
+	uint8(Start>>48),
+	uint8(Start>>56),
+	uint8(Length>>0),
+	uint8(Length>>8),
+	uint8(Length>>16),
+	uint8(Length>>24),
+	uint8(Length>>32),
+	uint8(Length>>40),
+	uint8(Length>>48),
+	uint8(Length>>56),
+	uint8(ProcID>>0),
+	uint8(ProcID>>8),
+	uint8(ProcID>>16),
+	uint8(ProcID>>24),
+	uint8(len(ClientID)),uint8(len(ClientID)>>8),
+	)
+	b = append(b, ClientID...)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalTgetlockPkt (b *bytes.Buffer, t Tag, OFID FID, LType uint8, Start uint64, Length uint64, ProcID uint32, ClientID string) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendTgetlockPkt(b.Bytes(), t, OFID, LType, Start, Length, ProcID, ClientID))
+}
+// DecodeTgetlockPkt decodes a Tgetlock from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeTgetlockPkt (b []byte) (OFID FID, LType uint8, Start uint64, Length uint64, ProcID uint32, ClientID string,  t Tag, err error) {
+var l uint64
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	OFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 1 {
+		err = fmt.Errorf("pkt too short for uint8: need 1, have %d", len(b))
+	return
+	}
+	LType = uint8(b[0])
+	b = b[1:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	Start = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	Length = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	ProcID = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
+	return
+	}
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
+	return
+	}
+	ClientID = string(b[:l])
+	b = b[l:]
 
-{
-l = uint64(b.Len())
-copy(b.Bytes(), []byte{uint8(l), uint8(l>>8), uint8(l>>16), uint8(l>>24)})
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
 }
 return
 }
-func UnmarshalTstatPkt (b *bytes.Buffer) (OFID FID,  t Tag, err error) {
-var u [8]uint8
-var l uint64
-if _, err = b.Read(u[:2]); err != nil {
-err = fmt.Errorf("pkt too short for Tag; need 2, have %d", b.Len())
+
+func UnmarshalTgetlockPkt (b *bytes.Buffer) (OFID FID, LType uint8, Start uint64, Length uint64, ProcID uint32, ClientID string,  t Tag, err error) {
+OFID, LType, Start, Length, ProcID, ClientID,  t, err = DecodeTgetlockPkt(b.Bytes())
+b.Reset()
 return
 }
-l = uint64(u[0]) | uint64(u[1])<<8
-t = Tag(l)
-	if _, err = b.Read(u[:4]); err != nil {
-		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", b.Len())
-	return
-	}
-	OFID = FID(u[0])
-	OFID |= FID(u[1])<<8
-	OFID |= FID(u[2])<<16
-	OFID |= FID(u[3])<<24
 
-if b.Len() > 0 {
-err = fmt.Errorf("Packet too long: %d bytes left over after decode", b.Len())
+func (TgetlockPkt) Type() MType {
+	return Tgetlock
 }
-return
+
+// Encode appends m, with tag t, to b.
+func (m TgetlockPkt) Encode(b []byte, t Tag) []byte {
+	return AppendTgetlockPkt(b, t, m.OFID, m.LType, m.Start, m.Length, m.ProcID, m.ClientID)
 }
-func (s *Server) SrvRstat(b*bytes.Buffer) (err error) {
-	OFID,  t, err := UnmarshalTstatPkt(b)
+
+// Decode decodes m from b, which holds all of a Tgetlock, and returns its tag.
+func (m *TgetlockPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Tgetlock); err != nil {
+		return 0, err
+	}
+	OFID, LType, Start, Length, ProcID, ClientID, t, err := DecodeTgetlockPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = TgetlockPkt{ OFID: OFID, LType: LType, Start: Start, Length: Length, ProcID: ProcID, ClientID: ClientID }
+	return t, nil
+}
+
+func (m TgetlockPkt) String() string {
+	return fmt.Sprintf("Tgetlock OFID %v LType %v Start %v Length %v ProcID %v ClientID %q", m.OFID, m.LType, m.Start, m.Length, m.ProcID, m.ClientID)
+}
+func (s *Server) SrvRgetlock(ctx context.Context, b*bytes.Buffer) (err error) {
+	OFID, LType, Start, Length, ProcID, ClientID,  t, err := UnmarshalTgetlockPkt(b)
 	//if err != nil {
 	//}
-	if B,  err := s.NS.Rstat(OFID); err != nil {
-	MarshalRerrorPkt(b, t, fmt.Sprintf("%v", err))
+	if RType, RStart, RLength, RProcID, RClientID,  err := s.session(ctx).dotl.Rgetlock(ctx, OFID, LType, Start, Length, ProcID, ClientID); err != nil {
+	s.rerror(ctx, b, t, err)
 } else {
-	MarshalRstatPkt(b, t, B)
+	MarshalRgetlockPkt(b, t, RType, RStart, RLength, RProcID, RClientID)
 }
 	return nil
 }
 
-func (c *Client)CallTstat (OFID FID) (B []byte,  err error) {
-var b = bytes.Buffer{}
-if c.Trace != nil {c.Trace("%v", Tstat)}
+func (c *Client)CallTgetlock (OFID FID, LType uint8, Start uint64, Length uint64, ProcID uint32, ClientID string) (RType uint8, RStart uint64, RLength uint64, RProcID uint32, RClientID string,  err error) {
+return c.CallTgetlockContext(context.Background(), OFID, LType, Start, Length, ProcID, ClientID)
+}
+
+func (c *Client)CallTgetlockContext (ctx context.Context, OFID FID, LType uint8, Start uint64, Length uint64, ProcID uint32, ClientID string) (RType uint8, RStart uint64, RLength uint64, RProcID uint32, RClientID string,  err error) {
+if c.Trace != nil {c.Trace("%v", Tgetlock)}
 t := Tag(0)
-r := make (chan []byte)
 if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
-MarshalTstatPkt(&b, t, OFID)
-c.FromClient <- &RPCCall{b: b.Bytes(), Reply: r}
-bb := <-r
-if MType(bb[4]) == Rerror {
-	s, _, err := UnmarshalRerrorPkt(bytes.NewBuffer(bb[5:]))
-	if err != nil {
-		return B,  err
-	}
-	return B,  fmt.Errorf("%v", s)
-} else {
-	B,  _, err = UnmarshalRstatPkt(bytes.NewBuffer(bb[5:]))
+b := AppendTgetlockPkt(nil, t, OFID, LType, Start, Length, ProcID, ClientID)
+if err = c.fits(len(b)); err != nil {
+	return RType, RStart, RLength, RProcID, RClientID,  err
 }
-return B,  err
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return RType, RStart, RLength, RProcID, RClientID,  err
 }
-func MarshalRwstatPkt (b *bytes.Buffer, t Tag, ) {
-var l uint64
-b.Reset()
-b.Write([]byte{0,0,0,0,
-uint8(Rwstat),
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return RType, RStart, RLength, RProcID, RClientID,  err
+}
+RType, RStart, RLength, RProcID, RClientID,  _, err = DecodeRgetlockPkt(bb[5:])
+putFrame(bb)
+return RType, RStart, RLength, RProcID, RClientID,  err
+}
+
+type TgetlockReply struct {
+RType uint8
+RStart uint64
+RLength uint64
+RProcID uint32
+RClientID string
+}
+
+func (c *Client)GoTgetlock (ctx context.Context, OFID FID, LType uint8, Start uint64, Length uint64, ProcID uint32, ClientID string, done chan *Call) *Call {
+b := AppendTgetlockPkt(nil, Tag(0), OFID, LType, Start, Length, ProcID, ClientID)
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	RType, RStart, RLength, RProcID, RClientID,  _, err := DecodeRgetlockPkt(bb[5:])
+	putFrame(bb)
+	return &TgetlockReply{ RType: RType, RStart: RStart, RLength: RLength, RProcID: RProcID, RClientID: RClientID }, err
+})
+}
+// AppendRlinkPkt appends a Rlink with tag t, from its size on, to b.
+func AppendRlinkPkt (b []byte, t Tag, ) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Rlink),
 byte(t), byte(t>>8),
-	})
+	)
 
-{
-l = uint64(b.Len())
-copy(b.Bytes(), []byte{uint8(l), uint8(l>>8), uint8(l>>16), uint8(l>>24)})
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
 }
-return
+
+func MarshalRlinkPkt (b *bytes.Buffer, t Tag, ) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendRlinkPkt(b.Bytes(), t, ))
 }
-func UnmarshalRwstatPkt (b *bytes.Buffer) ( t Tag, err error) {
-var u [8]uint8
-var l uint64
-if _, err = b.Read(u[:2]); err != nil {
-err = fmt.Errorf("pkt too short for Tag; need 2, have %d", b.Len())
+// DecodeRlinkPkt decodes a Rlink from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeRlinkPkt (b []byte) ( t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
 return
 }
-l = uint64(u[0]) | uint64(u[1])<<8
-t = Tag(l)
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
 
-if b.Len() > 0 {
-err = fmt.Errorf("Packet too long: %d bytes left over after decode", b.Len())
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
 }
 return
 }
-func MarshalTwstatPkt (b *bytes.Buffer, t Tag, OFID FID, B []byte) {
-var l uint64
+
+func UnmarshalRlinkPkt (b *bytes.Buffer) ( t Tag, err error) {
+ t, err = DecodeRlinkPkt(b.Bytes())
 b.Reset()
-b.Write([]byte{0,0,0,0,
-uint8(Twstat),
+return
+}
+
+func (RlinkPkt) Type() MType {
+	return Rlink
+}
+
+// Encode appends m, with tag t, to b.
+func (m RlinkPkt) Encode(b []byte, t Tag) []byte {
+	return AppendRlinkPkt(b, t, )
+}
+
+// Decode decodes m from b, which holds all of a Rlink, and returns its tag.
+func (m *RlinkPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Rlink); err != nil {
+		return 0, err
+	}
+	t, err := DecodeRlinkPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = RlinkPkt{  }
+	return t, nil
+}
+
+func (m RlinkPkt) String() string {
+	return fmt.Sprintf("Rlink")
+}
+// AppendTlinkPkt appends a Tlink with tag t, from its size on, to b.
+func AppendTlinkPkt (b []byte, t Tag, DFID FID, OFID FID, Name string) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Tlink),
 byte(t), byte(t>>8),
+	uint8(DFID>>0),
+	uint8(DFID>>8),
+	uint8(DFID>>16),
+	uint8(DFID>>24),
 	uint8(OFID>>0),
 	uint8(OFID>>8),
 	uint8(OFID>>16),
 	uint8(OFID>>24),
-	uint8(len(B)>>0),
-	uint8(len(B)>>8),
-	})
-	b.Write(B)
+	uint8(len(Name)),uint8(len(Name)>>8),
+	)
+	b = append(b, Name...)
 
-{
-l = uint64(b.Len())
-copy(b.Bytes(), []byte{uint8(l), uint8(l>>8), uint8(l>>16), uint8(l>>24)})
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
 }
-return
+
+func MarshalTlinkPkt (b *bytes.Buffer, t Tag, DFID FID, OFID FID, Name string) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendTlinkPkt(b.Bytes(), t, DFID, OFID, Name))
 }
-func UnmarshalTwstatPkt (b *bytes.Buffer) (OFID FID, B []byte,  t Tag, err error) {
-var u [8]uint8
+// DecodeTlinkPkt decodes a Tlink from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeTlinkPkt (b []byte) (DFID FID, OFID FID, Name string,  t Tag, err error) {
 var l uint64
-if _, err = b.Read(u[:2]); err != nil {
-err = fmt.Errorf("pkt too short for Tag; need 2, have %d", b.Len())
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
 return
 }
-l = uint64(u[0]) | uint64(u[1])<<8
-t = Tag(l)
-	if _, err = b.Read(u[:4]); err != nil {
-		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", b.Len())
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	DFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	OFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
 	return
 	}
-	OFID = FID(u[0])
-	OFID |= FID(u[1])<<8
-	OFID |= FID(u[2])<<16
-	OFID |= FID(u[3])<<24
-	if _, err = b.Read(u[:2]); err != nil {
-		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", b.Len())
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
 	return
 	}
-	l = uint64(u[0])
-	l |= uint64(u[1])<<8
-	B = b.Bytes()[:l]
-	_ = b.Next(int(l))
+	Name = string(b[:l])
+	b = b[l:]
 
-if b.Len() > 0 {
-err = fmt.Errorf("Packet too long: %d bytes left over after decode", b.Len())
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
 }
 return
 }
-func (s *Server) SrvRwstat(b*bytes.Buffer) (err error) {
-	OFID, B,  t, err := UnmarshalTwstatPkt(b)
+
+func UnmarshalTlinkPkt (b *bytes.Buffer) (DFID FID, OFID FID, Name string,  t Tag, err error) {
+DFID, OFID, Name,  t, err = DecodeTlinkPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (TlinkPkt) Type() MType {
+	return Tlink
+}
+
+// Encode appends m, with tag t, to b.
+func (m TlinkPkt) Encode(b []byte, t Tag) []byte {
+	return AppendTlinkPkt(b, t, m.DFID, m.OFID, m.Name)
+}
+
+// Decode decodes m from b, which holds all of a Tlink, and returns its tag.
+func (m *TlinkPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Tlink); err != nil {
+		return 0, err
+	}
+	DFID, OFID, Name, t, err := DecodeTlinkPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = TlinkPkt{ DFID: DFID, OFID: OFID, Name: Name }
+	return t, nil
+}
+
+func (m TlinkPkt) String() string {
+	return fmt.Sprintf("Tlink DFID %v OFID %v Name %q", m.DFID, m.OFID, m.Name)
+}
+func (s *Server) SrvRlink(ctx context.Context, b*bytes.Buffer) (err error) {
+	DFID, OFID, Name,  t, err := UnmarshalTlinkPkt(b)
 	//if err != nil {
 	//}
-	if  err := s.NS.Rwstat(OFID, B); err != nil {
-	MarshalRerrorPkt(b, t, fmt.Sprintf("%v", err))
+	if  err := s.session(ctx).dotl.Rlink(ctx, DFID, OFID, Name); err != nil {
+	s.rerror(ctx, b, t, err)
 } else {
-	MarshalRwstatPkt(b, t, )
+	MarshalRlinkPkt(b, t, )
 }
 	return nil
 }
 
-func (c *Client)CallTwstat (OFID FID, B []byte) ( err error) {
-var b = bytes.Buffer{}
-if c.Trace != nil {c.Trace("%v", Twstat)}
+func (c *Client)CallTlink (DFID FID, OFID FID, Name string) ( err error) {
+return c.CallTlinkContext(context.Background(), DFID, OFID, Name)
+}
+
+func (c *Client)CallTlinkContext (ctx context.Context, DFID FID, OFID FID, Name string) ( err error) {
+if c.Trace != nil {c.Trace("%v", Tlink)}
 t := Tag(0)
-r := make (chan []byte)
 if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
-MarshalTwstatPkt(&b, t, OFID, B)
-c.FromClient <- &RPCCall{b: b.Bytes(), Reply: r}
-bb := <-r
-if MType(bb[4]) == Rerror {
-	s, _, err := UnmarshalRerrorPkt(bytes.NewBuffer(bb[5:]))
-	if err != nil {
-		return  err
-	}
-	return  fmt.Errorf("%v", s)
-} else {
-	 _, err = UnmarshalRwstatPkt(bytes.NewBuffer(bb[5:]))
-}
-return  err
+b := AppendTlinkPkt(nil, t, DFID, OFID, Name)
+if err = c.fits(len(b)); err != nil {
+	return  err
 }
-func MarshalRclunkPkt (b *bytes.Buffer, t Tag, ) {
-var l uint64
-b.Reset()
-b.Write([]byte{0,0,0,0,
-uint8(Rclunk),
-byte(t), byte(t>>8),
-	})
-
-{
-l = uint64(b.Len())
-copy(b.Bytes(), []byte{uint8(l), uint8(l>>8), uint8(l>>16), uint8(l>>24)})
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return  err
 }
-return
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return  err
 }
-func UnmarshalRclunkPkt (b *bytes.Buffer) ( t Tag, err error) {
-var u [8]uint8
-var l uint64
-if _, err = b.Read(u[:2]); err != nil {
-err = fmt.Errorf("pkt too short for Tag; need 2, have %d", b.Len())
-return
+ _, err = DecodeRlinkPkt(bb[5:])
+putFrame(bb)
+return  err
 }
-l = uint64(u[0]) | uint64(u[1])<<8
-t = Tag(l)
 
-if b.Len() > 0 {
-err = fmt.Errorf("Packet too long: %d bytes left over after decode", b.Len())
-}
-return
+type TlinkReply struct {
+
 }
-func MarshalTclunkPkt (b *bytes.Buffer, t Tag, OFID FID) {
-var l uint64
-b.Reset()
-b.Write([]byte{0,0,0,0,
-uint8(Tclunk),
+
+func (c *Client)GoTlink (ctx context.Context, DFID FID, OFID FID, Name string, done chan *Call) *Call {
+b := AppendTlinkPkt(nil, Tag(0), DFID, OFID, Name)
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	 _, err := DecodeRlinkPkt(bb[5:])
+	putFrame(bb)
+	return &TlinkReply{  }, err
+})
+}
+// AppendRmkdirPkt appends a Rmkdir with tag t, from its size on, to b.
+func AppendRmkdirPkt (b []byte, t Tag, OQID QID) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Rmkdir),
 byte(t), byte(t>>8),
-	uint8(OFID>>0),
-	uint8(OFID>>8),
-	uint8(OFID>>16),
-	uint8(OFID>>24),
-	})
+	uint8(OQID.Type>>0),
+	uint8(OQID.Version>>0),
+	uint8(OQID.Version>>8),
+	uint8(OQID.Version>>16),
+	uint8(OQID.Version>>24),
+	uint8(OQID.Path>>0),
+	uint8(OQID.Path>>8),
+	uint8(OQID.Path>>16),
+	uint8(OQID.Path>>24),
+	uint8(OQID.Path>>32),
+	uint8(OQID.Path>>40),
+	uint8(OQID.Path>>48),
+	uint8(OQID.Path>>56),
+	)
 
-{
-l = uint64(b.Len())
-copy(b.Bytes(), []byte{uint8(l), uint8(l>>8), uint8(l>>16), uint8(l>>24)})
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
 }
-return
+
+func MarshalRmkdirPkt (b *bytes.Buffer, t Tag, OQID QID) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendRmkdirPkt(b.Bytes(), t, OQID))
 }
-func UnmarshalTclunkPkt (b *bytes.Buffer) (OFID FID,  t Tag, err error) {
-var u [8]uint8
-var l uint64
-if _, err = b.Read(u[:2]); err != nil {
-err = fmt.Errorf("pkt too short for Tag; need 2, have %d", b.Len())
+// DecodeRmkdirPkt decodes a Rmkdir from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeRmkdirPkt (b []byte) (OQID QID,  t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
 return
 }
-l = uint64(u[0]) | uint64(u[1])<<8
-t = Tag(l)
-	if _, err = b.Read(u[:4]); err != nil {
-		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", b.Len())
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 1 {
+		err = fmt.Errorf("pkt too short for uint8: need 1, have %d", len(b))
+	return
+	}
+	OQID.Type = uint8(b[0])
+	b = b[1:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
 	return
 	}
-	OFID = FID(u[0])
-	OFID |= FID(u[1])<<8
-	OFID |= FID(u[2])<<16
-	OFID |= FID(u[3])<<24
+	OQID.Version = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	OQID.Path = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
 
-if b.Len() > 0 {
-err = fmt.Errorf("Packet too long: %d bytes left over after decode", b.Len())
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
 }
 return
 }
-func (s *Server) SrvRclunk(b*bytes.Buffer) (err error) {
-	OFID,  t, err := UnmarshalTclunkPkt(b)
-	//if err != nil {
-	//}
-	if  err := s.NS.Rclunk(OFID); err != nil {
-	MarshalRerrorPkt(b, t, fmt.Sprintf("%v", err))
-} else {
-	MarshalRclunkPkt(b, t, )
+
+func UnmarshalRmkdirPkt (b *bytes.Buffer) (OQID QID,  t Tag, err error) {
+OQID,  t, err = DecodeRmkdirPkt(b.Bytes())
+b.Reset()
+return
 }
-	return nil
+
+func (RmkdirPkt) Type() MType {
+	return Rmkdir
 }
 
-func (c *Client)CallTclunk (OFID FID) ( err error) {
-var b = bytes.Buffer{}
-if c.Trace != nil {c.Trace("%v", Tclunk)}
-t := Tag(0)
-r := make (chan []byte)
-if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
-MarshalTclunkPkt(&b, t, OFID)
-c.FromClient <- &RPCCall{b: b.Bytes(), Reply: r}
-bb := <-r
-if MType(bb[4]) == Rerror {
-	s, _, err := UnmarshalRerrorPkt(bytes.NewBuffer(bb[5:]))
+// Encode appends m, with tag t, to b.
+func (m RmkdirPkt) Encode(b []byte, t Tag) []byte {
+	return AppendRmkdirPkt(b, t, m.OQID)
+}
+
+// Decode decodes m from b, which holds all of a Rmkdir, and returns its tag.
+func (m *RmkdirPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Rmkdir); err != nil {
+		return 0, err
+	}
+	OQID, t, err := DecodeRmkdirPkt(b[5:])
 	if err != nil {
-		return  err
+		return t, err
 	}
-	return  fmt.Errorf("%v", s)
-} else {
-	 _, err = UnmarshalRclunkPkt(bytes.NewBuffer(bb[5:]))
+	*m = RmkdirPkt{ OQID: OQID }
+	return t, nil
 }
-return  err
-}
-func MarshalRremovePkt (b *bytes.Buffer, t Tag, ) {
-var l uint64
-b.Reset()
-b.Write([]byte{0,0,0,0,
-uint8(Rremove),
+
+func (m RmkdirPkt) String() string {
+	return fmt.Sprintf("Rmkdir OQID %v", m.OQID)
+}
+// AppendTmkdirPkt appends a Tmkdir with tag t, from its size on, to b.
+func AppendTmkdirPkt (b []byte, t Tag, DFID FID, Name string, CreateMode uint32, GID uint32) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Tmkdir),
 byte(t), byte(t>>8),
-	})
+	uint8(DFID>>0),
+	uint8(DFID>>8),
+	uint8(DFID>>16),
+	uint8(DFID>>24),
+	uint8(len(Name)),uint8(len(Name)>>8),
+	)
+	b = append(b, Name...)
+	b = append(b,	uint8(CreateMode>>0),
+	uint8(CreateMode>>8),
+	uint8(CreateMode>>16),
+	uint8(CreateMode>>24),
+	uint8(GID>>0),
+	uint8(GID>>8),
+	uint8(GID>>16),
+	uint8(GID>>24),
+	)
 
-{
-l = uint64(b.Len())
-copy(b.Bytes(), []byte{uint8(l), uint8(l>>8), uint8(l>>16), uint8(l>>24)})
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
 }
-return
+
+func MarshalTmkdirPkt (b *bytes.Buffer, t Tag, DFID FID, Name string, CreateMode uint32, GID uint32) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendTmkdirPkt(b.Bytes(), t, DFID, Name, CreateMode, GID))
 }
-func UnmarshalRremovePkt (b *bytes.Buffer) ( t Tag, err error) {
-var u [8]uint8
+// DecodeTmkdirPkt decodes a Tmkdir from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeTmkdirPkt (b []byte) (DFID FID, Name string, CreateMode uint32, GID uint32,  t Tag, err error) {
 var l uint64
-if _, err = b.Read(u[:2]); err != nil {
-err = fmt.Errorf("pkt too short for Tag; need 2, have %d", b.Len())
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
 return
 }
-l = uint64(u[0]) | uint64(u[1])<<8
-t = Tag(l)
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	DFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
+	return
+	}
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
+	return
+	}
+	Name = string(b[:l])
+	b = b[l:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	CreateMode = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	GID = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
 
-if b.Len() > 0 {
-err = fmt.Errorf("Packet too long: %d bytes left over after decode", b.Len())
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
 }
 return
 }
-func MarshalTremovePkt (b *bytes.Buffer, t Tag, OFID FID) {
-var l uint64
-b.Reset()
-b.Write([]byte{0,0,0,0,
-uint8(Tremove),
-byte(t), byte(t>>8),
-	uint8(OFID>>0),
-	uint8(OFID>>8),
-	uint8(OFID>>16),
-	uint8(OFID>>24),
-	})
 
-{
-l = uint64(b.Len())
-copy(b.Bytes(), []byte{uint8(l), uint8(l>>8), uint8(l>>16), uint8(l>>24)})
-}
+func UnmarshalTmkdirPkt (b *bytes.Buffer) (DFID FID, Name string, CreateMode uint32, GID uint32,  t Tag, err error) {
+DFID, Name, CreateMode, GID,  t, err = DecodeTmkdirPkt(b.Bytes())
+b.Reset()
 return
 }
-func UnmarshalTremovePkt (b *bytes.Buffer) (OFID FID,  t Tag, err error) {
-var u [8]uint8
-var l uint64
-if _, err = b.Read(u[:2]); err != nil {
-err = fmt.Errorf("pkt too short for Tag; need 2, have %d", b.Len())
-return
+
+func (TmkdirPkt) Type() MType {
+	return Tmkdir
 }
-l = uint64(u[0]) | uint64(u[1])<<8
-t = Tag(l)
-	if _, err = b.Read(u[:4]); err != nil {
-		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", b.Len())
-	return
-	}
-	OFID = FID(u[0])
-	OFID |= FID(u[1])<<8
-	OFID |= FID(u[2])<<16
-	OFID |= FID(u[3])<<24
 
-if b.Len() > 0 {
-err = fmt.Errorf("Packet too long: %d bytes left over after decode", b.Len())
+// Encode appends m, with tag t, to b.
+func (m TmkdirPkt) Encode(b []byte, t Tag) []byte {
+	return AppendTmkdirPkt(b, t, m.DFID, m.Name, m.CreateMode, m.GID)
 }
-return
+
+// Decode decodes m from b, which holds all of a Tmkdir, and returns its tag.
+func (m *TmkdirPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Tmkdir); err != nil {
+		return 0, err
+	}
+	DFID, Name, CreateMode, GID, t, err := DecodeTmkdirPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = TmkdirPkt{ DFID: DFID, Name: Name, CreateMode: CreateMode, GID: GID }
+	return t, nil
 }
-func (s *Server) SrvRremove(b*bytes.Buffer) (err error) {
-	OFID,  t, err := UnmarshalTremovePkt(b)
+
+func (m TmkdirPkt) String() string {
+	return fmt.Sprintf("Tmkdir DFID %v Name %q CreateMode %v GID %v", m.DFID, m.Name, m.CreateMode, m.GID)
+}
+func (s *Server) SrvRmkdir(ctx context.Context, b*bytes.Buffer) (err error) {
+	DFID, Name, CreateMode, GID,  t, err := UnmarshalTmkdirPkt(b)
 	//if err != nil {
 	//}
-	if  err := s.NS.Rremove(OFID); err != nil {
-	MarshalRerrorPkt(b, t, fmt.Sprintf("%v", err))
+	if OQID,  err := s.session(ctx).dotl.Rmkdir(ctx, DFID, Name, CreateMode, GID); err != nil {
+	s.rerror(ctx, b, t, err)
 } else {
-	MarshalRremovePkt(b, t, )
+	MarshalRmkdirPkt(b, t, OQID)
 }
 	return nil
 }
 
-func (c *Client)CallTremove (OFID FID) ( err error) {
-var b = bytes.Buffer{}
-if c.Trace != nil {c.Trace("%v", Tremove)}
+func (c *Client)CallTmkdir (DFID FID, Name string, CreateMode uint32, GID uint32) (OQID QID,  err error) {
+return c.CallTmkdirContext(context.Background(), DFID, Name, CreateMode, GID)
+}
+
+func (c *Client)CallTmkdirContext (ctx context.Context, DFID FID, Name string, CreateMode uint32, GID uint32) (OQID QID,  err error) {
+if c.Trace != nil {c.Trace("%v", Tmkdir)}
 t := Tag(0)
-r := make (chan []byte)
 if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
-MarshalTremovePkt(&b, t, OFID)
-c.FromClient <- &RPCCall{b: b.Bytes(), Reply: r}
-bb := <-r
-if MType(bb[4]) == Rerror {
-	s, _, err := UnmarshalRerrorPkt(bytes.NewBuffer(bb[5:]))
-	if err != nil {
-		return  err
-	}
-	return  fmt.Errorf("%v", s)
-} else {
-	 _, err = UnmarshalRremovePkt(bytes.NewBuffer(bb[5:]))
-}
-return  err
+b := AppendTmkdirPkt(nil, t, DFID, Name, CreateMode, GID)
+if err = c.fits(len(b)); err != nil {
+	return OQID,  err
 }
-func MarshalRreadPkt (b *bytes.Buffer, t Tag, Data []uint8) {
-var l uint64
-b.Reset()
-b.Write([]byte{0,0,0,0,
-uint8(Rread),
-byte(t), byte(t>>8),
-	uint8(len(Data)>>0),
-	uint8(len(Data)>>8),
-	uint8(len(Data)>>16),
-	uint8(len(Data)>>24),
-	})
-	b.Write(Data)
-
-{
-l = uint64(b.Len())
-copy(b.Bytes(), []byte{uint8(l), uint8(l>>8), uint8(l>>16), uint8(l>>24)})
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return OQID,  err
 }
-return
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return OQID,  err
 }
-func UnmarshalRreadPkt (b *bytes.Buffer) (Data []uint8,  t Tag, err error) {
-var u [8]uint8
-var l uint64
-if _, err = b.Read(u[:2]); err != nil {
-err = fmt.Errorf("pkt too short for Tag; need 2, have %d", b.Len())
-return
+OQID,  _, err = DecodeRmkdirPkt(bb[5:])
+putFrame(bb)
+return OQID,  err
 }
-l = uint64(u[0]) | uint64(u[1])<<8
-t = Tag(l)
-	if _, err = b.Read(u[:4]); err != nil {
-		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", b.Len())
-	return
-	}
-	l = uint64(u[0])
-	l |= uint64(u[1])<<8
-	l |= uint64(u[2])<<16
-	l |= uint64(u[3])<<24
-	Data = b.Bytes()[:l]
-	_ = b.Next(int(l))
 
-if b.Len() > 0 {
-err = fmt.Errorf("Packet too long: %d bytes left over after decode", b.Len())
+type TmkdirReply struct {
+OQID QID
 }
-return
+
+func (c *Client)GoTmkdir (ctx context.Context, DFID FID, Name string, CreateMode uint32, GID uint32, done chan *Call) *Call {
+b := AppendTmkdirPkt(nil, Tag(0), DFID, Name, CreateMode, GID)
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	OQID,  _, err := DecodeRmkdirPkt(bb[5:])
+	putFrame(bb)
+	return &TmkdirReply{ OQID: OQID }, err
+})
+}
+// AppendRrenameatPkt appends a Rrenameat with tag t, from its size on, to b.
+func AppendRrenameatPkt (b []byte, t Tag, ) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Rrenameat),
+byte(t), byte(t>>8),
+	)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
 }
-func MarshalTreadPkt (b *bytes.Buffer, t Tag, OFID FID, Off Offset, Len Count) {
-var l uint64
+
+func MarshalRrenameatPkt (b *bytes.Buffer, t Tag, ) {
 b.Reset()
-b.Write([]byte{0,0,0,0,
-uint8(Tread),
-byte(t), byte(t>>8),
-	uint8(OFID>>0),
-	uint8(OFID>>8),
-	uint8(OFID>>16),
-	uint8(OFID>>24),
-	uint8(Off>>0),
-	uint8(Off>>8),
-	uint8(Off>>16),
-	uint8(Off>>24),
-	uint8(Off>>32),
-	uint8(Off>>40),
-	uint8(Off>>48),
-	uint8(Off>>56),
-	uint8(Len>>0),
-	uint8(Len>>8),
-	uint8(Len>>16),
-	uint8(Len>>24),
-	})
+*b = *bytes.NewBuffer(AppendRrenameatPkt(b.Bytes(), t, ))
+}
+// DecodeRrenameatPkt decodes a Rrenameat from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeRrenameatPkt (b []byte) ( t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
+return
+}
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
 
-{
-l = uint64(b.Len())
-copy(b.Bytes(), []byte{uint8(l), uint8(l>>8), uint8(l>>16), uint8(l>>24)})
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
 }
 return
 }
-func UnmarshalTreadPkt (b *bytes.Buffer) (OFID FID, Off Offset, Len Count,  t Tag, err error) {
-var u [8]uint8
+
+func UnmarshalRrenameatPkt (b *bytes.Buffer) ( t Tag, err error) {
+ t, err = DecodeRrenameatPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (RrenameatPkt) Type() MType {
+	return Rrenameat
+}
+
+// Encode appends m, with tag t, to b.
+func (m RrenameatPkt) Encode(b []byte, t Tag) []byte {
+	return AppendRrenameatPkt(b, t, )
+}
+
+// Decode decodes m from b, which holds all of a Rrenameat, and returns its tag.
+func (m *RrenameatPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Rrenameat); err != nil {
+		return 0, err
+	}
+	t, err := DecodeRrenameatPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = RrenameatPkt{  }
+	return t, nil
+}
+
+func (m RrenameatPkt) String() string {
+	return fmt.Sprintf("Rrenameat")
+}
+// AppendTrenameatPkt appends a Trenameat with tag t, from its size on, to b.
+func AppendTrenameatPkt (b []byte, t Tag, OldDFID FID, OldName string, NewDFID FID, NewName string) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Trenameat),
+byte(t), byte(t>>8),
+	uint8(OldDFID>>0),
+	uint8(OldDFID>>8),
+	uint8(OldDFID>>16),
+	uint8(OldDFID>>24),
+	uint8(len(OldName)),uint8(len(OldName)>>8),
+	)
+	b = append(b, OldName...)
+	b = append(b,	uint8(NewDFID>>0),
+	uint8(NewDFID>>8),
+	uint8(NewDFID>>16),
+	uint8(NewDFID>>24),
+	uint8(len(NewName)),uint8(len(NewName)>>8),
+	)
+	b = append(b, NewName...)
+
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
+}
+
+func MarshalTrenameatPkt (b *bytes.Buffer, t Tag, OldDFID FID, OldName string, NewDFID FID, NewName string) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendTrenameatPkt(b.Bytes(), t, OldDFID, OldName, NewDFID, NewName))
+}
+// DecodeTrenameatPkt decodes a Trenameat from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeTrenameatPkt (b []byte) (OldDFID FID, OldName string, NewDFID FID, NewName string,  t Tag, err error) {
 var l uint64
-if _, err = b.Read(u[:2]); err != nil {
-err = fmt.Errorf("pkt too short for Tag; need 2, have %d", b.Len())
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
 return
 }
-l = uint64(u[0]) | uint64(u[1])<<8
-t = Tag(l)
-	if _, err = b.Read(u[:4]); err != nil {
-		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", b.Len())
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	OldDFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
 	return
 	}
-	OFID = FID(u[0])
-	OFID |= FID(u[1])<<8
-	OFID |= FID(u[2])<<16
-	OFID |= FID(u[3])<<24
-	if _, err = b.Read(u[:8]); err != nil {
-		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", b.Len())
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
 	return
 	}
-	Off = Offset(u[0])
-	Off |= Offset(u[1])<<8
-	Off |= Offset(u[2])<<16
-	Off |= Offset(u[3])<<24
-	Off |= Offset(u[4])<<32
-	Off |= Offset(u[5])<<40
-	Off |= Offset(u[6])<<48
-	Off |= Offset(u[7])<<56
-	if _, err = b.Read(u[:4]); err != nil {
-		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", b.Len())
+	OldName = string(b[:l])
+	b = b[l:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
 	return
 	}
-	Len = Count(u[0])
-	Len |= Count(u[1])<<8
-	Len |= Count(u[2])<<16
-	Len |= Count(u[3])<<24
+	NewDFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
+	return
+	}
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
+	return
+	}
+	NewName = string(b[:l])
+	b = b[l:]
 
-if b.Len() > 0 {
-err = fmt.Errorf("Packet too long: %d bytes left over after decode", b.Len())
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
 }
 return
 }
-func (s *Server) SrvRread(b*bytes.Buffer) (err error) {
-	OFID, Off, Len,  t, err := UnmarshalTreadPkt(b)
+
+func UnmarshalTrenameatPkt (b *bytes.Buffer) (OldDFID FID, OldName string, NewDFID FID, NewName string,  t Tag, err error) {
+OldDFID, OldName, NewDFID, NewName,  t, err = DecodeTrenameatPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (TrenameatPkt) Type() MType {
+	return Trenameat
+}
+
+// Encode appends m, with tag t, to b.
+func (m TrenameatPkt) Encode(b []byte, t Tag) []byte {
+	return AppendTrenameatPkt(b, t, m.OldDFID, m.OldName, m.NewDFID, m.NewName)
+}
+
+// Decode decodes m from b, which holds all of a Trenameat, and returns its tag.
+func (m *TrenameatPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Trenameat); err != nil {
+		return 0, err
+	}
+	OldDFID, OldName, NewDFID, NewName, t, err := DecodeTrenameatPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = TrenameatPkt{ OldDFID: OldDFID, OldName: OldName, NewDFID: NewDFID, NewName: NewName }
+	return t, nil
+}
+
+func (m TrenameatPkt) String() string {
+	return fmt.Sprintf("Trenameat OldDFID %v OldName %q NewDFID %v NewName %q", m.OldDFID, m.OldName, m.NewDFID, m.NewName)
+}
+func (s *Server) SrvRrenameat(ctx context.Context, b*bytes.Buffer) (err error) {
+	OldDFID, OldName, NewDFID, NewName,  t, err := UnmarshalTrenameatPkt(b)
 	//if err != nil {
 	//}
-	if Data,  err := s.NS.Rread(OFID, Off, Len); err != nil {
-	MarshalRerrorPkt(b, t, fmt.Sprintf("%v", err))
+	if  err := s.session(ctx).dotl.Rrenameat(ctx, OldDFID, OldName, NewDFID, NewName); err != nil {
+	s.rerror(ctx, b, t, err)
 } else {
-	MarshalRreadPkt(b, t, Data)
+	MarshalRrenameatPkt(b, t, )
 }
 	return nil
 }
 
-func (c *Client)CallTread (OFID FID, Off Offset, Len Count) (Data []uint8,  err error) {
-var b = bytes.Buffer{}
-if c.Trace != nil {c.Trace("%v", Tread)}
+func (c *Client)CallTrenameat (OldDFID FID, OldName string, NewDFID FID, NewName string) ( err error) {
+return c.CallTrenameatContext(context.Background(), OldDFID, OldName, NewDFID, NewName)
+}
+
+func (c *Client)CallTrenameatContext (ctx context.Context, OldDFID FID, OldName string, NewDFID FID, NewName string) ( err error) {
+if c.Trace != nil {c.Trace("%v", Trenameat)}
 t := Tag(0)
-r := make (chan []byte)
 if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
-MarshalTreadPkt(&b, t, OFID, Off, Len)
-c.FromClient <- &RPCCall{b: b.Bytes(), Reply: r}
-bb := <-r
-if MType(bb[4]) == Rerror {
-	s, _, err := UnmarshalRerrorPkt(bytes.NewBuffer(bb[5:]))
-	if err != nil {
-		return Data,  err
-	}
-	return Data,  fmt.Errorf("%v", s)
-} else {
-	Data,  _, err = UnmarshalRreadPkt(bytes.NewBuffer(bb[5:]))
+b := AppendTrenameatPkt(nil, t, OldDFID, OldName, NewDFID, NewName)
+if err = c.fits(len(b)); err != nil {
+	return  err
 }
-return Data,  err
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return  err
 }
-func MarshalRwritePkt (b *bytes.Buffer, t Tag, RLen Count) {
-var l uint64
-b.Reset()
-b.Write([]byte{0,0,0,0,
-uint8(Rwrite),
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return  err
+}
+ _, err = DecodeRrenameatPkt(bb[5:])
+putFrame(bb)
+return  err
+}
+
+type TrenameatReply struct {
+
+}
+
+func (c *Client)GoTrenameat (ctx context.Context, OldDFID FID, OldName string, NewDFID FID, NewName string, done chan *Call) *Call {
+b := AppendTrenameatPkt(nil, Tag(0), OldDFID, OldName, NewDFID, NewName)
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	 _, err := DecodeRrenameatPkt(bb[5:])
+	putFrame(bb)
+	return &TrenameatReply{  }, err
+})
+}
+// AppendRunlinkatPkt appends a Runlinkat with tag t, from its size on, to b.
+func AppendRunlinkatPkt (b []byte, t Tag, ) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Runlinkat),
 byte(t), byte(t>>8),
-	uint8(RLen>>0),
-	uint8(RLen>>8),
-	uint8(RLen>>16),
-	uint8(RLen>>24),
-	})
+	)
 
-{
-l = uint64(b.Len())
-copy(b.Bytes(), []byte{uint8(l), uint8(l>>8), uint8(l>>16), uint8(l>>24)})
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
 }
-return
+
+func MarshalRunlinkatPkt (b *bytes.Buffer, t Tag, ) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendRunlinkatPkt(b.Bytes(), t, ))
 }
-func UnmarshalRwritePkt (b *bytes.Buffer) (RLen Count,  t Tag, err error) {
-var u [8]uint8
-var l uint64
-if _, err = b.Read(u[:2]); err != nil {
-err = fmt.Errorf("pkt too short for Tag; need 2, have %d", b.Len())
+// DecodeRunlinkatPkt decodes a Runlinkat from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeRunlinkatPkt (b []byte) ( t Tag, err error) {
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
 return
 }
-l = uint64(u[0]) | uint64(u[1])<<8
-t = Tag(l)
-	if _, err = b.Read(u[:4]); err != nil {
-		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", b.Len())
-	return
-	}
-	RLen = Count(u[0])
-	RLen |= Count(u[1])<<8
-	RLen |= Count(u[2])<<16
-	RLen |= Count(u[3])<<24
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
 
-if b.Len() > 0 {
-err = fmt.Errorf("Packet too long: %d bytes left over after decode", b.Len())
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
 }
 return
 }
-func MarshalTwritePkt (b *bytes.Buffer, t Tag, OFID FID, Off Offset, Data []uint8) {
-var l uint64
+
+func UnmarshalRunlinkatPkt (b *bytes.Buffer) ( t Tag, err error) {
+ t, err = DecodeRunlinkatPkt(b.Bytes())
 b.Reset()
-b.Write([]byte{0,0,0,0,
-uint8(Twrite),
+return
+}
+
+func (RunlinkatPkt) Type() MType {
+	return Runlinkat
+}
+
+// Encode appends m, with tag t, to b.
+func (m RunlinkatPkt) Encode(b []byte, t Tag) []byte {
+	return AppendRunlinkatPkt(b, t, )
+}
+
+// Decode decodes m from b, which holds all of a Runlinkat, and returns its tag.
+func (m *RunlinkatPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Runlinkat); err != nil {
+		return 0, err
+	}
+	t, err := DecodeRunlinkatPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = RunlinkatPkt{  }
+	return t, nil
+}
+
+func (m RunlinkatPkt) String() string {
+	return fmt.Sprintf("Runlinkat")
+}
+// AppendTunlinkatPkt appends a Tunlinkat with tag t, from its size on, to b.
+func AppendTunlinkatPkt (b []byte, t Tag, DFID FID, Name string, UFlags uint32) []byte {
+o := len(b)
+b = append(b, 0,0,0,0,
+uint8(Tunlinkat),
 byte(t), byte(t>>8),
-	uint8(OFID>>0),
-	uint8(OFID>>8),
-	uint8(OFID>>16),
-	uint8(OFID>>24),
-	uint8(Off>>0),
-	uint8(Off>>8),
-	uint8(Off>>16),
-	uint8(Off>>24),
-	uint8(Off>>32),
-	uint8(Off>>40),
-	uint8(Off>>48),
-	uint8(Off>>56),
-	uint8(len(Data)>>0),
-	uint8(len(Data)>>8),
-	uint8(len(Data)>>16),
-	uint8(len(Data)>>24),
-	})
-	b.Write(Data)
+	uint8(DFID>>0),
+	uint8(DFID>>8),
+	uint8(DFID>>16),
+	uint8(DFID>>24),
+	uint8(len(Name)),uint8(len(Name)>>8),
+	)
+	b = append(b, Name...)
+	b = append(b,	uint8(UFlags>>0),
+	uint8(UFlags>>8),
+	uint8(UFlags>>16),
+	uint8(UFlags>>24),
+	)
 
-{
-l = uint64(b.Len())
-copy(b.Bytes(), []byte{uint8(l), uint8(l>>8), uint8(l>>16), uint8(l>>24)})
+binary.LittleEndian.PutUint32(b[o:], uint32(len(b)-o))
+return b
 }
-return
+
+func MarshalTunlinkatPkt (b *bytes.Buffer, t Tag, DFID FID, Name string, UFlags uint32) {
+b.Reset()
+*b = *bytes.NewBuffer(AppendTunlinkatPkt(b.Bytes(), t, DFID, Name, UFlags))
 }
-func UnmarshalTwritePkt (b *bytes.Buffer) (OFID FID, Off Offset, Data []uint8,  t Tag, err error) {
-var u [8]uint8
+// DecodeTunlinkatPkt decodes a Tunlinkat from b, which starts at its tag, after the size and type.
+// Byte slices in it refer to b; strings, and other slices, are allocated.
+func DecodeTunlinkatPkt (b []byte) (DFID FID, Name string, UFlags uint32,  t Tag, err error) {
 var l uint64
-if _, err = b.Read(u[:2]); err != nil {
-err = fmt.Errorf("pkt too short for Tag; need 2, have %d", b.Len())
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for Tag; need 2, have %d", len(b))
 return
 }
-l = uint64(u[0]) | uint64(u[1])<<8
-t = Tag(l)
-	if _, err = b.Read(u[:4]); err != nil {
-		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", b.Len())
+t = Tag(binary.LittleEndian.Uint16(b))
+b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	DFID = FID(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
 	return
 	}
-	OFID = FID(u[0])
-	OFID |= FID(u[1])<<8
-	OFID |= FID(u[2])<<16
-	OFID |= FID(u[3])<<24
-	if _, err = b.Read(u[:8]); err != nil {
-		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", b.Len())
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
 	return
 	}
-	Off = Offset(u[0])
-	Off |= Offset(u[1])<<8
-	Off |= Offset(u[2])<<16
-	Off |= Offset(u[3])<<24
-	Off |= Offset(u[4])<<32
-	Off |= Offset(u[5])<<40
-	Off |= Offset(u[6])<<48
-	Off |= Offset(u[7])<<56
-	if _, err = b.Read(u[:4]); err != nil {
-		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", b.Len())
+	Name = string(b[:l])
+	b = b[l:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
 	return
 	}
-	l = uint64(u[0])
-	l |= uint64(u[1])<<8
-	l |= uint64(u[2])<<16
-	l |= uint64(u[3])<<24
-	Data = b.Bytes()[:l]
-	_ = b.Next(int(l))
+	UFlags = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
 
-if b.Len() > 0 {
-err = fmt.Errorf("Packet too long: %d bytes left over after decode", b.Len())
+if len(b) > 0 {
+err = fmt.Errorf("Packet too long: %d bytes left over after decode", len(b))
 }
 return
 }
-func (s *Server) SrvRwrite(b*bytes.Buffer) (err error) {
-	OFID, Off, Data,  t, err := UnmarshalTwritePkt(b)
+
+func UnmarshalTunlinkatPkt (b *bytes.Buffer) (DFID FID, Name string, UFlags uint32,  t Tag, err error) {
+DFID, Name, UFlags,  t, err = DecodeTunlinkatPkt(b.Bytes())
+b.Reset()
+return
+}
+
+func (TunlinkatPkt) Type() MType {
+	return Tunlinkat
+}
+
+// Encode appends m, with tag t, to b.
+func (m TunlinkatPkt) Encode(b []byte, t Tag) []byte {
+	return AppendTunlinkatPkt(b, t, m.DFID, m.Name, m.UFlags)
+}
+
+// Decode decodes m from b, which holds all of a Tunlinkat, and returns its tag.
+func (m *TunlinkatPkt) Decode(b []byte) (Tag, error) {
+	if err := checkMsg(b, Tunlinkat); err != nil {
+		return 0, err
+	}
+	DFID, Name, UFlags, t, err := DecodeTunlinkatPkt(b[5:])
+	if err != nil {
+		return t, err
+	}
+	*m = TunlinkatPkt{ DFID: DFID, Name: Name, UFlags: UFlags }
+	return t, nil
+}
+
+func (m TunlinkatPkt) String() string {
+	return fmt.Sprintf("Tunlinkat DFID %v Name %q UFlags %v", m.DFID, m.Name, m.UFlags)
+}
+func (s *Server) SrvRunlinkat(ctx context.Context, b*bytes.Buffer) (err error) {
+	DFID, Name, UFlags,  t, err := UnmarshalTunlinkatPkt(b)
 	//if err != nil {
 	//}
-	if RLen,  err := s.NS.Rwrite(OFID, Off, Data); err != nil {
-	MarshalRerrorPkt(b, t, fmt.Sprintf("%v", err))
+	if  err := s.session(ctx).dotl.Runlinkat(ctx, DFID, Name, UFlags); err != nil {
+	s.rerror(ctx, b, t, err)
 } else {
-	MarshalRwritePkt(b, t, RLen)
+	MarshalRunlinkatPkt(b, t, )
 }
 	return nil
 }
 
-func (c *Client)CallTwrite (OFID FID, Off Offset, Data []uint8) (RLen Count,  err error) {
-var b = bytes.Buffer{}
-if c.Trace != nil {c.Trace("%v", Twrite)}
+func (c *Client)CallTunlinkat (DFID FID, Name string, UFlags uint32) ( err error) {
+return c.CallTunlinkatContext(context.Background(), DFID, Name, UFlags)
+}
+
+func (c *Client)CallTunlinkatContext (ctx context.Context, DFID FID, Name string, UFlags uint32) ( err error) {
+if c.Trace != nil {c.Trace("%v", Tunlinkat)}
 t := Tag(0)
-r := make (chan []byte)
 if c.Trace != nil { c.Trace(":tag %v, FID %v", t, c.FID)}
-MarshalTwritePkt(&b, t, OFID, Off, Data)
-c.FromClient <- &RPCCall{b: b.Bytes(), Reply: r}
-bb := <-r
-if MType(bb[4]) == Rerror {
-	s, _, err := UnmarshalRerrorPkt(bytes.NewBuffer(bb[5:]))
-	if err != nil {
-		return RLen,  err
-	}
-	return RLen,  fmt.Errorf("%v", s)
-} else {
-	RLen,  _, err = UnmarshalRwritePkt(bytes.NewBuffer(bb[5:]))
+b := AppendTunlinkatPkt(nil, t, DFID, Name, UFlags)
+if err = c.fits(len(b)); err != nil {
+	return  err
 }
-return RLen,  err
+bb, err := c.rpc(ctx, b)
+if err != nil {
+	return  err
+}
+if MType(bb[4]) == Rerror || MType(bb[4]) == Rlerror {
+	err = rerror(bb)
+	putFrame(bb)
+	return  err
+}
+ _, err = DecodeRunlinkatPkt(bb[5:])
+putFrame(bb)
+return  err
+}
+
+type TunlinkatReply struct {
+
+}
+
+func (c *Client)GoTunlinkat (ctx context.Context, DFID FID, Name string, UFlags uint32, done chan *Call) *Call {
+b := AppendTunlinkatPkt(nil, Tag(0), DFID, Name, UFlags)
+return c.goCall(ctx, b, done, func(bb []byte) (interface{}, error) {
+	 _, err := DecodeRunlinkatPkt(bb[5:])
+	putFrame(bb)
+	return &TunlinkatReply{  }, err
+})
 }
 func ServerError (b *bytes.Buffer, s string) {
 	var u [8]byte
@@ -1743,11 +8473,333 @@ func ServerError (b *bytes.Buffer, s string) {
 	t := Tag(uint16(u[0])|uint16(u[1])<<8)
 	MarshalRerrorPkt (b, t, s)
 }
+
+// newMsg returns a new Msg of type t, or nil if there is none.
+func newMsg(t MType) Msg {
+	switch t {
+	case Rerror:
+		return &RerrorPkt{}
+	case Rversion:
+		return &RversionPkt{}
+	case Tversion:
+		return &TversionPkt{}
+	case Rauth:
+		return &RauthPkt{}
+	case Tauth:
+		return &TauthPkt{}
+	case Rattach:
+		return &RattachPkt{}
+	case Tattach:
+		return &TattachPkt{}
+	case Rflush:
+		return &RflushPkt{}
+	case Tflush:
+		return &TflushPkt{}
+	case Rwalk:
+		return &RwalkPkt{}
+	case Twalk:
+		return &TwalkPkt{}
+	case Ropen:
+		return &RopenPkt{}
+	case Topen:
+		return &TopenPkt{}
+	case Rcreate:
+		return &RcreatePkt{}
+	case Tcreate:
+		return &TcreatePkt{}
+	case Rstat:
+		return &RstatPkt{}
+	case Tstat:
+		return &TstatPkt{}
+	case Rwstat:
+		return &RwstatPkt{}
+	case Twstat:
+		return &TwstatPkt{}
+	case Rclunk:
+		return &RclunkPkt{}
+	case Tclunk:
+		return &TclunkPkt{}
+	case Rremove:
+		return &RremovePkt{}
+	case Tremove:
+		return &TremovePkt{}
+	case Rread:
+		return &RreadPkt{}
+	case Tread:
+		return &TreadPkt{}
+	case Rwrite:
+		return &RwritePkt{}
+	case Twrite:
+		return &TwritePkt{}
+	case Rlerror:
+		return &RlerrorPkt{}
+	case Rstatfs:
+		return &RstatfsPkt{}
+	case Tstatfs:
+		return &TstatfsPkt{}
+	case Rlopen:
+		return &RlopenPkt{}
+	case Tlopen:
+		return &TlopenPkt{}
+	case Rlcreate:
+		return &RlcreatePkt{}
+	case Tlcreate:
+		return &TlcreatePkt{}
+	case Rsymlink:
+		return &RsymlinkPkt{}
+	case Tsymlink:
+		return &TsymlinkPkt{}
+	case Rmknod:
+		return &RmknodPkt{}
+	case Tmknod:
+		return &TmknodPkt{}
+	case Rrename:
+		return &RrenamePkt{}
+	case Trename:
+		return &TrenamePkt{}
+	case Rreadlink:
+		return &RreadlinkPkt{}
+	case Treadlink:
+		return &TreadlinkPkt{}
+	case Rgetattr:
+		return &RgetattrPkt{}
+	case Tgetattr:
+		return &TgetattrPkt{}
+	case Rsetattr:
+		return &RsetattrPkt{}
+	case Tsetattr:
+		return &TsetattrPkt{}
+	case Rxattrwalk:
+		return &RxattrwalkPkt{}
+	case Txattrwalk:
+		return &TxattrwalkPkt{}
+	case Rxattrcreate:
+		return &RxattrcreatePkt{}
+	case Txattrcreate:
+		return &TxattrcreatePkt{}
+	case Rreaddir:
+		return &RreaddirPkt{}
+	case Treaddir:
+		return &TreaddirPkt{}
+	case Rfsync:
+		return &RfsyncPkt{}
+	case Tfsync:
+		return &TfsyncPkt{}
+	case Rlock:
+		return &RlockPkt{}
+	case Tlock:
+		return &TlockPkt{}
+	case Rgetlock:
+		return &RgetlockPkt{}
+	case Tgetlock:
+		return &TgetlockPkt{}
+	case Rlink:
+		return &RlinkPkt{}
+	case Tlink:
+		return &TlinkPkt{}
+	case Rmkdir:
+		return &RmkdirPkt{}
+	case Tmkdir:
+		return &TmkdirPkt{}
+	case Rrenameat:
+		return &RrenameatPkt{}
+	case Trenameat:
+		return &TrenameatPkt{}
+	case Runlinkat:
+		return &RunlinkatPkt{}
+	case Tunlinkat:
+		return &TunlinkatPkt{}
+	}
+	return nil
+}
+// Appenddir appends the encoding of a dir, with its size, to b.
+func Appenddir (b []byte, D Dir) []byte {
+o := len(b)
+b = append(b, 0, 0)
+	b = append(b,	uint8(D.Type>>0),
+	uint8(D.Type>>8),
+	uint8(D.Dev>>0),
+	uint8(D.Dev>>8),
+	uint8(D.Dev>>16),
+	uint8(D.Dev>>24),
+	uint8(D.QID.Type>>0),
+	uint8(D.QID.Version>>0),
+	uint8(D.QID.Version>>8),
+	uint8(D.QID.Version>>16),
+	uint8(D.QID.Version>>24),
+	uint8(D.QID.Path>>0),
+	uint8(D.QID.Path>>8),
+	uint8(D.QID.Path>>16),
+	uint8(D.QID.Path>>24),
+	uint8(D.QID.Path>>32),
+	uint8(D.QID.Path>>40),
+	uint8(D.QID.Path>>48),
+	uint8(D.QID.Path>>56),
+	uint8(D.Mode>>0),
+	uint8(D.Mode>>8),
+	uint8(D.Mode>>16),
+	uint8(D.Mode>>24),
+	uint8(D.Atime>>0),
+	uint8(D.Atime>>8),
+	uint8(D.Atime>>16),
+	uint8(D.Atime>>24),
+	uint8(D.Mtime>>0),
+	uint8(D.Mtime>>8),
+	uint8(D.Mtime>>16),
+	uint8(D.Mtime>>24),
+	uint8(D.Length>>0),
+	uint8(D.Length>>8),
+	uint8(D.Length>>16),
+	uint8(D.Length>>24),
+	uint8(D.Length>>32),
+	uint8(D.Length>>40),
+	uint8(D.Length>>48),
+	uint8(D.Length>>56),
+	uint8(len(D.Name)),uint8(len(D.Name)>>8),
+	)
+	b = append(b, D.Name...)
+	b = append(b,	uint8(len(D.User)),uint8(len(D.User)>>8),
+	)
+	b = append(b, D.User...)
+	b = append(b,	uint8(len(D.Group)),uint8(len(D.Group)>>8),
+	)
+	b = append(b, D.Group...)
+	b = append(b,	uint8(len(D.ModUser)),uint8(len(D.ModUser)>>8),
+	)
+	b = append(b, D.ModUser...)
+
+binary.LittleEndian.PutUint16(b[o:], uint16(len(b)-o-2))
+return b
+}
+
 func Marshaldir (b *bytes.Buffer, D Dir) {
+b.Reset()
+*b = *bytes.NewBuffer(Appenddir(b.Bytes(), D))
+}
+// Decodedir decodes a dir from the front of b, which starts with its size.
+func Decodedir (b []byte) (D Dir,  err error) {
 var l uint64
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for size: need 2, have %d", len(b))
+return
+}
+b = b[2:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
+	return
+	}
+	D.Type = uint16(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	D.Dev = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 1 {
+		err = fmt.Errorf("pkt too short for uint8: need 1, have %d", len(b))
+	return
+	}
+	D.QID.Type = uint8(b[0])
+	b = b[1:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	D.QID.Version = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	D.QID.Path = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	D.Mode = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	D.Atime = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	D.Mtime = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
+	return
+	}
+	D.Length = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
+	return
+	}
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
+	return
+	}
+	D.Name = string(b[:l])
+	b = b[l:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
+	return
+	}
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
+	return
+	}
+	D.User = string(b[:l])
+	b = b[l:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
+	return
+	}
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
+	return
+	}
+	D.Group = string(b[:l])
+	b = b[l:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
+	return
+	}
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
+	return
+	}
+	D.ModUser = string(b[:l])
+	b = b[l:]
+
+return
+}
+
+func Unmarshaldir (b *bytes.Buffer) (D Dir,  err error) {
+D,  err = Decodedir(b.Bytes())
 b.Reset()
-b.Write([]byte{0,0,})
-	b.Write([]byte{	uint8(D.Type>>0),
+return
+}
+// AppenddirDotu appends the encoding of a dir, with its size, to b.
+func AppenddirDotu (b []byte, D Dir) []byte {
+o := len(b)
+b = append(b, 0, 0)
+	b = append(b,	uint8(D.Type>>0),
 	uint8(D.Type>>8),
 	uint8(D.Dev>>0),
 	uint8(D.Dev>>8),
@@ -1787,149 +8839,188 @@ b.Write([]byte{0,0,})
 	uint8(D.Length>>48),
 	uint8(D.Length>>56),
 	uint8(len(D.Name)),uint8(len(D.Name)>>8),
-	})
-	b.Write([]byte(D.Name))
-	b.Write([]byte{	uint8(len(D.User)),uint8(len(D.User)>>8),
-	})
-	b.Write([]byte(D.User))
-	b.Write([]byte{	uint8(len(D.Group)),uint8(len(D.Group)>>8),
-	})
-	b.Write([]byte(D.Group))
-	b.Write([]byte{	uint8(len(D.ModUser)),uint8(len(D.ModUser)>>8),
-	})
-	b.Write([]byte(D.ModUser))
-
-l = uint64(b.Len()) - 2
-copy(b.Bytes(), []byte{uint8(l), uint8(l>>8)})
-return
+	)
+	b = append(b, D.Name...)
+	b = append(b,	uint8(len(D.User)),uint8(len(D.User)>>8),
+	)
+	b = append(b, D.User...)
+	b = append(b,	uint8(len(D.Group)),uint8(len(D.Group)>>8),
+	)
+	b = append(b, D.Group...)
+	b = append(b,	uint8(len(D.ModUser)),uint8(len(D.ModUser)>>8),
+	)
+	b = append(b, D.ModUser...)
+	b = append(b,	uint8(len(D.Extension)),uint8(len(D.Extension)>>8),
+	)
+	b = append(b, D.Extension...)
+	b = append(b,	uint8(D.UID>>0),
+	uint8(D.UID>>8),
+	uint8(D.UID>>16),
+	uint8(D.UID>>24),
+	uint8(D.GID>>0),
+	uint8(D.GID>>8),
+	uint8(D.GID>>16),
+	uint8(D.GID>>24),
+	uint8(D.MUID>>0),
+	uint8(D.MUID>>8),
+	uint8(D.MUID>>16),
+	uint8(D.MUID>>24),
+	)
+
+binary.LittleEndian.PutUint16(b[o:], uint16(len(b)-o-2))
+return b
 }
-func Unmarshaldir (b *bytes.Buffer) (D Dir,  err error) {
-var u [8]uint8
+
+func MarshaldirDotu (b *bytes.Buffer, D Dir) {
+b.Reset()
+*b = *bytes.NewBuffer(AppenddirDotu(b.Bytes(), D))
+}
+// DecodedirDotu decodes a dir from the front of b, which starts with its size.
+func DecodedirDotu (b []byte) (D Dir,  err error) {
 var l uint64
-_ = b.Next(2) // eat the length too
-	if _, err = b.Read(u[:2]); err != nil {
-		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", b.Len())
+if len(b) < 2 {
+err = fmt.Errorf("pkt too short for size: need 2, have %d", len(b))
+return
+}
+b = b[2:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
+	return
+	}
+	D.Type = uint16(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	D.Dev = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 1 {
+		err = fmt.Errorf("pkt too short for uint8: need 1, have %d", len(b))
+	return
+	}
+	D.QID.Type = uint8(b[0])
+	b = b[1:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
 	return
 	}
-	D.Type = uint16(u[0])
-	D.Type |= uint16(u[1])<<8
-	if _, err = b.Read(u[:4]); err != nil {
-		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", b.Len())
+	D.QID.Version = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
 	return
 	}
-	D.Dev = uint32(u[0])
-	D.Dev |= uint32(u[1])<<8
-	D.Dev |= uint32(u[2])<<16
-	D.Dev |= uint32(u[3])<<24
-	if _, err = b.Read(u[:1]); err != nil {
-		err = fmt.Errorf("pkt too short for uint8: need 1, have %d", b.Len())
+	D.QID.Path = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
 	return
 	}
-	D.QID.Type = uint8(u[0])
-	if _, err = b.Read(u[:4]); err != nil {
-		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", b.Len())
+	D.Mode = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
 	return
 	}
-	D.QID.Version = uint32(u[0])
-	D.QID.Version |= uint32(u[1])<<8
-	D.QID.Version |= uint32(u[2])<<16
-	D.QID.Version |= uint32(u[3])<<24
-	if _, err = b.Read(u[:8]); err != nil {
-		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", b.Len())
+	D.Atime = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
 	return
 	}
-	D.QID.Path = uint64(u[0])
-	D.QID.Path |= uint64(u[1])<<8
-	D.QID.Path |= uint64(u[2])<<16
-	D.QID.Path |= uint64(u[3])<<24
-	D.QID.Path |= uint64(u[4])<<32
-	D.QID.Path |= uint64(u[5])<<40
-	D.QID.Path |= uint64(u[6])<<48
-	D.QID.Path |= uint64(u[7])<<56
-	if _, err = b.Read(u[:4]); err != nil {
-		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", b.Len())
+	D.Mtime = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 8 {
+		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", len(b))
 	return
 	}
-	D.Mode = uint32(u[0])
-	D.Mode |= uint32(u[1])<<8
-	D.Mode |= uint32(u[2])<<16
-	D.Mode |= uint32(u[3])<<24
-	if _, err = b.Read(u[:4]); err != nil {
-		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", b.Len())
+	D.Length = uint64(binary.LittleEndian.Uint64(b))
+	b = b[8:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
 	return
 	}
-	D.Atime = uint32(u[0])
-	D.Atime |= uint32(u[1])<<8
-	D.Atime |= uint32(u[2])<<16
-	D.Atime |= uint32(u[3])<<24
-	if _, err = b.Read(u[:4]); err != nil {
-		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", b.Len())
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
 	return
 	}
-	D.Mtime = uint32(u[0])
-	D.Mtime |= uint32(u[1])<<8
-	D.Mtime |= uint32(u[2])<<16
-	D.Mtime |= uint32(u[3])<<24
-	if _, err = b.Read(u[:8]); err != nil {
-		err = fmt.Errorf("pkt too short for uint64: need 8, have %d", b.Len())
+	D.Name = string(b[:l])
+	b = b[l:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
 	return
 	}
-	D.Length = uint64(u[0])
-	D.Length |= uint64(u[1])<<8
-	D.Length |= uint64(u[2])<<16
-	D.Length |= uint64(u[3])<<24
-	D.Length |= uint64(u[4])<<32
-	D.Length |= uint64(u[5])<<40
-	D.Length |= uint64(u[6])<<48
-	D.Length |= uint64(u[7])<<56
-	if _, err = b.Read(u[:2]); err != nil {
-		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", b.Len())
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
 	return
 	}
-	l = uint64(u[0])
-	l |= uint64(u[1])<<8
-	if b.Len() < int(l) {
-		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, b.Len())
+	D.User = string(b[:l])
+	b = b[l:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
 	return
 	}
-	D.Name = string(b.Bytes()[:l])
-	_ = b.Next(int(l))
-	if _, err = b.Read(u[:2]); err != nil {
-		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", b.Len())
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
 	return
 	}
-	l = uint64(u[0])
-	l |= uint64(u[1])<<8
-	if b.Len() < int(l) {
-		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, b.Len())
+	D.Group = string(b[:l])
+	b = b[l:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
 	return
 	}
-	D.User = string(b.Bytes()[:l])
-	_ = b.Next(int(l))
-	if _, err = b.Read(u[:2]); err != nil {
-		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", b.Len())
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
 	return
 	}
-	l = uint64(u[0])
-	l |= uint64(u[1])<<8
-	if b.Len() < int(l) {
-		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, b.Len())
+	D.ModUser = string(b[:l])
+	b = b[l:]
+	if len(b) < 2 {
+		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", len(b))
 	return
 	}
-	D.Group = string(b.Bytes()[:l])
-	_ = b.Next(int(l))
-	if _, err = b.Read(u[:2]); err != nil {
-		err = fmt.Errorf("pkt too short for uint16: need 2, have %d", b.Len())
+	l = uint64(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if uint64(len(b)) < l {
+		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, len(b))
 	return
 	}
-	l = uint64(u[0])
-	l |= uint64(u[1])<<8
-	if b.Len() < int(l) {
-		err = fmt.Errorf("pkt too short for string: need %d, have %d", l, b.Len())
+	D.Extension = string(b[:l])
+	b = b[l:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
 	return
 	}
-	D.ModUser = string(b.Bytes()[:l])
-	_ = b.Next(int(l))
+	D.UID = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	D.GID = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < 4 {
+		err = fmt.Errorf("pkt too short for uint32: need 4, have %d", len(b))
+	return
+	}
+	D.MUID = uint32(binary.LittleEndian.Uint32(b))
+	b = b[4:]
 
 return
 }
+
+func UnmarshaldirDotu (b *bytes.Buffer) (D Dir,  err error) {
+D,  err = DecodedirDotu(b.Bytes())
+b.Reset()
+return
+}
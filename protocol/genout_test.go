@@ -0,0 +1,4288 @@
+
+package protocol
+import (
+"bytes"
+"fmt"
+"testing"
+)
+
+func BenchmarkRerror(b *testing.B) {
+	Error := "sample"
+	var m bytes.Buffer
+	MarshalRerrorPkt(&m, 1, Error)
+	pkt := m.Bytes()
+	want := fmt.Sprint(Error, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeRerrorPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeRerrorPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalRerrorPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalRerrorPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalRerrorPkt(&bb, 1, Error)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalRerrorPkt(&bb, 1, Error)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendRerrorPkt(bb[:0], 1, Error)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := bufferUnmarshalRerrorPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := UnmarshalRerrorPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := DecodeRerrorPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRerrorDotu(b *testing.B) {
+	Error := "sample"
+	Errno := ^uint32(0)
+	var m bytes.Buffer
+	MarshalRerrorDotuPkt(&m, 1, Error, Errno)
+	pkt := m.Bytes()
+	want := fmt.Sprint(Error, Errno, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeRerrorDotuPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeRerrorDotuPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalRerrorDotuPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalRerrorDotuPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalRerrorDotuPkt(&bb, 1, Error, Errno)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalRerrorDotuPkt(&bb, 1, Error, Errno)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendRerrorDotuPkt(bb[:0], 1, Error, Errno)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := bufferUnmarshalRerrorDotuPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := UnmarshalRerrorDotuPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := DecodeRerrorDotuPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRversion(b *testing.B) {
+	RMsize := ^MaxSize(0)
+	RVersion := "sample"
+	var m bytes.Buffer
+	MarshalRversionPkt(&m, 1, RMsize, RVersion)
+	pkt := m.Bytes()
+	want := fmt.Sprint(RMsize, RVersion, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeRversionPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeRversionPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalRversionPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalRversionPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalRversionPkt(&bb, 1, RMsize, RVersion)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalRversionPkt(&bb, 1, RMsize, RVersion)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendRversionPkt(bb[:0], 1, RMsize, RVersion)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := bufferUnmarshalRversionPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := UnmarshalRversionPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := DecodeRversionPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTversion(b *testing.B) {
+	TMsize := ^MaxSize(0)
+	TVersion := "sample"
+	var m bytes.Buffer
+	MarshalTversionPkt(&m, 1, TMsize, TVersion)
+	pkt := m.Bytes()
+	want := fmt.Sprint(TMsize, TVersion, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeTversionPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeTversionPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalTversionPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalTversionPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalTversionPkt(&bb, 1, TMsize, TVersion)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalTversionPkt(&bb, 1, TMsize, TVersion)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendTversionPkt(bb[:0], 1, TMsize, TVersion)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := bufferUnmarshalTversionPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := UnmarshalTversionPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := DecodeTversionPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRauth(b *testing.B) {
+	AQID := QID{Type: ^uint8(0), Version: ^uint32(0), Path: ^uint64(0)}
+	var m bytes.Buffer
+	MarshalRauthPkt(&m, 1, AQID)
+	pkt := m.Bytes()
+	want := fmt.Sprint(AQID, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeRauthPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeRauthPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalRauthPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalRauthPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalRauthPkt(&bb, 1, AQID)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalRauthPkt(&bb, 1, AQID)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendRauthPkt(bb[:0], 1, AQID)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := bufferUnmarshalRauthPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := UnmarshalRauthPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := DecodeRauthPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTauth(b *testing.B) {
+	AFID := ^FID(0)
+	Uname := "sample"
+	Aname := "sample"
+	var m bytes.Buffer
+	MarshalTauthPkt(&m, 1, AFID, Uname, Aname)
+	pkt := m.Bytes()
+	want := fmt.Sprint(AFID, Uname, Aname, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeTauthPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeTauthPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalTauthPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalTauthPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalTauthPkt(&bb, 1, AFID, Uname, Aname)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalTauthPkt(&bb, 1, AFID, Uname, Aname)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendTauthPkt(bb[:0], 1, AFID, Uname, Aname)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, err := bufferUnmarshalTauthPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, err := UnmarshalTauthPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, err := DecodeTauthPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTauthDotu(b *testing.B) {
+	AFID := ^FID(0)
+	Uname := "sample"
+	Aname := "sample"
+	NUname := ^uint32(0)
+	var m bytes.Buffer
+	MarshalTauthDotuPkt(&m, 1, AFID, Uname, Aname, NUname)
+	pkt := m.Bytes()
+	want := fmt.Sprint(AFID, Uname, Aname, NUname, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeTauthDotuPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeTauthDotuPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalTauthDotuPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalTauthDotuPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalTauthDotuPkt(&bb, 1, AFID, Uname, Aname, NUname)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalTauthDotuPkt(&bb, 1, AFID, Uname, Aname, NUname)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendTauthDotuPkt(bb[:0], 1, AFID, Uname, Aname, NUname)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, err := bufferUnmarshalTauthDotuPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, err := UnmarshalTauthDotuPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, err := DecodeTauthDotuPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRattach(b *testing.B) {
+	QID := QID{Type: ^uint8(0), Version: ^uint32(0), Path: ^uint64(0)}
+	var m bytes.Buffer
+	MarshalRattachPkt(&m, 1, QID)
+	pkt := m.Bytes()
+	want := fmt.Sprint(QID, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeRattachPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeRattachPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalRattachPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalRattachPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalRattachPkt(&bb, 1, QID)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalRattachPkt(&bb, 1, QID)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendRattachPkt(bb[:0], 1, QID)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := bufferUnmarshalRattachPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := UnmarshalRattachPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := DecodeRattachPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTattach(b *testing.B) {
+	SFID := ^FID(0)
+	AFID := ^FID(0)
+	Uname := "sample"
+	Aname := "sample"
+	var m bytes.Buffer
+	MarshalTattachPkt(&m, 1, SFID, AFID, Uname, Aname)
+	pkt := m.Bytes()
+	want := fmt.Sprint(SFID, AFID, Uname, Aname, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeTattachPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeTattachPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalTattachPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalTattachPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalTattachPkt(&bb, 1, SFID, AFID, Uname, Aname)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalTattachPkt(&bb, 1, SFID, AFID, Uname, Aname)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendTattachPkt(bb[:0], 1, SFID, AFID, Uname, Aname)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, err := bufferUnmarshalTattachPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, err := UnmarshalTattachPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, err := DecodeTattachPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTattachDotu(b *testing.B) {
+	SFID := ^FID(0)
+	AFID := ^FID(0)
+	Uname := "sample"
+	Aname := "sample"
+	NUname := ^uint32(0)
+	var m bytes.Buffer
+	MarshalTattachDotuPkt(&m, 1, SFID, AFID, Uname, Aname, NUname)
+	pkt := m.Bytes()
+	want := fmt.Sprint(SFID, AFID, Uname, Aname, NUname, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeTattachDotuPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeTattachDotuPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalTattachDotuPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalTattachDotuPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalTattachDotuPkt(&bb, 1, SFID, AFID, Uname, Aname, NUname)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalTattachDotuPkt(&bb, 1, SFID, AFID, Uname, Aname, NUname)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendTattachDotuPkt(bb[:0], 1, SFID, AFID, Uname, Aname, NUname)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, _, err := bufferUnmarshalTattachDotuPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, _, err := UnmarshalTattachDotuPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, _, err := DecodeTattachDotuPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRflush(b *testing.B) {
+	var m bytes.Buffer
+	MarshalRflushPkt(&m, 1, )
+	pkt := m.Bytes()
+	want := fmt.Sprint(Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeRflushPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeRflushPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalRflushPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalRflushPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalRflushPkt(&bb, 1, )
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalRflushPkt(&bb, 1, )
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendRflushPkt(bb[:0], 1, )
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := bufferUnmarshalRflushPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := UnmarshalRflushPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := DecodeRflushPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTflush(b *testing.B) {
+	OTag := ^Tag(0)
+	var m bytes.Buffer
+	MarshalTflushPkt(&m, 1, OTag)
+	pkt := m.Bytes()
+	want := fmt.Sprint(OTag, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeTflushPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeTflushPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalTflushPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalTflushPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalTflushPkt(&bb, 1, OTag)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalTflushPkt(&bb, 1, OTag)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendTflushPkt(bb[:0], 1, OTag)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := bufferUnmarshalTflushPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := UnmarshalTflushPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := DecodeTflushPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRwalk(b *testing.B) {
+	QIDs := []QID{QID{Type: ^uint8(0), Version: ^uint32(0), Path: ^uint64(0)}, QID{Type: ^uint8(0), Version: ^uint32(0), Path: ^uint64(0)}}
+	var m bytes.Buffer
+	MarshalRwalkPkt(&m, 1, QIDs)
+	pkt := m.Bytes()
+	want := fmt.Sprint(QIDs, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeRwalkPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeRwalkPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalRwalkPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalRwalkPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalRwalkPkt(&bb, 1, QIDs)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalRwalkPkt(&bb, 1, QIDs)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendRwalkPkt(bb[:0], 1, QIDs)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := bufferUnmarshalRwalkPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := UnmarshalRwalkPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := DecodeRwalkPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTwalk(b *testing.B) {
+	SFID := ^FID(0)
+	NewFID := ^FID(0)
+	Paths := []string{"a", "sample"}
+	var m bytes.Buffer
+	MarshalTwalkPkt(&m, 1, SFID, NewFID, Paths)
+	pkt := m.Bytes()
+	want := fmt.Sprint(SFID, NewFID, Paths, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeTwalkPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeTwalkPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalTwalkPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalTwalkPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalTwalkPkt(&bb, 1, SFID, NewFID, Paths)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalTwalkPkt(&bb, 1, SFID, NewFID, Paths)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendTwalkPkt(bb[:0], 1, SFID, NewFID, Paths)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, err := bufferUnmarshalTwalkPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, err := UnmarshalTwalkPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, err := DecodeTwalkPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRopen(b *testing.B) {
+	OQID := QID{Type: ^uint8(0), Version: ^uint32(0), Path: ^uint64(0)}
+	IOUnit := ^MaxSize(0)
+	var m bytes.Buffer
+	MarshalRopenPkt(&m, 1, OQID, IOUnit)
+	pkt := m.Bytes()
+	want := fmt.Sprint(OQID, IOUnit, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeRopenPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeRopenPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalRopenPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalRopenPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalRopenPkt(&bb, 1, OQID, IOUnit)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalRopenPkt(&bb, 1, OQID, IOUnit)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendRopenPkt(bb[:0], 1, OQID, IOUnit)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := bufferUnmarshalRopenPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := UnmarshalRopenPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := DecodeRopenPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTopen(b *testing.B) {
+	OFID := ^FID(0)
+	Omode := ^Mode(0)
+	var m bytes.Buffer
+	MarshalTopenPkt(&m, 1, OFID, Omode)
+	pkt := m.Bytes()
+	want := fmt.Sprint(OFID, Omode, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeTopenPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeTopenPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalTopenPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalTopenPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalTopenPkt(&bb, 1, OFID, Omode)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalTopenPkt(&bb, 1, OFID, Omode)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendTopenPkt(bb[:0], 1, OFID, Omode)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := bufferUnmarshalTopenPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := UnmarshalTopenPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := DecodeTopenPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRcreate(b *testing.B) {
+	OQID := QID{Type: ^uint8(0), Version: ^uint32(0), Path: ^uint64(0)}
+	IOUnit := ^MaxSize(0)
+	var m bytes.Buffer
+	MarshalRcreatePkt(&m, 1, OQID, IOUnit)
+	pkt := m.Bytes()
+	want := fmt.Sprint(OQID, IOUnit, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeRcreatePkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeRcreatePkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalRcreatePkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalRcreatePkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalRcreatePkt(&bb, 1, OQID, IOUnit)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalRcreatePkt(&bb, 1, OQID, IOUnit)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendRcreatePkt(bb[:0], 1, OQID, IOUnit)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := bufferUnmarshalRcreatePkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := UnmarshalRcreatePkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := DecodeRcreatePkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTcreate(b *testing.B) {
+	OFID := ^FID(0)
+	Name := "sample"
+	CreatePerm := ^Perm(0)
+	Omode := ^Mode(0)
+	var m bytes.Buffer
+	MarshalTcreatePkt(&m, 1, OFID, Name, CreatePerm, Omode)
+	pkt := m.Bytes()
+	want := fmt.Sprint(OFID, Name, CreatePerm, Omode, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeTcreatePkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeTcreatePkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalTcreatePkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalTcreatePkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalTcreatePkt(&bb, 1, OFID, Name, CreatePerm, Omode)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalTcreatePkt(&bb, 1, OFID, Name, CreatePerm, Omode)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendTcreatePkt(bb[:0], 1, OFID, Name, CreatePerm, Omode)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, err := bufferUnmarshalTcreatePkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, err := UnmarshalTcreatePkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, err := DecodeTcreatePkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTcreateDotu(b *testing.B) {
+	OFID := ^FID(0)
+	Name := "sample"
+	CreatePerm := ^Perm(0)
+	Omode := ^Mode(0)
+	Extension := "sample"
+	var m bytes.Buffer
+	MarshalTcreateDotuPkt(&m, 1, OFID, Name, CreatePerm, Omode, Extension)
+	pkt := m.Bytes()
+	want := fmt.Sprint(OFID, Name, CreatePerm, Omode, Extension, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeTcreateDotuPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeTcreateDotuPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalTcreateDotuPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalTcreateDotuPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalTcreateDotuPkt(&bb, 1, OFID, Name, CreatePerm, Omode, Extension)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalTcreateDotuPkt(&bb, 1, OFID, Name, CreatePerm, Omode, Extension)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendTcreateDotuPkt(bb[:0], 1, OFID, Name, CreatePerm, Omode, Extension)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, _, err := bufferUnmarshalTcreateDotuPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, _, err := UnmarshalTcreateDotuPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, _, err := DecodeTcreateDotuPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRstat(b *testing.B) {
+	B := make([]byte, 1024)
+	var m bytes.Buffer
+	MarshalRstatPkt(&m, 1, B)
+	pkt := m.Bytes()
+	want := fmt.Sprint(B, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeRstatPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeRstatPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalRstatPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalRstatPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalRstatPkt(&bb, 1, B)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalRstatPkt(&bb, 1, B)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendRstatPkt(bb[:0], 1, B)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := bufferUnmarshalRstatPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := UnmarshalRstatPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := DecodeRstatPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTstat(b *testing.B) {
+	OFID := ^FID(0)
+	var m bytes.Buffer
+	MarshalTstatPkt(&m, 1, OFID)
+	pkt := m.Bytes()
+	want := fmt.Sprint(OFID, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeTstatPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeTstatPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalTstatPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalTstatPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalTstatPkt(&bb, 1, OFID)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalTstatPkt(&bb, 1, OFID)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendTstatPkt(bb[:0], 1, OFID)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := bufferUnmarshalTstatPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := UnmarshalTstatPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := DecodeTstatPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRwstat(b *testing.B) {
+	var m bytes.Buffer
+	MarshalRwstatPkt(&m, 1, )
+	pkt := m.Bytes()
+	want := fmt.Sprint(Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeRwstatPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeRwstatPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalRwstatPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalRwstatPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalRwstatPkt(&bb, 1, )
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalRwstatPkt(&bb, 1, )
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendRwstatPkt(bb[:0], 1, )
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := bufferUnmarshalRwstatPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := UnmarshalRwstatPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := DecodeRwstatPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTwstat(b *testing.B) {
+	OFID := ^FID(0)
+	B := make([]byte, 1024)
+	var m bytes.Buffer
+	MarshalTwstatPkt(&m, 1, OFID, B)
+	pkt := m.Bytes()
+	want := fmt.Sprint(OFID, B, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeTwstatPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeTwstatPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalTwstatPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalTwstatPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalTwstatPkt(&bb, 1, OFID, B)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalTwstatPkt(&bb, 1, OFID, B)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendTwstatPkt(bb[:0], 1, OFID, B)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := bufferUnmarshalTwstatPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := UnmarshalTwstatPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := DecodeTwstatPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRclunk(b *testing.B) {
+	var m bytes.Buffer
+	MarshalRclunkPkt(&m, 1, )
+	pkt := m.Bytes()
+	want := fmt.Sprint(Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeRclunkPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeRclunkPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalRclunkPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalRclunkPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalRclunkPkt(&bb, 1, )
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalRclunkPkt(&bb, 1, )
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendRclunkPkt(bb[:0], 1, )
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := bufferUnmarshalRclunkPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := UnmarshalRclunkPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := DecodeRclunkPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTclunk(b *testing.B) {
+	OFID := ^FID(0)
+	var m bytes.Buffer
+	MarshalTclunkPkt(&m, 1, OFID)
+	pkt := m.Bytes()
+	want := fmt.Sprint(OFID, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeTclunkPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeTclunkPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalTclunkPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalTclunkPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalTclunkPkt(&bb, 1, OFID)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalTclunkPkt(&bb, 1, OFID)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendTclunkPkt(bb[:0], 1, OFID)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := bufferUnmarshalTclunkPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := UnmarshalTclunkPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := DecodeTclunkPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRremove(b *testing.B) {
+	var m bytes.Buffer
+	MarshalRremovePkt(&m, 1, )
+	pkt := m.Bytes()
+	want := fmt.Sprint(Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeRremovePkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeRremovePkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalRremovePkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalRremovePkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalRremovePkt(&bb, 1, )
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalRremovePkt(&bb, 1, )
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendRremovePkt(bb[:0], 1, )
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := bufferUnmarshalRremovePkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := UnmarshalRremovePkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := DecodeRremovePkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTremove(b *testing.B) {
+	OFID := ^FID(0)
+	var m bytes.Buffer
+	MarshalTremovePkt(&m, 1, OFID)
+	pkt := m.Bytes()
+	want := fmt.Sprint(OFID, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeTremovePkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeTremovePkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalTremovePkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalTremovePkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalTremovePkt(&bb, 1, OFID)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalTremovePkt(&bb, 1, OFID)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendTremovePkt(bb[:0], 1, OFID)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := bufferUnmarshalTremovePkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := UnmarshalTremovePkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := DecodeTremovePkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRread(b *testing.B) {
+	Data := make([]byte, 1024)
+	var m bytes.Buffer
+	MarshalRreadPkt(&m, 1, Data)
+	pkt := m.Bytes()
+	want := fmt.Sprint(Data, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeRreadPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeRreadPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalRreadPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalRreadPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalRreadPkt(&bb, 1, Data)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalRreadPkt(&bb, 1, Data)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendRreadPkt(bb[:0], 1, Data)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := bufferUnmarshalRreadPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := UnmarshalRreadPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := DecodeRreadPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTread(b *testing.B) {
+	OFID := ^FID(0)
+	Off := ^Offset(0)
+	Len := ^Count(0)
+	var m bytes.Buffer
+	MarshalTreadPkt(&m, 1, OFID, Off, Len)
+	pkt := m.Bytes()
+	want := fmt.Sprint(OFID, Off, Len, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeTreadPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeTreadPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalTreadPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalTreadPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalTreadPkt(&bb, 1, OFID, Off, Len)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalTreadPkt(&bb, 1, OFID, Off, Len)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendTreadPkt(bb[:0], 1, OFID, Off, Len)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, err := bufferUnmarshalTreadPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, err := UnmarshalTreadPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, err := DecodeTreadPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRwrite(b *testing.B) {
+	RLen := ^Count(0)
+	var m bytes.Buffer
+	MarshalRwritePkt(&m, 1, RLen)
+	pkt := m.Bytes()
+	want := fmt.Sprint(RLen, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeRwritePkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeRwritePkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalRwritePkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalRwritePkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalRwritePkt(&bb, 1, RLen)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalRwritePkt(&bb, 1, RLen)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendRwritePkt(bb[:0], 1, RLen)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := bufferUnmarshalRwritePkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := UnmarshalRwritePkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := DecodeRwritePkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTwrite(b *testing.B) {
+	OFID := ^FID(0)
+	Off := ^Offset(0)
+	Data := make([]byte, 1024)
+	var m bytes.Buffer
+	MarshalTwritePkt(&m, 1, OFID, Off, Data)
+	pkt := m.Bytes()
+	want := fmt.Sprint(OFID, Off, Data, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeTwritePkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeTwritePkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalTwritePkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalTwritePkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalTwritePkt(&bb, 1, OFID, Off, Data)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalTwritePkt(&bb, 1, OFID, Off, Data)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendTwritePkt(bb[:0], 1, OFID, Off, Data)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, err := bufferUnmarshalTwritePkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, err := UnmarshalTwritePkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, err := DecodeTwritePkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRlerror(b *testing.B) {
+	Ecode := ^uint32(0)
+	var m bytes.Buffer
+	MarshalRlerrorPkt(&m, 1, Ecode)
+	pkt := m.Bytes()
+	want := fmt.Sprint(Ecode, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeRlerrorPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeRlerrorPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalRlerrorPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalRlerrorPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalRlerrorPkt(&bb, 1, Ecode)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalRlerrorPkt(&bb, 1, Ecode)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendRlerrorPkt(bb[:0], 1, Ecode)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := bufferUnmarshalRlerrorPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := UnmarshalRlerrorPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := DecodeRlerrorPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRstatfs(b *testing.B) {
+	StatFS := StatFS{Type: ^uint32(0), BSize: ^uint32(0), Blocks: ^uint64(0), BFree: ^uint64(0), BAvail: ^uint64(0), Files: ^uint64(0), FFree: ^uint64(0), FSID: ^uint64(0), NameLen: ^uint32(0)}
+	var m bytes.Buffer
+	MarshalRstatfsPkt(&m, 1, StatFS)
+	pkt := m.Bytes()
+	want := fmt.Sprint(StatFS, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeRstatfsPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeRstatfsPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalRstatfsPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalRstatfsPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalRstatfsPkt(&bb, 1, StatFS)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalRstatfsPkt(&bb, 1, StatFS)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendRstatfsPkt(bb[:0], 1, StatFS)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := bufferUnmarshalRstatfsPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := UnmarshalRstatfsPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := DecodeRstatfsPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTstatfs(b *testing.B) {
+	OFID := ^FID(0)
+	var m bytes.Buffer
+	MarshalTstatfsPkt(&m, 1, OFID)
+	pkt := m.Bytes()
+	want := fmt.Sprint(OFID, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeTstatfsPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeTstatfsPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalTstatfsPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalTstatfsPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalTstatfsPkt(&bb, 1, OFID)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalTstatfsPkt(&bb, 1, OFID)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendTstatfsPkt(bb[:0], 1, OFID)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := bufferUnmarshalTstatfsPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := UnmarshalTstatfsPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := DecodeTstatfsPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRlopen(b *testing.B) {
+	OQID := QID{Type: ^uint8(0), Version: ^uint32(0), Path: ^uint64(0)}
+	IOUnit := ^MaxSize(0)
+	var m bytes.Buffer
+	MarshalRlopenPkt(&m, 1, OQID, IOUnit)
+	pkt := m.Bytes()
+	want := fmt.Sprint(OQID, IOUnit, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeRlopenPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeRlopenPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalRlopenPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalRlopenPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalRlopenPkt(&bb, 1, OQID, IOUnit)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalRlopenPkt(&bb, 1, OQID, IOUnit)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendRlopenPkt(bb[:0], 1, OQID, IOUnit)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := bufferUnmarshalRlopenPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := UnmarshalRlopenPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := DecodeRlopenPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTlopen(b *testing.B) {
+	OFID := ^FID(0)
+	LFlags := ^uint32(0)
+	var m bytes.Buffer
+	MarshalTlopenPkt(&m, 1, OFID, LFlags)
+	pkt := m.Bytes()
+	want := fmt.Sprint(OFID, LFlags, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeTlopenPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeTlopenPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalTlopenPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalTlopenPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalTlopenPkt(&bb, 1, OFID, LFlags)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalTlopenPkt(&bb, 1, OFID, LFlags)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendTlopenPkt(bb[:0], 1, OFID, LFlags)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := bufferUnmarshalTlopenPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := UnmarshalTlopenPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := DecodeTlopenPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRlcreate(b *testing.B) {
+	OQID := QID{Type: ^uint8(0), Version: ^uint32(0), Path: ^uint64(0)}
+	IOUnit := ^MaxSize(0)
+	var m bytes.Buffer
+	MarshalRlcreatePkt(&m, 1, OQID, IOUnit)
+	pkt := m.Bytes()
+	want := fmt.Sprint(OQID, IOUnit, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeRlcreatePkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeRlcreatePkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalRlcreatePkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalRlcreatePkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalRlcreatePkt(&bb, 1, OQID, IOUnit)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalRlcreatePkt(&bb, 1, OQID, IOUnit)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendRlcreatePkt(bb[:0], 1, OQID, IOUnit)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := bufferUnmarshalRlcreatePkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := UnmarshalRlcreatePkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := DecodeRlcreatePkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTlcreate(b *testing.B) {
+	OFID := ^FID(0)
+	Name := "sample"
+	LFlags := ^uint32(0)
+	CreateMode := ^uint32(0)
+	GID := ^uint32(0)
+	var m bytes.Buffer
+	MarshalTlcreatePkt(&m, 1, OFID, Name, LFlags, CreateMode, GID)
+	pkt := m.Bytes()
+	want := fmt.Sprint(OFID, Name, LFlags, CreateMode, GID, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeTlcreatePkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeTlcreatePkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalTlcreatePkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalTlcreatePkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalTlcreatePkt(&bb, 1, OFID, Name, LFlags, CreateMode, GID)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalTlcreatePkt(&bb, 1, OFID, Name, LFlags, CreateMode, GID)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendTlcreatePkt(bb[:0], 1, OFID, Name, LFlags, CreateMode, GID)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, _, err := bufferUnmarshalTlcreatePkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, _, err := UnmarshalTlcreatePkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, _, err := DecodeTlcreatePkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRsymlink(b *testing.B) {
+	OQID := QID{Type: ^uint8(0), Version: ^uint32(0), Path: ^uint64(0)}
+	var m bytes.Buffer
+	MarshalRsymlinkPkt(&m, 1, OQID)
+	pkt := m.Bytes()
+	want := fmt.Sprint(OQID, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeRsymlinkPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeRsymlinkPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalRsymlinkPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalRsymlinkPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalRsymlinkPkt(&bb, 1, OQID)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalRsymlinkPkt(&bb, 1, OQID)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendRsymlinkPkt(bb[:0], 1, OQID)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := bufferUnmarshalRsymlinkPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := UnmarshalRsymlinkPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := DecodeRsymlinkPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTsymlink(b *testing.B) {
+	OFID := ^FID(0)
+	Name := "sample"
+	Target := "sample"
+	GID := ^uint32(0)
+	var m bytes.Buffer
+	MarshalTsymlinkPkt(&m, 1, OFID, Name, Target, GID)
+	pkt := m.Bytes()
+	want := fmt.Sprint(OFID, Name, Target, GID, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeTsymlinkPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeTsymlinkPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalTsymlinkPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalTsymlinkPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalTsymlinkPkt(&bb, 1, OFID, Name, Target, GID)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalTsymlinkPkt(&bb, 1, OFID, Name, Target, GID)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendTsymlinkPkt(bb[:0], 1, OFID, Name, Target, GID)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, err := bufferUnmarshalTsymlinkPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, err := UnmarshalTsymlinkPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, err := DecodeTsymlinkPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRmknod(b *testing.B) {
+	OQID := QID{Type: ^uint8(0), Version: ^uint32(0), Path: ^uint64(0)}
+	var m bytes.Buffer
+	MarshalRmknodPkt(&m, 1, OQID)
+	pkt := m.Bytes()
+	want := fmt.Sprint(OQID, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeRmknodPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeRmknodPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalRmknodPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalRmknodPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalRmknodPkt(&bb, 1, OQID)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalRmknodPkt(&bb, 1, OQID)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendRmknodPkt(bb[:0], 1, OQID)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := bufferUnmarshalRmknodPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := UnmarshalRmknodPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := DecodeRmknodPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTmknod(b *testing.B) {
+	DFID := ^FID(0)
+	Name := "sample"
+	CreateMode := ^uint32(0)
+	Major := ^uint32(0)
+	Minor := ^uint32(0)
+	GID := ^uint32(0)
+	var m bytes.Buffer
+	MarshalTmknodPkt(&m, 1, DFID, Name, CreateMode, Major, Minor, GID)
+	pkt := m.Bytes()
+	want := fmt.Sprint(DFID, Name, CreateMode, Major, Minor, GID, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeTmknodPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeTmknodPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalTmknodPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalTmknodPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalTmknodPkt(&bb, 1, DFID, Name, CreateMode, Major, Minor, GID)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalTmknodPkt(&bb, 1, DFID, Name, CreateMode, Major, Minor, GID)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendTmknodPkt(bb[:0], 1, DFID, Name, CreateMode, Major, Minor, GID)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, _, _, err := bufferUnmarshalTmknodPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, _, _, err := UnmarshalTmknodPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, _, _, err := DecodeTmknodPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRrename(b *testing.B) {
+	var m bytes.Buffer
+	MarshalRrenamePkt(&m, 1, )
+	pkt := m.Bytes()
+	want := fmt.Sprint(Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeRrenamePkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeRrenamePkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalRrenamePkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalRrenamePkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalRrenamePkt(&bb, 1, )
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalRrenamePkt(&bb, 1, )
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendRrenamePkt(bb[:0], 1, )
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := bufferUnmarshalRrenamePkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := UnmarshalRrenamePkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := DecodeRrenamePkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTrename(b *testing.B) {
+	OFID := ^FID(0)
+	DFID := ^FID(0)
+	Name := "sample"
+	var m bytes.Buffer
+	MarshalTrenamePkt(&m, 1, OFID, DFID, Name)
+	pkt := m.Bytes()
+	want := fmt.Sprint(OFID, DFID, Name, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeTrenamePkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeTrenamePkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalTrenamePkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalTrenamePkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalTrenamePkt(&bb, 1, OFID, DFID, Name)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalTrenamePkt(&bb, 1, OFID, DFID, Name)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendTrenamePkt(bb[:0], 1, OFID, DFID, Name)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, err := bufferUnmarshalTrenamePkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, err := UnmarshalTrenamePkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, err := DecodeTrenamePkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRreadlink(b *testing.B) {
+	Target := "sample"
+	var m bytes.Buffer
+	MarshalRreadlinkPkt(&m, 1, Target)
+	pkt := m.Bytes()
+	want := fmt.Sprint(Target, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeRreadlinkPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeRreadlinkPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalRreadlinkPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalRreadlinkPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalRreadlinkPkt(&bb, 1, Target)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalRreadlinkPkt(&bb, 1, Target)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendRreadlinkPkt(bb[:0], 1, Target)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := bufferUnmarshalRreadlinkPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := UnmarshalRreadlinkPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := DecodeRreadlinkPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTreadlink(b *testing.B) {
+	OFID := ^FID(0)
+	var m bytes.Buffer
+	MarshalTreadlinkPkt(&m, 1, OFID)
+	pkt := m.Bytes()
+	want := fmt.Sprint(OFID, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeTreadlinkPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeTreadlinkPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalTreadlinkPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalTreadlinkPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalTreadlinkPkt(&bb, 1, OFID)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalTreadlinkPkt(&bb, 1, OFID)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendTreadlinkPkt(bb[:0], 1, OFID)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := bufferUnmarshalTreadlinkPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := UnmarshalTreadlinkPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := DecodeTreadlinkPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRgetattr(b *testing.B) {
+	Attr := Attr{Valid: ^uint64(0), QID: QID{Type: ^uint8(0), Version: ^uint32(0), Path: ^uint64(0)}, Mode: ^uint32(0), UID: ^uint32(0), GID: ^uint32(0), NLink: ^uint64(0), RDev: ^uint64(0), Size: ^uint64(0), BlkSize: ^uint64(0), Blocks: ^uint64(0), ATimeSec: ^uint64(0), ATimeNSec: ^uint64(0), MTimeSec: ^uint64(0), MTimeNSec: ^uint64(0), CTimeSec: ^uint64(0), CTimeNSec: ^uint64(0), BTimeSec: ^uint64(0), BTimeNSec: ^uint64(0), Gen: ^uint64(0), DataVersion: ^uint64(0)}
+	var m bytes.Buffer
+	MarshalRgetattrPkt(&m, 1, Attr)
+	pkt := m.Bytes()
+	want := fmt.Sprint(Attr, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeRgetattrPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeRgetattrPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalRgetattrPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalRgetattrPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalRgetattrPkt(&bb, 1, Attr)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalRgetattrPkt(&bb, 1, Attr)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendRgetattrPkt(bb[:0], 1, Attr)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := bufferUnmarshalRgetattrPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := UnmarshalRgetattrPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := DecodeRgetattrPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTgetattr(b *testing.B) {
+	OFID := ^FID(0)
+	Mask := ^uint64(0)
+	var m bytes.Buffer
+	MarshalTgetattrPkt(&m, 1, OFID, Mask)
+	pkt := m.Bytes()
+	want := fmt.Sprint(OFID, Mask, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeTgetattrPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeTgetattrPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalTgetattrPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalTgetattrPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalTgetattrPkt(&bb, 1, OFID, Mask)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalTgetattrPkt(&bb, 1, OFID, Mask)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendTgetattrPkt(bb[:0], 1, OFID, Mask)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := bufferUnmarshalTgetattrPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := UnmarshalTgetattrPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := DecodeTgetattrPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRsetattr(b *testing.B) {
+	var m bytes.Buffer
+	MarshalRsetattrPkt(&m, 1, )
+	pkt := m.Bytes()
+	want := fmt.Sprint(Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeRsetattrPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeRsetattrPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalRsetattrPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalRsetattrPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalRsetattrPkt(&bb, 1, )
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalRsetattrPkt(&bb, 1, )
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendRsetattrPkt(bb[:0], 1, )
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := bufferUnmarshalRsetattrPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := UnmarshalRsetattrPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := DecodeRsetattrPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTsetattr(b *testing.B) {
+	OFID := ^FID(0)
+	SetAttr := SetAttr{Valid: ^uint32(0), Mode: ^uint32(0), UID: ^uint32(0), GID: ^uint32(0), Size: ^uint64(0), ATimeSec: ^uint64(0), ATimeNSec: ^uint64(0), MTimeSec: ^uint64(0), MTimeNSec: ^uint64(0)}
+	var m bytes.Buffer
+	MarshalTsetattrPkt(&m, 1, OFID, SetAttr)
+	pkt := m.Bytes()
+	want := fmt.Sprint(OFID, SetAttr, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeTsetattrPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeTsetattrPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalTsetattrPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalTsetattrPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalTsetattrPkt(&bb, 1, OFID, SetAttr)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalTsetattrPkt(&bb, 1, OFID, SetAttr)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendTsetattrPkt(bb[:0], 1, OFID, SetAttr)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := bufferUnmarshalTsetattrPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := UnmarshalTsetattrPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := DecodeTsetattrPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRxattrwalk(b *testing.B) {
+	Size := ^uint64(0)
+	var m bytes.Buffer
+	MarshalRxattrwalkPkt(&m, 1, Size)
+	pkt := m.Bytes()
+	want := fmt.Sprint(Size, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeRxattrwalkPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeRxattrwalkPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalRxattrwalkPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalRxattrwalkPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalRxattrwalkPkt(&bb, 1, Size)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalRxattrwalkPkt(&bb, 1, Size)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendRxattrwalkPkt(bb[:0], 1, Size)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := bufferUnmarshalRxattrwalkPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := UnmarshalRxattrwalkPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := DecodeRxattrwalkPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTxattrwalk(b *testing.B) {
+	OFID := ^FID(0)
+	NewFID := ^FID(0)
+	Name := "sample"
+	var m bytes.Buffer
+	MarshalTxattrwalkPkt(&m, 1, OFID, NewFID, Name)
+	pkt := m.Bytes()
+	want := fmt.Sprint(OFID, NewFID, Name, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeTxattrwalkPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeTxattrwalkPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalTxattrwalkPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalTxattrwalkPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalTxattrwalkPkt(&bb, 1, OFID, NewFID, Name)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalTxattrwalkPkt(&bb, 1, OFID, NewFID, Name)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendTxattrwalkPkt(bb[:0], 1, OFID, NewFID, Name)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, err := bufferUnmarshalTxattrwalkPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, err := UnmarshalTxattrwalkPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, err := DecodeTxattrwalkPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRxattrcreate(b *testing.B) {
+	var m bytes.Buffer
+	MarshalRxattrcreatePkt(&m, 1, )
+	pkt := m.Bytes()
+	want := fmt.Sprint(Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeRxattrcreatePkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeRxattrcreatePkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalRxattrcreatePkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalRxattrcreatePkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalRxattrcreatePkt(&bb, 1, )
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalRxattrcreatePkt(&bb, 1, )
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendRxattrcreatePkt(bb[:0], 1, )
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := bufferUnmarshalRxattrcreatePkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := UnmarshalRxattrcreatePkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := DecodeRxattrcreatePkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTxattrcreate(b *testing.B) {
+	OFID := ^FID(0)
+	Name := "sample"
+	AttrSize := ^uint64(0)
+	XFlags := ^uint32(0)
+	var m bytes.Buffer
+	MarshalTxattrcreatePkt(&m, 1, OFID, Name, AttrSize, XFlags)
+	pkt := m.Bytes()
+	want := fmt.Sprint(OFID, Name, AttrSize, XFlags, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeTxattrcreatePkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeTxattrcreatePkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalTxattrcreatePkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalTxattrcreatePkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalTxattrcreatePkt(&bb, 1, OFID, Name, AttrSize, XFlags)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalTxattrcreatePkt(&bb, 1, OFID, Name, AttrSize, XFlags)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendTxattrcreatePkt(bb[:0], 1, OFID, Name, AttrSize, XFlags)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, err := bufferUnmarshalTxattrcreatePkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, err := UnmarshalTxattrcreatePkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, err := DecodeTxattrcreatePkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRreaddir(b *testing.B) {
+	Data := make([]byte, 1024)
+	var m bytes.Buffer
+	MarshalRreaddirPkt(&m, 1, Data)
+	pkt := m.Bytes()
+	want := fmt.Sprint(Data, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeRreaddirPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeRreaddirPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalRreaddirPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalRreaddirPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalRreaddirPkt(&bb, 1, Data)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalRreaddirPkt(&bb, 1, Data)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendRreaddirPkt(bb[:0], 1, Data)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := bufferUnmarshalRreaddirPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := UnmarshalRreaddirPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := DecodeRreaddirPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTreaddir(b *testing.B) {
+	OFID := ^FID(0)
+	Off := ^Offset(0)
+	Len := ^Count(0)
+	var m bytes.Buffer
+	MarshalTreaddirPkt(&m, 1, OFID, Off, Len)
+	pkt := m.Bytes()
+	want := fmt.Sprint(OFID, Off, Len, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeTreaddirPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeTreaddirPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalTreaddirPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalTreaddirPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalTreaddirPkt(&bb, 1, OFID, Off, Len)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalTreaddirPkt(&bb, 1, OFID, Off, Len)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendTreaddirPkt(bb[:0], 1, OFID, Off, Len)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, err := bufferUnmarshalTreaddirPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, err := UnmarshalTreaddirPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, err := DecodeTreaddirPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRfsync(b *testing.B) {
+	var m bytes.Buffer
+	MarshalRfsyncPkt(&m, 1, )
+	pkt := m.Bytes()
+	want := fmt.Sprint(Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeRfsyncPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeRfsyncPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalRfsyncPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalRfsyncPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalRfsyncPkt(&bb, 1, )
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalRfsyncPkt(&bb, 1, )
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendRfsyncPkt(bb[:0], 1, )
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := bufferUnmarshalRfsyncPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := UnmarshalRfsyncPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := DecodeRfsyncPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTfsync(b *testing.B) {
+	OFID := ^FID(0)
+	Datasync := ^uint32(0)
+	var m bytes.Buffer
+	MarshalTfsyncPkt(&m, 1, OFID, Datasync)
+	pkt := m.Bytes()
+	want := fmt.Sprint(OFID, Datasync, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeTfsyncPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeTfsyncPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalTfsyncPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalTfsyncPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalTfsyncPkt(&bb, 1, OFID, Datasync)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalTfsyncPkt(&bb, 1, OFID, Datasync)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendTfsyncPkt(bb[:0], 1, OFID, Datasync)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := bufferUnmarshalTfsyncPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := UnmarshalTfsyncPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := DecodeTfsyncPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRlock(b *testing.B) {
+	Status := ^uint8(0)
+	var m bytes.Buffer
+	MarshalRlockPkt(&m, 1, Status)
+	pkt := m.Bytes()
+	want := fmt.Sprint(Status, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeRlockPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeRlockPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalRlockPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalRlockPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalRlockPkt(&bb, 1, Status)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalRlockPkt(&bb, 1, Status)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendRlockPkt(bb[:0], 1, Status)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := bufferUnmarshalRlockPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := UnmarshalRlockPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := DecodeRlockPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTlock(b *testing.B) {
+	OFID := ^FID(0)
+	LType := ^uint8(0)
+	LFlags := ^uint32(0)
+	Start := ^uint64(0)
+	Length := ^uint64(0)
+	ProcID := ^uint32(0)
+	ClientID := "sample"
+	var m bytes.Buffer
+	MarshalTlockPkt(&m, 1, OFID, LType, LFlags, Start, Length, ProcID, ClientID)
+	pkt := m.Bytes()
+	want := fmt.Sprint(OFID, LType, LFlags, Start, Length, ProcID, ClientID, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeTlockPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeTlockPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalTlockPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalTlockPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalTlockPkt(&bb, 1, OFID, LType, LFlags, Start, Length, ProcID, ClientID)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalTlockPkt(&bb, 1, OFID, LType, LFlags, Start, Length, ProcID, ClientID)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendTlockPkt(bb[:0], 1, OFID, LType, LFlags, Start, Length, ProcID, ClientID)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, _, _, _, err := bufferUnmarshalTlockPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, _, _, _, err := UnmarshalTlockPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, _, _, _, err := DecodeTlockPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRgetlock(b *testing.B) {
+	RType := ^uint8(0)
+	RStart := ^uint64(0)
+	RLength := ^uint64(0)
+	RProcID := ^uint32(0)
+	RClientID := "sample"
+	var m bytes.Buffer
+	MarshalRgetlockPkt(&m, 1, RType, RStart, RLength, RProcID, RClientID)
+	pkt := m.Bytes()
+	want := fmt.Sprint(RType, RStart, RLength, RProcID, RClientID, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeRgetlockPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeRgetlockPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalRgetlockPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalRgetlockPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalRgetlockPkt(&bb, 1, RType, RStart, RLength, RProcID, RClientID)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalRgetlockPkt(&bb, 1, RType, RStart, RLength, RProcID, RClientID)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendRgetlockPkt(bb[:0], 1, RType, RStart, RLength, RProcID, RClientID)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, _, err := bufferUnmarshalRgetlockPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, _, err := UnmarshalRgetlockPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, _, err := DecodeRgetlockPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTgetlock(b *testing.B) {
+	OFID := ^FID(0)
+	LType := ^uint8(0)
+	Start := ^uint64(0)
+	Length := ^uint64(0)
+	ProcID := ^uint32(0)
+	ClientID := "sample"
+	var m bytes.Buffer
+	MarshalTgetlockPkt(&m, 1, OFID, LType, Start, Length, ProcID, ClientID)
+	pkt := m.Bytes()
+	want := fmt.Sprint(OFID, LType, Start, Length, ProcID, ClientID, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeTgetlockPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeTgetlockPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalTgetlockPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalTgetlockPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalTgetlockPkt(&bb, 1, OFID, LType, Start, Length, ProcID, ClientID)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalTgetlockPkt(&bb, 1, OFID, LType, Start, Length, ProcID, ClientID)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendTgetlockPkt(bb[:0], 1, OFID, LType, Start, Length, ProcID, ClientID)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, _, _, err := bufferUnmarshalTgetlockPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, _, _, err := UnmarshalTgetlockPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, _, _, err := DecodeTgetlockPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRlink(b *testing.B) {
+	var m bytes.Buffer
+	MarshalRlinkPkt(&m, 1, )
+	pkt := m.Bytes()
+	want := fmt.Sprint(Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeRlinkPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeRlinkPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalRlinkPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalRlinkPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalRlinkPkt(&bb, 1, )
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalRlinkPkt(&bb, 1, )
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendRlinkPkt(bb[:0], 1, )
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := bufferUnmarshalRlinkPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := UnmarshalRlinkPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := DecodeRlinkPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTlink(b *testing.B) {
+	DFID := ^FID(0)
+	OFID := ^FID(0)
+	Name := "sample"
+	var m bytes.Buffer
+	MarshalTlinkPkt(&m, 1, DFID, OFID, Name)
+	pkt := m.Bytes()
+	want := fmt.Sprint(DFID, OFID, Name, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeTlinkPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeTlinkPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalTlinkPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalTlinkPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalTlinkPkt(&bb, 1, DFID, OFID, Name)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalTlinkPkt(&bb, 1, DFID, OFID, Name)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendTlinkPkt(bb[:0], 1, DFID, OFID, Name)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, err := bufferUnmarshalTlinkPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, err := UnmarshalTlinkPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, err := DecodeTlinkPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRmkdir(b *testing.B) {
+	OQID := QID{Type: ^uint8(0), Version: ^uint32(0), Path: ^uint64(0)}
+	var m bytes.Buffer
+	MarshalRmkdirPkt(&m, 1, OQID)
+	pkt := m.Bytes()
+	want := fmt.Sprint(OQID, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeRmkdirPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeRmkdirPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalRmkdirPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalRmkdirPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalRmkdirPkt(&bb, 1, OQID)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalRmkdirPkt(&bb, 1, OQID)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendRmkdirPkt(bb[:0], 1, OQID)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := bufferUnmarshalRmkdirPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := UnmarshalRmkdirPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := DecodeRmkdirPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTmkdir(b *testing.B) {
+	DFID := ^FID(0)
+	Name := "sample"
+	CreateMode := ^uint32(0)
+	GID := ^uint32(0)
+	var m bytes.Buffer
+	MarshalTmkdirPkt(&m, 1, DFID, Name, CreateMode, GID)
+	pkt := m.Bytes()
+	want := fmt.Sprint(DFID, Name, CreateMode, GID, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeTmkdirPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeTmkdirPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalTmkdirPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalTmkdirPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalTmkdirPkt(&bb, 1, DFID, Name, CreateMode, GID)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalTmkdirPkt(&bb, 1, DFID, Name, CreateMode, GID)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendTmkdirPkt(bb[:0], 1, DFID, Name, CreateMode, GID)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, err := bufferUnmarshalTmkdirPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, err := UnmarshalTmkdirPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, err := DecodeTmkdirPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRrenameat(b *testing.B) {
+	var m bytes.Buffer
+	MarshalRrenameatPkt(&m, 1, )
+	pkt := m.Bytes()
+	want := fmt.Sprint(Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeRrenameatPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeRrenameatPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalRrenameatPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalRrenameatPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalRrenameatPkt(&bb, 1, )
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalRrenameatPkt(&bb, 1, )
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendRrenameatPkt(bb[:0], 1, )
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := bufferUnmarshalRrenameatPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := UnmarshalRrenameatPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := DecodeRrenameatPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTrenameat(b *testing.B) {
+	OldDFID := ^FID(0)
+	OldName := "sample"
+	NewDFID := ^FID(0)
+	NewName := "sample"
+	var m bytes.Buffer
+	MarshalTrenameatPkt(&m, 1, OldDFID, OldName, NewDFID, NewName)
+	pkt := m.Bytes()
+	want := fmt.Sprint(OldDFID, OldName, NewDFID, NewName, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeTrenameatPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeTrenameatPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalTrenameatPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalTrenameatPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalTrenameatPkt(&bb, 1, OldDFID, OldName, NewDFID, NewName)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalTrenameatPkt(&bb, 1, OldDFID, OldName, NewDFID, NewName)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendTrenameatPkt(bb[:0], 1, OldDFID, OldName, NewDFID, NewName)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, err := bufferUnmarshalTrenameatPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, err := UnmarshalTrenameatPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, _, err := DecodeTrenameatPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRunlinkat(b *testing.B) {
+	var m bytes.Buffer
+	MarshalRunlinkatPkt(&m, 1, )
+	pkt := m.Bytes()
+	want := fmt.Sprint(Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeRunlinkatPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeRunlinkatPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalRunlinkatPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalRunlinkatPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalRunlinkatPkt(&bb, 1, )
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalRunlinkatPkt(&bb, 1, )
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendRunlinkatPkt(bb[:0], 1, )
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := bufferUnmarshalRunlinkatPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := UnmarshalRunlinkatPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := DecodeRunlinkatPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTunlinkat(b *testing.B) {
+	DFID := ^FID(0)
+	Name := "sample"
+	UFlags := ^uint32(0)
+	var m bytes.Buffer
+	MarshalTunlinkatPkt(&m, 1, DFID, Name, UFlags)
+	pkt := m.Bytes()
+	want := fmt.Sprint(DFID, Name, UFlags, Tag(1), error(nil))
+	if got := fmt.Sprint(DecodeTunlinkatPkt(pkt[5:])); got != want {
+		b.Fatalf("DecodeTunlinkatPkt: got %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(bufferUnmarshalTunlinkatPkt(bytes.NewBuffer(pkt[5:]))); got != want {
+		b.Fatalf("bufferUnmarshalTunlinkatPkt: got %v, want %v", got, want)
+	}
+	b.Run("BufferMarshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bufferMarshalTunlinkatPkt(&bb, 1, DFID, Name, UFlags)
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		var bb bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MarshalTunlinkatPkt(&bb, 1, DFID, Name, UFlags)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		bb := make([]byte, 0, len(pkt))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bb = AppendTunlinkatPkt(bb[:0], 1, DFID, Name, UFlags)
+		}
+	})
+	b.Run("BufferUnmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, err := bufferUnmarshalTunlinkatPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, err := UnmarshalTunlinkatPkt(bytes.NewBuffer(pkt[5:])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, _, _, err := DecodeTunlinkatPkt(pkt[5:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// msgSamples has a sample of each Msg.
+var msgSamples = []Msg{
+	&RerrorPkt{Error: "sample"},
+	&RversionPkt{RMsize: ^MaxSize(0), RVersion: "sample"},
+	&TversionPkt{TMsize: ^MaxSize(0), TVersion: "sample"},
+	&RauthPkt{AQID: QID{Type: ^uint8(0), Version: ^uint32(0), Path: ^uint64(0)}},
+	&TauthPkt{AFID: ^FID(0), Uname: "sample", Aname: "sample"},
+	&RattachPkt{QID: QID{Type: ^uint8(0), Version: ^uint32(0), Path: ^uint64(0)}},
+	&TattachPkt{SFID: ^FID(0), AFID: ^FID(0), Uname: "sample", Aname: "sample"},
+	&RflushPkt{},
+	&TflushPkt{OTag: ^Tag(0)},
+	&RwalkPkt{QIDs: []QID{QID{Type: ^uint8(0), Version: ^uint32(0), Path: ^uint64(0)}, QID{Type: ^uint8(0), Version: ^uint32(0), Path: ^uint64(0)}}},
+	&TwalkPkt{SFID: ^FID(0), NewFID: ^FID(0), Paths: []string{"a", "sample"}},
+	&RopenPkt{OQID: QID{Type: ^uint8(0), Version: ^uint32(0), Path: ^uint64(0)}, IOUnit: ^MaxSize(0)},
+	&TopenPkt{OFID: ^FID(0), Omode: ^Mode(0)},
+	&RcreatePkt{OQID: QID{Type: ^uint8(0), Version: ^uint32(0), Path: ^uint64(0)}, IOUnit: ^MaxSize(0)},
+	&TcreatePkt{OFID: ^FID(0), Name: "sample", CreatePerm: ^Perm(0), Omode: ^Mode(0)},
+	&RstatPkt{B: make([]byte, 1024)},
+	&TstatPkt{OFID: ^FID(0)},
+	&RwstatPkt{},
+	&TwstatPkt{OFID: ^FID(0), B: make([]byte, 1024)},
+	&RclunkPkt{},
+	&TclunkPkt{OFID: ^FID(0)},
+	&RremovePkt{},
+	&TremovePkt{OFID: ^FID(0)},
+	&RreadPkt{Data: make([]byte, 1024)},
+	&TreadPkt{OFID: ^FID(0), Off: ^Offset(0), Len: ^Count(0)},
+	&RwritePkt{RLen: ^Count(0)},
+	&TwritePkt{OFID: ^FID(0), Off: ^Offset(0), Data: make([]byte, 1024)},
+	&RlerrorPkt{Ecode: ^uint32(0)},
+	&RstatfsPkt{StatFS: StatFS{Type: ^uint32(0), BSize: ^uint32(0), Blocks: ^uint64(0), BFree: ^uint64(0), BAvail: ^uint64(0), Files: ^uint64(0), FFree: ^uint64(0), FSID: ^uint64(0), NameLen: ^uint32(0)}},
+	&TstatfsPkt{OFID: ^FID(0)},
+	&RlopenPkt{OQID: QID{Type: ^uint8(0), Version: ^uint32(0), Path: ^uint64(0)}, IOUnit: ^MaxSize(0)},
+	&TlopenPkt{OFID: ^FID(0), LFlags: ^uint32(0)},
+	&RlcreatePkt{OQID: QID{Type: ^uint8(0), Version: ^uint32(0), Path: ^uint64(0)}, IOUnit: ^MaxSize(0)},
+	&TlcreatePkt{OFID: ^FID(0), Name: "sample", LFlags: ^uint32(0), CreateMode: ^uint32(0), GID: ^uint32(0)},
+	&RsymlinkPkt{OQID: QID{Type: ^uint8(0), Version: ^uint32(0), Path: ^uint64(0)}},
+	&TsymlinkPkt{OFID: ^FID(0), Name: "sample", Target: "sample", GID: ^uint32(0)},
+	&RmknodPkt{OQID: QID{Type: ^uint8(0), Version: ^uint32(0), Path: ^uint64(0)}},
+	&TmknodPkt{DFID: ^FID(0), Name: "sample", CreateMode: ^uint32(0), Major: ^uint32(0), Minor: ^uint32(0), GID: ^uint32(0)},
+	&RrenamePkt{},
+	&TrenamePkt{OFID: ^FID(0), DFID: ^FID(0), Name: "sample"},
+	&RreadlinkPkt{Target: "sample"},
+	&TreadlinkPkt{OFID: ^FID(0)},
+	&RgetattrPkt{Attr: Attr{Valid: ^uint64(0), QID: QID{Type: ^uint8(0), Version: ^uint32(0), Path: ^uint64(0)}, Mode: ^uint32(0), UID: ^uint32(0), GID: ^uint32(0), NLink: ^uint64(0), RDev: ^uint64(0), Size: ^uint64(0), BlkSize: ^uint64(0), Blocks: ^uint64(0), ATimeSec: ^uint64(0), ATimeNSec: ^uint64(0), MTimeSec: ^uint64(0), MTimeNSec: ^uint64(0), CTimeSec: ^uint64(0), CTimeNSec: ^uint64(0), BTimeSec: ^uint64(0), BTimeNSec: ^uint64(0), Gen: ^uint64(0), DataVersion: ^uint64(0)}},
+	&TgetattrPkt{OFID: ^FID(0), Mask: ^uint64(0)},
+	&RsetattrPkt{},
+	&TsetattrPkt{OFID: ^FID(0), SetAttr: SetAttr{Valid: ^uint32(0), Mode: ^uint32(0), UID: ^uint32(0), GID: ^uint32(0), Size: ^uint64(0), ATimeSec: ^uint64(0), ATimeNSec: ^uint64(0), MTimeSec: ^uint64(0), MTimeNSec: ^uint64(0)}},
+	&RxattrwalkPkt{Size: ^uint64(0)},
+	&TxattrwalkPkt{OFID: ^FID(0), NewFID: ^FID(0), Name: "sample"},
+	&RxattrcreatePkt{},
+	&TxattrcreatePkt{OFID: ^FID(0), Name: "sample", AttrSize: ^uint64(0), XFlags: ^uint32(0)},
+	&RreaddirPkt{Data: make([]byte, 1024)},
+	&TreaddirPkt{OFID: ^FID(0), Off: ^Offset(0), Len: ^Count(0)},
+	&RfsyncPkt{},
+	&TfsyncPkt{OFID: ^FID(0), Datasync: ^uint32(0)},
+	&RlockPkt{Status: ^uint8(0)},
+	&TlockPkt{OFID: ^FID(0), LType: ^uint8(0), LFlags: ^uint32(0), Start: ^uint64(0), Length: ^uint64(0), ProcID: ^uint32(0), ClientID: "sample"},
+	&RgetlockPkt{RType: ^uint8(0), RStart: ^uint64(0), RLength: ^uint64(0), RProcID: ^uint32(0), RClientID: "sample"},
+	&TgetlockPkt{OFID: ^FID(0), LType: ^uint8(0), Start: ^uint64(0), Length: ^uint64(0), ProcID: ^uint32(0), ClientID: "sample"},
+	&RlinkPkt{},
+	&TlinkPkt{DFID: ^FID(0), OFID: ^FID(0), Name: "sample"},
+	&RmkdirPkt{OQID: QID{Type: ^uint8(0), Version: ^uint32(0), Path: ^uint64(0)}},
+	&TmkdirPkt{DFID: ^FID(0), Name: "sample", CreateMode: ^uint32(0), GID: ^uint32(0)},
+	&RrenameatPkt{},
+	&TrenameatPkt{OldDFID: ^FID(0), OldName: "sample", NewDFID: ^FID(0), NewName: "sample"},
+	&RunlinkatPkt{},
+	&TunlinkatPkt{DFID: ^FID(0), Name: "sample", UFlags: ^uint32(0)},
+}
+
+// msgSamplesDotu has a sample of each DotuMsg, with its 9P2000.u fields.
+var msgSamplesDotu = []DotuMsg{
+	&RerrorPkt{Error: "sample", Errno: ^uint32(0)},
+	&TauthPkt{AFID: ^FID(0), Uname: "sample", Aname: "sample", NUname: ^uint32(0)},
+	&TattachPkt{SFID: ^FID(0), AFID: ^FID(0), Uname: "sample", Aname: "sample", NUname: ^uint32(0)},
+	&TcreatePkt{OFID: ^FID(0), Name: "sample", CreatePerm: ^Perm(0), Omode: ^Mode(0), Extension: "sample"},
+}
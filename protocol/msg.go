@@ -0,0 +1,83 @@
+// Copyright 2018 The Ninep Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protocol
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// A Msg is a 9P message, which can be passed around, queued and logged
+// whole, rather than as the arguments of the Marshal and Unmarshal
+// functions. The structs for the messages, such as *TwalkPkt, are Msgs.
+//
+// Encode appends the message, from its size on, with tag t, and Decode
+// decodes one such message, which must be all of b, and returns its tag.
+// Byte slices decoded, such as the Data of an Rread, refer to b.
+type Msg interface {
+	Type() MType
+	Encode(b []byte, t Tag) []byte
+	Decode(b []byte) (Tag, error)
+	String() string
+}
+
+// A DotuMsg is a Msg with fields which are only sent in 9P2000.u, and in
+// 9P2000.L, which uses its Tattach and Tauth. Encode leaves them out, and
+// EncodeDotu puts them in. Decode takes either form, and DecodeDotu only
+// the 9P2000.u one.
+type DotuMsg interface {
+	Msg
+	EncodeDotu(b []byte, t Tag) []byte
+	DecodeDotu(b []byte) (Tag, error)
+}
+
+// ReadMsg reads a message from r, of at most MSIZE bytes, and returns its
+// tag and the message.
+func ReadMsg(r io.Reader) (Tag, Msg, error) {
+	b, err := NewFramer(r, nil).ReadFrame(MSIZE)
+	if err != nil {
+		return 0, nil, err
+	}
+	m := newMsg(MType(b[4]))
+	if m == nil {
+		return 0, nil, fmt.Errorf("unknown message type %d", b[4])
+	}
+	t, err := m.Decode(b)
+	if err != nil {
+		return 0, nil, err
+	}
+	return t, m, nil
+}
+
+// WriteMsg writes m to w, with tag t, as 9P2000 has it.
+func WriteMsg(w io.Writer, t Tag, m Msg) error {
+	_, err := w.Write(m.Encode(nil, t))
+	return err
+}
+
+// WriteMsgDotu is WriteMsg for 9P2000.u and 9P2000.L, which add fields to a
+// few messages.
+func WriteMsgDotu(w io.Writer, t Tag, m Msg) error {
+	if d, ok := m.(DotuMsg); ok {
+		_, err := w.Write(d.EncodeDotu(nil, t))
+		return err
+	}
+	return WriteMsg(w, t, m)
+}
+
+// checkMsg checks that b holds all of a message of type t.
+func checkMsg(b []byte, t MType) error {
+	if len(b) < 7 {
+		return fmt.Errorf("%v is %d bytes; need at least 7", RPCNames[t], len(b))
+	}
+	if n := binary.LittleEndian.Uint32(b); int64(n) != int64(len(b)) {
+		return fmt.Errorf("%v says it is %d bytes; have %d", RPCNames[t], n, len(b))
+	}
+	if MType(b[4]) != t {
+		return fmt.Errorf("got %v, want %v", RPCNames[MType(b[4])], RPCNames[t])
+	}
+	return nil
+}
@@ -4,7 +4,10 @@
 
 package protocol
 
-import "bytes"
+import (
+	"bytes"
+	"context"
+)
 
 // 9P2000 message types
 const (
@@ -39,6 +42,57 @@ const (
 	Tlast
 )
 
+// 9P2000.L message types
+const (
+	Tlerror      MType = 6
+	Rlerror      MType = 7
+	Tstatfs      MType = 8
+	Rstatfs      MType = 9
+	Tlopen       MType = 12
+	Rlopen       MType = 13
+	Tlcreate     MType = 14
+	Rlcreate     MType = 15
+	Tsymlink     MType = 16
+	Rsymlink     MType = 17
+	Tmknod       MType = 18
+	Rmknod       MType = 19
+	Trename      MType = 20
+	Rrename      MType = 21
+	Treadlink    MType = 22
+	Rreadlink    MType = 23
+	Tgetattr     MType = 24
+	Rgetattr     MType = 25
+	Tsetattr     MType = 26
+	Rsetattr     MType = 27
+	Txattrwalk   MType = 30
+	Rxattrwalk   MType = 31
+	Txattrcreate MType = 32
+	Rxattrcreate MType = 33
+	Treaddir     MType = 40
+	Rreaddir     MType = 41
+	Tfsync       MType = 50
+	Rfsync       MType = 51
+	Tlock        MType = 52
+	Rlock        MType = 53
+	Tgetlock     MType = 54
+	Rgetlock     MType = 55
+	Tlink        MType = 70
+	Rlink        MType = 71
+	Tmkdir       MType = 72
+	Rmkdir       MType = 73
+	Trenameat    MType = 74
+	Rrenameat    MType = 75
+	Tunlinkat    MType = 76
+	Runlinkat    MType = 77
+)
+
+// Protocol versions
+const (
+	Version9P2000  = "9P2000"
+	Version9P2000u = "9P2000.u"
+	Version9P2000L = "9P2000.L"
+)
+
 const (
 	MSIZE   = 2*1048576 + IOHDRSZ // default message size (1048576+IOHdrSz)
 	IOHDRSZ = 24                  // the non-data size of the Twrite messages
@@ -81,9 +135,18 @@ const (
 	DMMOUNT  = 0x10000000 // mode bit for mounted channel
 	DMAUTH   = 0x08000000 // mode bit for authentication file
 	DMTMP    = 0x04000000 // mode bit for non-backed-up file
-	DMREAD   = 0x4        // mode bit for read permission
-	DMWRITE  = 0x2        // mode bit for write permission
-	DMEXEC   = 0x1        // mode bit for execute permission
+	// 9P2000.u extensions
+	DMSYMLINK   = 0x02000000 // mode bit for symbolic link
+	DMLINK      = 0x01000000 // mode bit for hard link
+	DMDEVICE    = 0x00800000 // mode bit for device file
+	DMNAMEDPIPE = 0x00200000 // mode bit for named pipe
+	DMSOCKET    = 0x00100000 // mode bit for socket
+	DMSETUID    = 0x00080000 // mode bit for setuid
+	DMSETGID    = 0x00040000 // mode bit for setgid
+	DMSETVTX    = 0x00010000 // mode bit for sticky bit
+	DMREAD      = 0x4        // mode bit for read permission
+	DMWRITE     = 0x2        // mode bit for write permission
+	DMEXEC      = 0x1        // mode bit for execute permission
 )
 
 const (
@@ -95,6 +158,10 @@ const (
 	NumTags = 1<<16 - 2
 )
 
+// NOUID is the numeric user id meaning "none" in 9P2000.u, e.g. the n_uname
+// of a Tattach from a client which only knows user names.
+const NOUID = 0xFFFFFFFF
+
 // Error values
 const (
 	EPERM   = 1
@@ -119,17 +186,11 @@ type (
 	Offset     uint64
 	Data       []byte
 	// Some []byte fields are encoded with a 16-bit length, e.g. stat data.
-	// We use this type to tag such fields. The parameters are still []byte,
-	// this was just the only way I could think of to make the stub generator do the right
-	// thing.
+	// Such fields are tagged `ninep:"count16"`, and the stub generator
+	// uses this type for them.
 	DataCnt16 byte // []byte with a 16-bit count.
 )
 
-// Error represents a 9P2000 error
-type Error struct {
-	Err string
-}
-
 // File identifier
 type QID struct {
 	Type    uint8  // type of the file (high 8 bits of the mode)
@@ -137,6 +198,58 @@ type QID struct {
 	Path    uint64 // server's unique identification of the file
 }
 
+// StatFS describes a file system, as returned by 9P2000.L statfs.
+type StatFS struct {
+	Type    uint32 // file system type, as in Linux statfs(2)
+	BSize   uint32 // block size
+	Blocks  uint64 // total blocks
+	BFree   uint64 // free blocks
+	BAvail  uint64 // free blocks available to unprivileged users
+	Files   uint64 // total files
+	FFree   uint64 // free files
+	FSID    uint64 // file system id
+	NameLen uint32 // maximum length of a file name
+}
+
+// Attr describes a file, as returned by 9P2000.L getattr. Valid is a mask of
+// the GETATTR bits for the fields which the server filled in.
+type Attr struct {
+	Valid       uint64
+	QID         QID
+	Mode        uint32 // Linux st_mode: file type and permissions
+	UID         uint32
+	GID         uint32
+	NLink       uint64
+	RDev        uint64
+	Size        uint64
+	BlkSize     uint64
+	Blocks      uint64
+	ATimeSec    uint64
+	ATimeNSec   uint64
+	MTimeSec    uint64
+	MTimeNSec   uint64
+	CTimeSec    uint64
+	CTimeNSec   uint64
+	BTimeSec    uint64
+	BTimeNSec   uint64
+	Gen         uint64
+	DataVersion uint64
+}
+
+// SetAttr holds the changes for a 9P2000.L setattr. Valid is a mask of the
+// SETATTR bits for the fields which are to be changed.
+type SetAttr struct {
+	Valid     uint32
+	Mode      uint32
+	UID       uint32
+	GID       uint32
+	Size      uint64
+	ATimeSec  uint64
+	ATimeNSec uint64
+	MTimeSec  uint64
+	MTimeNSec uint64
+}
+
 // Dir describes a file
 type Dir struct {
 	Type    uint16
@@ -150,12 +263,23 @@ type Dir struct {
 	User    string // owner name
 	Group   string // group name
 	ModUser string // name of the last user that modified the file
+
+	// 9P2000.u extensions. These are only on the wire if 9P2000.u
+	// was negotiated.
+	Extension string `ninep:"dotu"` // symlink target, device numbers, etc.
+	UID       uint32 `ninep:"dotu"` // numeric owner id
+	GID       uint32 `ninep:"dotu"` // numeric group id
+	MUID      uint32 `ninep:"dotu"` // numeric id of the last user that modified the file
 }
 
-type Dispatcher func(s *Server, b *bytes.Buffer, t MType) error
+// A Dispatcher serves the request of type t in b, leaving the reply in b.
+// The context is cancelled if the request is flushed.
+type Dispatcher func(ctx context.Context, s *Server, b *bytes.Buffer, t MType) error
 
 // N.B. In all packets, the wire order is assumed to be the order in which you
 // put struct members.
+// Members tagged `ninep:"dotu"` are only sent when 9P2000.u has been negotiated;
+// gen.go generates a second set of functions, with a Dotu suffix, for those messages.
 // In an earlier version of this code we got really fancy and made it so you
 // could have identically named fields in the R and T packets. It's only an issue
 // in a trivial number of packets so we place the burden on you, the user, to make
@@ -172,11 +296,23 @@ type RversionPkt struct {
 	RVersion string
 }
 
+type TauthPkt struct {
+	AFID   FID
+	Uname  string
+	Aname  string
+	NUname uint32 `ninep:"dotu"`
+}
+
+type RauthPkt struct {
+	AQID QID
+}
+
 type TattachPkt struct {
-	SFID  FID
-	AFID  FID
-	Uname string
-	Aname string
+	SFID   FID
+	AFID   FID
+	Uname  string
+	Aname  string
+	NUname uint32 `ninep:"dotu"`
 }
 
 type RattachPkt struct {
@@ -215,6 +351,7 @@ type TcreatePkt struct {
 	Name       string
 	CreatePerm Perm
 	Omode      Mode
+	Extension  string `ninep:"dotu"`
 }
 
 type RcreatePkt struct {
@@ -241,12 +378,12 @@ type TstatPkt struct {
 }
 
 type RstatPkt struct {
-	B []DataCnt16
+	B []byte `ninep:"count16"`
 }
 
 type TwstatPkt struct {
 	OFID FID
-	B    []DataCnt16
+	B    []byte `ninep:"count16"`
 }
 
 type RwstatPkt struct {
@@ -274,6 +411,215 @@ type RwritePkt struct {
 
 type RerrorPkt struct {
 	Error string
+	Errno uint32 `ninep:"dotu"`
+}
+
+// 9P2000.L. Tattach is the 9P2000.u one; Topen, Tcreate, Tstat and Twstat
+// are replaced by the messages below, and Rerror by Rlerror.
+
+type RlerrorPkt struct {
+	Ecode uint32
+}
+
+// An Rlerror's Ecode is a Linux errno, whatever the server's host is.
+// lENOSYS is the one sent for messages the NineServer doesn't serve.
+const lENOSYS = 38
+
+type TstatfsPkt struct {
+	OFID FID
+}
+
+type RstatfsPkt struct {
+	StatFS StatFS
+}
+
+type TlopenPkt struct {
+	OFID   FID
+	LFlags uint32
+}
+
+type RlopenPkt struct {
+	OQID   QID
+	IOUnit MaxSize
+}
+
+type TlcreatePkt struct {
+	OFID       FID
+	Name       string
+	LFlags     uint32
+	CreateMode uint32
+	GID        uint32
+}
+
+type RlcreatePkt struct {
+	OQID   QID
+	IOUnit MaxSize
+}
+
+type TsymlinkPkt struct {
+	OFID   FID
+	Name   string
+	Target string
+	GID    uint32
+}
+
+type RsymlinkPkt struct {
+	OQID QID
+}
+
+type TmknodPkt struct {
+	DFID       FID
+	Name       string
+	CreateMode uint32
+	Major      uint32
+	Minor      uint32
+	GID        uint32
+}
+
+type RmknodPkt struct {
+	OQID QID
+}
+
+type TrenamePkt struct {
+	OFID FID
+	DFID FID
+	Name string
+}
+
+type RrenamePkt struct {
+}
+
+type TreadlinkPkt struct {
+	OFID FID
+}
+
+type RreadlinkPkt struct {
+	Target string
+}
+
+type TgetattrPkt struct {
+	OFID FID
+	Mask uint64
+}
+
+type RgetattrPkt struct {
+	Attr Attr
+}
+
+type TsetattrPkt struct {
+	OFID    FID
+	SetAttr SetAttr
+}
+
+type RsetattrPkt struct {
+}
+
+type TxattrwalkPkt struct {
+	OFID   FID
+	NewFID FID
+	Name   string
+}
+
+type RxattrwalkPkt struct {
+	Size uint64
+}
+
+type TxattrcreatePkt struct {
+	OFID     FID
+	Name     string
+	AttrSize uint64
+	XFlags   uint32
+}
+
+type RxattrcreatePkt struct {
+}
+
+type TreaddirPkt struct {
+	OFID FID
+	Off  Offset
+	Len  Count
+}
+
+type RreaddirPkt struct {
+	Data []byte
+}
+
+type TfsyncPkt struct {
+	OFID     FID
+	Datasync uint32
+}
+
+type RfsyncPkt struct {
+}
+
+type TlockPkt struct {
+	OFID     FID
+	LType    uint8
+	LFlags   uint32
+	Start    uint64
+	Length   uint64
+	ProcID   uint32
+	ClientID string
+}
+
+type RlockPkt struct {
+	Status uint8
+}
+
+type TgetlockPkt struct {
+	OFID     FID
+	LType    uint8
+	Start    uint64
+	Length   uint64
+	ProcID   uint32
+	ClientID string
+}
+
+type RgetlockPkt struct {
+	RType     uint8
+	RStart    uint64
+	RLength   uint64
+	RProcID   uint32
+	RClientID string
+}
+
+type TlinkPkt struct {
+	DFID FID
+	OFID FID
+	Name string
+}
+
+type RlinkPkt struct {
+}
+
+type TmkdirPkt struct {
+	DFID       FID
+	Name       string
+	CreateMode uint32
+	GID        uint32
+}
+
+type RmkdirPkt struct {
+	OQID QID
+}
+
+type TrenameatPkt struct {
+	OldDFID FID
+	OldName string
+	NewDFID FID
+	NewName string
+}
+
+type RrenameatPkt struct {
+}
+
+type TunlinkatPkt struct {
+	DFID   FID
+	Name   string
+	UFlags uint32
+}
+
+type RunlinkatPkt struct {
 }
 
 type DirPkt struct {
@@ -287,6 +633,8 @@ type RPCCall struct {
 
 type RPCReply struct {
 	b []byte
+	// frame is the pooled buffer b is in, if any.
+	frame []byte
 }
 
 /* rpc servers */
@@ -305,10 +653,328 @@ type NineServer interface {
 	Rclunk(FID) error
 	Rremove(FID) error
 	Rread(FID, Offset, Count) ([]byte, error)
+	// The data passed to Rwrite is the NineServer's own, and may be
+	// kept; a WriteFromServer is passed the Twrite's buffer instead.
 	Rwrite(FID, Offset, []byte) (Count, error)
+	// Deprecated: Rflush is never called. The Server handles Tflush
+	// itself, and a NineServerContext sees the flush as the cancelling
+	// of the flushed request's context. Rflush is kept so that existing
+	// NineServers still are ones; it can simply return nil.
 	Rflush(Otag Tag) error
 }
 
+// NineServerContext is NineServer with a context for each request, which
+// is cancelled when the request is flushed, or its connection goes away.
+// The Server takes care of Tflush itself, so there is no Rflush: once the
+// flushed request returns, its reply is dropped and the Rflush sent.
+//
+// A NineServerContext serves 9P2000, and the messages 9P2000.u and
+// 9P2000.L share with it, such as Twalk and Tread. The rest, from the
+// 9P2000.u Tattach and Tcreate to all of the 9P2000.L ones, are served by
+// it if it is a NineServerDotuContext or NineServerLContext. Otherwise
+// they go to the Server's NS, if it is a NineServerDotu or NineServerL,
+// which can't know of flushes; a Server with neither refuses those
+// versions at Tversion.
+type NineServerContext interface {
+	Rversion(context.Context, MaxSize, string) (MaxSize, string, error)
+	Rattach(context.Context, FID, FID, string, string) (QID, error)
+	Rwalk(context.Context, FID, FID, []string) ([]QID, error)
+	Ropen(context.Context, FID, Mode) (QID, MaxSize, error)
+	Rcreate(context.Context, FID, string, Perm, Mode) (QID, MaxSize, error)
+	Rstat(context.Context, FID) ([]byte, error)
+	Rwstat(context.Context, FID, []byte) error
+	Rclunk(context.Context, FID) error
+	Rremove(context.Context, FID) error
+	Rread(context.Context, FID, Offset, Count) ([]byte, error)
+	Rwrite(context.Context, FID, Offset, []byte) (Count, error)
+}
+
+// nineServerContext serves a NineServerContext with a NineServer, which
+// carries on with flushed requests, as it can't know about them.
+type nineServerContext struct {
+	ns NineServer
+}
+
+// WithContext returns a NineServerContext which serves requests with ns.
+// If ns is a NineServerDotu or NineServerL, it is a NineServerDotuContext
+// or NineServerLContext.
+func WithContext(ns NineServer) NineServerContext {
+	n := &nineServerContext{ns: ns}
+	switch ns := ns.(type) {
+	case NineServerL:
+		return &nineServerLContext{&nineServerDotuContext{n, ns}, ns}
+	case NineServerDotu:
+		return &nineServerDotuContext{n, ns}
+	}
+	return n
+}
+
+// served returns the NineServer nsc serves requests with, if it is one
+// made by WithContext.
+func served(nsc NineServerContext) (NineServer, bool) {
+	if n, ok := nsc.(interface{ nineServer() NineServer }); ok {
+		return n.nineServer(), true
+	}
+	return nil, false
+}
+
+func (n *nineServerContext) nineServer() NineServer {
+	return n.ns
+}
+
+func (n *nineServerContext) Rversion(_ context.Context, msize MaxSize, version string) (MaxSize, string, error) {
+	return n.ns.Rversion(msize, version)
+}
+
+func (n *nineServerContext) Rattach(_ context.Context, fid FID, afid FID, uname string, aname string) (QID, error) {
+	return n.ns.Rattach(fid, afid, uname, aname)
+}
+
+func (n *nineServerContext) Rwalk(_ context.Context, fid FID, newfid FID, paths []string) ([]QID, error) {
+	return n.ns.Rwalk(fid, newfid, paths)
+}
+
+func (n *nineServerContext) Ropen(_ context.Context, fid FID, mode Mode) (QID, MaxSize, error) {
+	return n.ns.Ropen(fid, mode)
+}
+
+func (n *nineServerContext) Rcreate(_ context.Context, fid FID, name string, perm Perm, mode Mode) (QID, MaxSize, error) {
+	return n.ns.Rcreate(fid, name, perm, mode)
+}
+
+func (n *nineServerContext) Rstat(_ context.Context, fid FID) ([]byte, error) {
+	return n.ns.Rstat(fid)
+}
+
+func (n *nineServerContext) Rwstat(_ context.Context, fid FID, b []byte) error {
+	return n.ns.Rwstat(fid, b)
+}
+
+func (n *nineServerContext) Rclunk(_ context.Context, fid FID) error {
+	return n.ns.Rclunk(fid)
+}
+
+func (n *nineServerContext) Rremove(_ context.Context, fid FID) error {
+	return n.ns.Rremove(fid)
+}
+
+func (n *nineServerContext) Rread(_ context.Context, fid FID, o Offset, c Count) ([]byte, error) {
+	return n.ns.Rread(fid, o, c)
+}
+
+func (n *nineServerContext) Rwrite(_ context.Context, fid FID, o Offset, b []byte) (Count, error) {
+	return n.ns.Rwrite(fid, o, b)
+}
+
+// A SessionServer is a NineServer which keeps state, such as fids, for
+// each connection. The Server calls NewSession for each connection it
+// accepts, and sends the connection's requests to the NineServer it
+// returns. If that NineServer is an io.Closer, it is closed once the
+// connection is gone. Each session's NineServer is served with
+// WithContext, so a Server whose NS is a SessionServer may not have a
+// NSContext of its own.
+type SessionServer interface {
+	NineServer
+	NewSession() (NineServer, error)
+}
+
+// A ReadIntoServer is a NineServer which can read straight into the
+// buffer the Rread is sent from, rather than returning data for the Server
+// to copy there. If the server for a session is one, and is not wrapped in
+// a NineServerContext of the user's, the Server calls RreadInto for each
+// Tread instead of Rread, with dst as long as the count asked for, and
+// sends the first n bytes of it.
+type ReadIntoServer interface {
+	RreadInto(fid FID, off Offset, dst []byte) (n int, err error)
+}
+
+// ReadIntoServerContext is ReadIntoServer for a NineServerContext.
+type ReadIntoServerContext interface {
+	RreadInto(ctx context.Context, fid FID, off Offset, dst []byte) (n int, err error)
+}
+
+// A WriteFromServer is a NineServer which can write straight from the
+// buffer the Twrite was read into, rather than from a copy of the data.
+// If the server for a session is one, and is not wrapped in a
+// NineServerContext of the user's, the Server calls RwriteFrom for each
+// Twrite instead of Rwrite. src is reused for other messages once
+// RwriteFrom returns, so it must not be kept.
+type WriteFromServer interface {
+	RwriteFrom(fid FID, off Offset, src []byte) (Count, error)
+}
+
+// WriteFromServerContext is WriteFromServer for a NineServerContext.
+type WriteFromServerContext interface {
+	RwriteFrom(ctx context.Context, fid FID, off Offset, src []byte) (Count, error)
+}
+
+// NineServerDotu is implemented by servers which speak 9P2000.u. A server
+// negotiates it by returning Version9P2000u from Rversion; from then on
+// Tattach and Tcreate are passed the extra 9P2000.u fields, and errors carry
+// an errno. Data passed to and returned from Rstat, Rwstat and directory
+// reads should use MarshaldirDotu and UnmarshaldirDotu.
+type NineServerDotu interface {
+	NineServer
+	RattachDotu(FID, FID, string, string, uint32) (QID, error)
+	RcreateDotu(FID, string, Perm, Mode, string) (QID, MaxSize, error)
+}
+
+// NineServerL is implemented by servers which speak 9P2000.L, which a
+// server negotiates by returning Version9P2000L from Rversion. 9P2000.L
+// shares Tattach with 9P2000.u, and Twalk, Tread, Twrite, Tclunk, Tremove
+// and Tflush with 9P2000; the rest of its messages go to the methods below.
+// Errors are sent as Rlerror, with the errno taken from the error if it
+// wraps a syscall.Errno.
+type NineServerL interface {
+	NineServerDotu
+	Rstatfs(FID) (StatFS, error)
+	Rlopen(FID, uint32) (QID, MaxSize, error)
+	Rlcreate(FID, string, uint32, uint32, uint32) (QID, MaxSize, error)
+	Rsymlink(FID, string, string, uint32) (QID, error)
+	Rmknod(FID, string, uint32, uint32, uint32, uint32) (QID, error)
+	Rrename(FID, FID, string) error
+	Rreadlink(FID) (string, error)
+	Rgetattr(FID, uint64) (Attr, error)
+	Rsetattr(FID, SetAttr) error
+	Rxattrwalk(FID, FID, string) (uint64, error)
+	Rxattrcreate(FID, string, uint64, uint32) error
+	Rreaddir(FID, Offset, Count) ([]byte, error)
+	Rfsync(FID, uint32) error
+	Rlock(FID, uint8, uint32, uint64, uint64, uint32, string) (uint8, error)
+	Rgetlock(FID, uint8, uint64, uint64, uint32, string) (uint8, uint64, uint64, uint32, string, error)
+	Rlink(FID, FID, string) error
+	Rmkdir(FID, string, uint32, uint32) (QID, error)
+	Rrenameat(FID, string, FID, string) error
+	Runlinkat(FID, string, uint32) error
+}
+
+// NineServerDotuContext is NineServerDotu for a NineServerContext.
+type NineServerDotuContext interface {
+	NineServerContext
+	RattachDotu(context.Context, FID, FID, string, string, uint32) (QID, error)
+	RcreateDotu(context.Context, FID, string, Perm, Mode, string) (QID, MaxSize, error)
+}
+
+// NineServerLContext is NineServerL for a NineServerContext.
+type NineServerLContext interface {
+	NineServerDotuContext
+	Rstatfs(context.Context, FID) (StatFS, error)
+	Rlopen(context.Context, FID, uint32) (QID, MaxSize, error)
+	Rlcreate(context.Context, FID, string, uint32, uint32, uint32) (QID, MaxSize, error)
+	Rsymlink(context.Context, FID, string, string, uint32) (QID, error)
+	Rmknod(context.Context, FID, string, uint32, uint32, uint32, uint32) (QID, error)
+	Rrename(context.Context, FID, FID, string) error
+	Rreadlink(context.Context, FID) (string, error)
+	Rgetattr(context.Context, FID, uint64) (Attr, error)
+	Rsetattr(context.Context, FID, SetAttr) error
+	Rxattrwalk(context.Context, FID, FID, string) (uint64, error)
+	Rxattrcreate(context.Context, FID, string, uint64, uint32) error
+	Rreaddir(context.Context, FID, Offset, Count) ([]byte, error)
+	Rfsync(context.Context, FID, uint32) error
+	Rlock(context.Context, FID, uint8, uint32, uint64, uint64, uint32, string) (uint8, error)
+	Rgetlock(context.Context, FID, uint8, uint64, uint64, uint32, string) (uint8, uint64, uint64, uint32, string, error)
+	Rlink(context.Context, FID, FID, string) error
+	Rmkdir(context.Context, FID, string, uint32, uint32) (QID, error)
+	Rrenameat(context.Context, FID, string, FID, string) error
+	Runlinkat(context.Context, FID, string, uint32) error
+}
+
+// nineServerDotuContext is WithContext for a NineServerDotu.
+type nineServerDotuContext struct {
+	*nineServerContext
+	u NineServerDotu
+}
+
+func (n *nineServerDotuContext) RattachDotu(_ context.Context, fid FID, afid FID, uname string, aname string, nuname uint32) (QID, error) {
+	return n.u.RattachDotu(fid, afid, uname, aname, nuname)
+}
+
+func (n *nineServerDotuContext) RcreateDotu(_ context.Context, fid FID, name string, perm Perm, mode Mode, ext string) (QID, MaxSize, error) {
+	return n.u.RcreateDotu(fid, name, perm, mode, ext)
+}
+
+// nineServerLContext is WithContext for a NineServerL.
+type nineServerLContext struct {
+	*nineServerDotuContext
+	l NineServerL
+}
+
+func (n *nineServerLContext) Rstatfs(_ context.Context, fid FID) (StatFS, error) {
+	return n.l.Rstatfs(fid)
+}
+
+func (n *nineServerLContext) Rlopen(_ context.Context, fid FID, flags uint32) (QID, MaxSize, error) {
+	return n.l.Rlopen(fid, flags)
+}
+
+func (n *nineServerLContext) Rlcreate(_ context.Context, fid FID, name string, flags uint32, mode uint32, gid uint32) (QID, MaxSize, error) {
+	return n.l.Rlcreate(fid, name, flags, mode, gid)
+}
+
+func (n *nineServerLContext) Rsymlink(_ context.Context, fid FID, name string, target string, gid uint32) (QID, error) {
+	return n.l.Rsymlink(fid, name, target, gid)
+}
+
+func (n *nineServerLContext) Rmknod(_ context.Context, dfid FID, name string, mode uint32, major uint32, minor uint32, gid uint32) (QID, error) {
+	return n.l.Rmknod(dfid, name, mode, major, minor, gid)
+}
+
+func (n *nineServerLContext) Rrename(_ context.Context, fid FID, dfid FID, name string) error {
+	return n.l.Rrename(fid, dfid, name)
+}
+
+func (n *nineServerLContext) Rreadlink(_ context.Context, fid FID) (string, error) {
+	return n.l.Rreadlink(fid)
+}
+
+func (n *nineServerLContext) Rgetattr(_ context.Context, fid FID, mask uint64) (Attr, error) {
+	return n.l.Rgetattr(fid, mask)
+}
+
+func (n *nineServerLContext) Rsetattr(_ context.Context, fid FID, s SetAttr) error {
+	return n.l.Rsetattr(fid, s)
+}
+
+func (n *nineServerLContext) Rxattrwalk(_ context.Context, fid FID, newfid FID, name string) (uint64, error) {
+	return n.l.Rxattrwalk(fid, newfid, name)
+}
+
+func (n *nineServerLContext) Rxattrcreate(_ context.Context, fid FID, name string, size uint64, flags uint32) error {
+	return n.l.Rxattrcreate(fid, name, size, flags)
+}
+
+func (n *nineServerLContext) Rreaddir(_ context.Context, fid FID, o Offset, c Count) ([]byte, error) {
+	return n.l.Rreaddir(fid, o, c)
+}
+
+func (n *nineServerLContext) Rfsync(_ context.Context, fid FID, datasync uint32) error {
+	return n.l.Rfsync(fid, datasync)
+}
+
+func (n *nineServerLContext) Rlock(_ context.Context, fid FID, typ uint8, flags uint32, start uint64, length uint64, procid uint32, clientid string) (uint8, error) {
+	return n.l.Rlock(fid, typ, flags, start, length, procid, clientid)
+}
+
+func (n *nineServerLContext) Rgetlock(_ context.Context, fid FID, typ uint8, start uint64, length uint64, procid uint32, clientid string) (uint8, uint64, uint64, uint32, string, error) {
+	return n.l.Rgetlock(fid, typ, start, length, procid, clientid)
+}
+
+func (n *nineServerLContext) Rlink(_ context.Context, dfid FID, fid FID, name string) error {
+	return n.l.Rlink(dfid, fid, name)
+}
+
+func (n *nineServerLContext) Rmkdir(_ context.Context, dfid FID, name string, mode uint32, gid uint32) (QID, error) {
+	return n.l.Rmkdir(dfid, name, mode, gid)
+}
+
+func (n *nineServerLContext) Rrenameat(_ context.Context, olddfid FID, oldname string, newdfid FID, newname string) error {
+	return n.l.Rrenameat(olddfid, oldname, newdfid, newname)
+}
+
+func (n *nineServerLContext) Runlinkat(_ context.Context, dfid FID, name string, flags uint32) error {
+	return n.l.Runlinkat(dfid, name, flags)
+}
+
 var (
 	RPCNames = map[MType]string{
 		Tversion: "Tversion",
@@ -339,5 +1005,46 @@ var (
 		Rstat:    "Rstat",
 		Twstat:   "Twstat",
 		Rwstat:   "Rwstat",
+
+		Tlerror:      "Tlerror",
+		Rlerror:      "Rlerror",
+		Tstatfs:      "Tstatfs",
+		Rstatfs:      "Rstatfs",
+		Tlopen:       "Tlopen",
+		Rlopen:       "Rlopen",
+		Tlcreate:     "Tlcreate",
+		Rlcreate:     "Rlcreate",
+		Tsymlink:     "Tsymlink",
+		Rsymlink:     "Rsymlink",
+		Tmknod:       "Tmknod",
+		Rmknod:       "Rmknod",
+		Trename:      "Trename",
+		Rrename:      "Rrename",
+		Treadlink:    "Treadlink",
+		Rreadlink:    "Rreadlink",
+		Tgetattr:     "Tgetattr",
+		Rgetattr:     "Rgetattr",
+		Tsetattr:     "Tsetattr",
+		Rsetattr:     "Rsetattr",
+		Txattrwalk:   "Txattrwalk",
+		Rxattrwalk:   "Rxattrwalk",
+		Txattrcreate: "Txattrcreate",
+		Rxattrcreate: "Rxattrcreate",
+		Treaddir:     "Treaddir",
+		Rreaddir:     "Rreaddir",
+		Tfsync:       "Tfsync",
+		Rfsync:       "Rfsync",
+		Tlock:        "Tlock",
+		Rlock:        "Rlock",
+		Tgetlock:     "Tgetlock",
+		Rgetlock:     "Rgetlock",
+		Tlink:        "Tlink",
+		Rlink:        "Rlink",
+		Tmkdir:       "Tmkdir",
+		Rmkdir:       "Rmkdir",
+		Trenameat:    "Trenameat",
+		Rrenameat:    "Rrenameat",
+		Tunlinkat:    "Tunlinkat",
+		Runlinkat:    "Runlinkat",
 	}
 )
@@ -6,11 +6,23 @@ package protocol
 
 import (
 	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"iter"
 	"net"
 	"os"
 	"reflect"
+	"strings"
+	"sync"
+	"syscall"
 	"testing"
+	"testing/iotest"
+	"time"
 )
 
 var (
@@ -62,6 +74,36 @@ func TestEncode(t *testing.T) {
 			[]byte{19, 0, 0, 0, 101, 0xaa, 0x55, 0, 32, 0, 0, 6, 0, 57, 80, 50, 48, 48, 48},
 			func(b *bytes.Buffer) { MarshalRversionPkt(b, Tag(0x55aa), 8192, "9P2000") },
 		},
+		{
+			"Tauth tag 1 afid 2 uname glenda aname ''",
+			[]byte{21, 0, 0, 0, 102, 1, 0, 2, 0, 0, 0, 6, 0, 103, 108, 101, 110, 100, 97, 0, 0},
+			func(b *bytes.Buffer) { MarshalTauthPkt(b, Tag(1), 2, "glenda", "") },
+		},
+		{
+			"9P2000.u Tattach tag 1 fid 48 afid -1 uname rminnich nuname 1000 aname ''",
+			[]byte{31, 0, 0, 0, 104, 1, 0, 48, 0, 0, 0, 255, 255, 255, 255, 8, 0, 114, 109, 105, 110, 110, 105, 99, 104, 0, 0, 232, 3, 0, 0},
+			func(b *bytes.Buffer) { MarshalTattachDotuPkt(b, Tag(1), 48, NOFID, "rminnich", "", 1000) },
+		},
+		{
+			"9P2000.u Rerror tag 1 ename 'no such file' errno 2",
+			[]byte{25, 0, 0, 0, 107, 1, 0, 12, 0, 110, 111, 32, 115, 117, 99, 104, 32, 102, 105, 108, 101, 2, 0, 0, 0},
+			func(b *bytes.Buffer) { MarshalRerrorDotuPkt(b, Tag(1), "no such file", ENOENT) },
+		},
+		{
+			"9P2000.L Tlopen tag 1 fid 1 flags O_RDWR",
+			[]byte{15, 0, 0, 0, 12, 1, 0, 1, 0, 0, 0, 2, 0, 0, 0},
+			func(b *bytes.Buffer) { MarshalTlopenPkt(b, Tag(1), 1, DotlRdwr) },
+		},
+		{
+			"9P2000.L Rlerror tag 1 ecode ENOENT",
+			[]byte{11, 0, 0, 0, 7, 1, 0, 2, 0, 0, 0},
+			func(b *bytes.Buffer) { MarshalRlerrorPkt(b, Tag(1), ENOENT) },
+		},
+		{
+			"9P2000.L Tunlinkat tag 2 dfid 3 name 'x' flags AT_REMOVEDIR",
+			[]byte{18, 0, 0, 0, 76, 2, 0, 3, 0, 0, 0, 1, 0, 120, 0, 2, 0, 0},
+			func(b *bytes.Buffer) { MarshalTunlinkatPkt(b, Tag(2), 3, "x", AtRemoveDir) },
+		},
 		/*
 			{
 				"Twalk tag 0 fid 0 newfid 1 to null",
@@ -176,6 +218,312 @@ func TestEncode(t *testing.T) {
 
 }
 
+func TestDirDotu(t *testing.T) {
+	d := Dir{
+		QID:       QID{Type: QTSYMLINK, Version: 1, Path: 2},
+		Mode:      DMSYMLINK | 0777,
+		Name:      "link",
+		User:      "none",
+		Group:     "none",
+		ModUser:   "none",
+		Extension: "/etc/passwd",
+		UID:       1000,
+		GID:       1001,
+		MUID:      NOUID,
+	}
+	var b bytes.Buffer
+	MarshaldirDotu(&b, d)
+	got, err := UnmarshaldirDotu(&b)
+	if err != nil {
+		t.Fatalf("UnmarshaldirDotu: want nil, got %v", err)
+	}
+	if !reflect.DeepEqual(d, got) {
+		t.Errorf("UnmarshaldirDotu: got %v, want %v", got, d)
+	}
+
+	// 9P2000 leaves the extensions off the wire.
+	Marshaldir(&b, d)
+	got, err = Unmarshaldir(&b)
+	if err != nil {
+		t.Fatalf("Unmarshaldir: want nil, got %v", err)
+	}
+	d.Extension, d.UID, d.GID, d.MUID = "", 0, 0, 0
+	if !reflect.DeepEqual(d, got) {
+		t.Errorf("Unmarshaldir: got %v, want %v", got, d)
+	}
+}
+
+func TestUnmarshaldirs(t *testing.T) {
+	var ds []Dir
+	var packed, packedDotu []byte
+	for i, n := range []string{"a", "bb", "ccc"} {
+		d := Dir{QID: QID{Path: uint64(i)}, Mode: 0644, Name: n, User: "none", Group: "none", ModUser: "none"}
+		ds = append(ds, d)
+		var b bytes.Buffer
+		Marshaldir(&b, d)
+		packed = append(packed, b.Bytes()...)
+		b.Reset()
+		MarshaldirDotu(&b, d)
+		packedDotu = append(packedDotu, b.Bytes()...)
+	}
+	got, err := Unmarshaldirs(packed)
+	if err != nil || !reflect.DeepEqual(got, ds) {
+		t.Errorf("Unmarshaldirs: want %v, nil, got %v, %v", ds, got, err)
+	}
+	got, err = UnmarshaldirsDotu(packedDotu)
+	if err != nil || !reflect.DeepEqual(got, ds) {
+		t.Errorf("UnmarshaldirsDotu: want %v, nil, got %v, %v", ds, got, err)
+	}
+	if got, err := Unmarshaldirs(nil); err != nil || len(got) != 0 {
+		t.Errorf("Unmarshaldirs(nil): want [], nil, got %v, %v", got, err)
+	}
+
+	// A truncated entry is an error, after the whole ones.
+	got, err = Unmarshaldirs(packed[:len(packed)-3])
+	if err == nil || !reflect.DeepEqual(got, ds[:2]) {
+		t.Errorf("Unmarshaldirs of truncated data: want %v, error, got %v, %v", ds[:2], got, err)
+	}
+}
+
+func TestDirReader(t *testing.T) {
+	var ds []Dir
+	for i := 0; i < 100; i++ {
+		ds = append(ds, Dir{QID: QID{Path: uint64(i)}, Mode: 0644, Name: fmt.Sprintf("f%d", i), User: "none", Group: "none", ModUser: "none"})
+	}
+	var (
+		lists int
+		boom  = fmt.Errorf("boom")
+		fail  = -1
+	)
+	r := NewDirReader(func() iter.Seq2[Dir, error] {
+		lists++
+		return func(yield func(Dir, error) bool) {
+			for i, d := range ds {
+				if i == fail {
+					yield(Dir{}, boom)
+					return
+				}
+				if !yield(d, nil) {
+					return
+				}
+			}
+		}
+	}, false)
+	defer r.Close()
+
+	readAll := func(c Count) ([]Dir, error) {
+		var (
+			got []Dir
+			o   Offset
+		)
+		for {
+			b, err := r.Read(o, c)
+			if err != nil {
+				return got, err
+			}
+			if len(b) == 0 {
+				return got, nil
+			}
+			if len(b) > int(c) {
+				t.Fatalf("Read(%v, %v): got %d bytes", o, c, len(b))
+			}
+			dd, err := Unmarshaldirs(b)
+			if err != nil {
+				t.Fatalf("Unmarshaldirs: want nil, got %v", err)
+			}
+			if len(dd) < 2 && len(got)+len(dd) < len(ds) {
+				t.Errorf("Read(%v, %v): want several entries, got %d", o, c, len(dd))
+			}
+			got = append(got, dd...)
+			o += Offset(len(b))
+		}
+	}
+	for _, c := range []Count{200, 1000, 8192} {
+		if got, err := readAll(c); err != nil || !reflect.DeepEqual(got, ds) {
+			t.Errorf("Reading with count %d: want %d entries, nil, got %d, %v", c, len(ds), len(got), err)
+		}
+	}
+	if lists != 3 {
+		t.Errorf("Listings: want 3, got %d", lists)
+	}
+
+	// Reads must start at 0 or where the last ended.
+	b, err := r.Read(0, 200)
+	if err != nil {
+		t.Fatalf("Read(0, 200): want nil, got %v", err)
+	}
+	if _, err := r.Read(Offset(len(b)+1), 200); err == nil {
+		t.Errorf("Read at a bad offset: want error, got nil")
+	}
+	if _, err := r.Read(Offset(len(b)), 10); err == nil {
+		t.Errorf("Read with a count too small for an entry: want error, got nil")
+	}
+	if b2, err := r.Read(Offset(len(b)), 200); err != nil || len(b2) == 0 {
+		t.Errorf("Read at the end of the last read: want data, nil, got %d bytes, %v", len(b2), err)
+	}
+	if _, err := NewDirReader(nil, false).Read(10, 200); err == nil {
+		t.Errorf("First Read at 10: want error, got nil")
+	}
+
+	// An error from the listing comes after the entries before it.
+	fail = 50
+	if got, err := readAll(8192); err != boom || !reflect.DeepEqual(got, ds[:50]) {
+		t.Errorf("Reading a failing listing: want %d entries, boom, got %d, %v", 50, len(got), err)
+	}
+}
+
+func TestDirHelpers(t *testing.T) {
+	mt := time.Date(2009, time.November, 10, 23, 4, 5, 0, time.UTC)
+	d := Dir{
+		Type:    'M',
+		Dev:     3,
+		QID:     QID{Type: QTDIR, Path: 1},
+		Mode:    DMDIR | DMEXCL | 0755,
+		Length:  1024,
+		Name:    "name",
+		User:    "glenda",
+		Group:   "sys",
+		ModUser: "glenda",
+	}
+	d.SetModTime(mt)
+	d.SetAccessTime(mt.Add(time.Hour))
+	if !d.ModTime().Equal(mt) || !d.AccessTime().Equal(mt.Add(time.Hour)) {
+		t.Errorf("ModTime, AccessTime: want %v, %v, got %v, %v", mt, mt.Add(time.Hour), d.ModTime(), d.AccessTime())
+	}
+	want := "dlrwxr-xr-x M 3 glenda sys 1024 " + mt.Local().Format("Jan _2  2006") + " name"
+	if d.String() != want {
+		t.Errorf("String: want %q, got %q", want, d.String())
+	}
+	l := Dir{Mode: DMSYMLINK | 0777, Name: "l", Extension: "/etc/passwd"}
+	if s := l.String(); !strings.HasPrefix(s, "--rwxrwxrwx - 0") || !strings.HasSuffix(s, " l -> /etc/passwd") {
+		t.Errorf("String of a symlink: got %q", s)
+	}
+
+	fi := d.FileInfo()
+	if fi.Name() != "name" || fi.Size() != 1024 || !fi.IsDir() || !fi.ModTime().Equal(mt) || fi.Mode() != fs.ModeDir|fs.ModeExclusive|0755 {
+		t.Errorf("FileInfo: got %v %v %v %v %v", fi.Name(), fi.Size(), fi.IsDir(), fi.ModTime(), fi.Mode())
+	}
+	if got := DirFromFileInfo(fi); !reflect.DeepEqual(got, d) {
+		t.Errorf("DirFromFileInfo(FileInfo()): want %v, got %v", d, got)
+	}
+
+	for _, m := range []fs.FileMode{0644, fs.ModeDir | 0755, fs.ModeSymlink | 0777, fs.ModeNamedPipe | fs.ModeSetuid | fs.ModeSetgid | fs.ModeSticky | 0600, fs.ModeAppend | fs.ModeTemporary | 0400} {
+		if got := (Dir{Mode: DirMode(m)}).FileMode(); got != m {
+			t.Errorf("FileMode(DirMode(%v)): got %v", m, got)
+		}
+	}
+	if m := DirMode(fs.ModeDevice | fs.ModeCharDevice | 0600); m != DMDEVICE|0600 {
+		t.Errorf("DirMode of a char device: want %#x, got %#x", DMDEVICE|0600, m)
+	}
+
+	f, err := ioutil.TempFile("", "dir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	f.Write([]byte("hello"))
+	f.Close()
+	st, err := os.Stat(f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	fd := DirFromFileInfo(st)
+	if fd.Name != st.Name() || fd.Length != 5 || fd.QID.Type != QTFILE || fd.FileMode() != st.Mode() || !fd.ModTime().Equal(st.ModTime().Truncate(time.Second)) || fd.UID != NOUID {
+		t.Errorf("DirFromFileInfo(%v): got %v", st, fd)
+	}
+
+	// A null Dir on the wire is all ~0 and empty strings.
+	var b bytes.Buffer
+	MarshaldirDotu(&b, NullDir())
+	for i, c := range b.Bytes()[2:] {
+		// The string lengths are 0.
+		if c != 0xff && c != 0 {
+			t.Fatalf("NullDir: byte %d is %#x", i+2, c)
+		}
+	}
+	nd, err := UnmarshaldirDotu(&b)
+	if err != nil || !reflect.DeepEqual(nd, NullDir()) {
+		t.Errorf("NullDir: want %v, got %v, %v", NullDir(), nd, err)
+	}
+}
+
+func TestToError(t *testing.T) {
+	pe := &Error{Err: "mine", Errno: eBUSY}
+	notExist := &fs.PathError{Op: "open", Path: "/x/y", Err: eNOENT}
+	for _, tt := range []struct {
+		err   error
+		s     string
+		errno syscall.Errno
+	}{
+		// A message with more to say than its errno is kept.
+		{notExist, "open /x/y: no such file or directory", eNOENT},
+		{fmt.Errorf("wrapped: %w", notExist), "wrapped: open /x/y: no such file or directory", eNOENT},
+		{fmt.Errorf("wrapped: %w", fs.ErrPermission), "wrapped: permission denied", eACCES},
+		{fs.ErrNotExist, "file does not exist", eNOENT},
+		{eNOENT, "file does not exist", eNOENT},
+		{ePERM, "permission denied", ePERM},
+		{fmt.Errorf("ENOENT"), "ENOENT", eIO},
+		{fmt.Errorf("Is A Directory"), "is a directory", eISDIR},
+		{fmt.Errorf("no such file or directory"), "file does not exist", eNOENT},
+		{fmt.Errorf("something broke"), "something broke", eIO},
+		{fmt.Errorf("wrapped: %w", pe), "mine", eBUSY},
+	} {
+		e := ToError(tt.err)
+		if e.Err != tt.s || e.Errno != tt.errno {
+			t.Errorf("ToError(%v): want %q, %v, got %q, %v", tt.err, tt.s, tt.errno, e.Err, e.Errno)
+		}
+	}
+
+	// Errors from the wire are matched by errno. 9P2000 has none, so
+	// only the Plan 9 messages have one.
+	for _, tt := range []struct {
+		name string
+		e    *Error
+		is   bool
+	}{
+		{"9P2000.u wrapped PathError", newError(ToError(fmt.Errorf("wrapped: %w", notExist)).Err, eNOENT), true},
+		{"9P2000 ErrNotExist", newError(ToError(fs.ErrNotExist).Err, 0), true},
+		{"9P2000.u other message", newError("anything", eNOENT), true},
+		{"9P2000 wrapped PathError", newError(ToError(fmt.Errorf("wrapped: %w", notExist)).Err, 0), false},
+		{"9P2000 Unix message", newError("no such file or directory", 0), false},
+		{"9P2000 message in another case", newError("File Does Not Exist", 0), false},
+		{"9P2000 message with no errno", newError("something broke", 0), false},
+	} {
+		if is := errors.Is(tt.e, fs.ErrNotExist) && errors.Is(tt.e, eNOENT); is != tt.is {
+			t.Errorf("%v: %q is ErrNotExist and ENOENT: want %v, got %v", tt.name, tt.e, tt.is, is)
+		}
+		if !tt.is && tt.e.Errno != 0 {
+			t.Errorf("%v: %q: want no errno, got %v", tt.name, tt.e, tt.e.Errno)
+		}
+	}
+}
+
+func TestDirent(t *testing.T) {
+	ds := []Dirent{
+		{QID: QID{Type: QTDIR, Version: 1, Path: 2}, Offset: 1, Type: 4, Name: "."},
+		{QID: QID{Type: QTFILE, Version: 3, Path: 0x0102030405060708}, Offset: 2, Type: 8, Name: "passwd"},
+	}
+	var b bytes.Buffer
+	for _, d := range ds {
+		MarshalDirent(&b, d)
+	}
+	if b.Len() != DirentLen(ds[0])+DirentLen(ds[1]) {
+		t.Errorf("MarshalDirent: got %d bytes, want %d", b.Len(), DirentLen(ds[0])+DirentLen(ds[1]))
+	}
+	for _, d := range ds {
+		got, err := UnmarshalDirent(&b)
+		if err != nil {
+			t.Fatalf("UnmarshalDirent: want nil, got %v", err)
+		}
+		if !reflect.DeepEqual(d, got) {
+			t.Errorf("UnmarshalDirent: got %v, want %v", got, d)
+		}
+	}
+	if _, err := UnmarshalDirent(&b); err == nil {
+		t.Errorf("UnmarshalDirent of empty buffer: want err, got nil")
+	}
+}
+
 /*
 func testDecode(t *testing.T) {
 	var tests = []struct {
@@ -200,6 +548,106 @@ func testDecode(t *testing.T) {
 }
 */
 
+func TestAppendDecode(t *testing.T) {
+	// Append adds to what is there.
+	b := AppendTwalkPkt([]byte("x"), 7, 1, 2, []string{"a", "bc"})
+	if b[0] != 'x' {
+		t.Fatalf("AppendTwalkPkt: lost the prefix, got %q", b)
+	}
+	var m bytes.Buffer
+	MarshalTwalkPkt(&m, 7, 1, 2, []string{"a", "bc"})
+	if !bytes.Equal(b[1:], m.Bytes()) {
+		t.Fatalf("AppendTwalkPkt: got %v, want %v", b[1:], m.Bytes())
+	}
+	p := b[1+5:]
+	f, nf, paths, tag, err := DecodeTwalkPkt(p)
+	if err != nil || f != 1 || nf != 2 || !reflect.DeepEqual(paths, []string{"a", "bc"}) || tag != 7 {
+		t.Fatalf("DecodeTwalkPkt: got %v, %v, %v, %v, %v", f, nf, paths, tag, err)
+	}
+	// Any less is too short, and any more too long.
+	for i := range p {
+		if _, _, _, _, err := DecodeTwalkPkt(p[:i]); err == nil {
+			t.Errorf("DecodeTwalkPkt of %d of %d bytes: want error, got nil", i, len(p))
+		}
+	}
+	if _, _, _, _, err := DecodeTwalkPkt(append(p, 0)); err == nil {
+		t.Errorf("DecodeTwalkPkt with a byte left over: want error, got nil")
+	}
+
+	// Data is not copied.
+	p = AppendRreadPkt(nil, 1, []byte("data"))[5:]
+	data, _, err := DecodeRreadPkt(p)
+	if err != nil || string(data) != "data" || &data[0] != &p[6] {
+		t.Errorf("DecodeRreadPkt: got %q, %v, want data in the packet", data, err)
+	}
+}
+
+func TestMsg(t *testing.T) {
+	var b bytes.Buffer
+	for _, m := range msgSamples {
+		if err := WriteMsg(&b, 7, m); err != nil {
+			t.Fatalf("WriteMsg(%v): want nil, got %v", m, err)
+		}
+	}
+	for _, m := range msgSamplesDotu {
+		if err := WriteMsgDotu(&b, 8, m); err != nil {
+			t.Fatalf("WriteMsgDotu(%v): want nil, got %v", m, err)
+		}
+	}
+	for i, want := range append(msgSamples, dotuMsgs(msgSamplesDotu)...) {
+		tag, m, err := ReadMsg(&b)
+		if err != nil {
+			t.Fatalf("ReadMsg of %v: want nil, got %v", want, err)
+		}
+		if wt := Tag(7 + i/len(msgSamples)); tag != wt || !reflect.DeepEqual(m, want) {
+			t.Errorf("ReadMsg: want %v, %v, got %v, %v", wt, want, tag, m)
+		}
+		if m.Type() != want.Type() || !strings.HasPrefix(m.String()+" ", RPCNames[want.Type()]+" ") {
+			t.Errorf("%v: got type %v, String %q", want, m.Type(), m.String())
+		}
+	}
+	if _, _, err := ReadMsg(&b); err != io.EOF {
+		t.Errorf("ReadMsg at the end: want EOF, got %v", err)
+	}
+
+	// Encode appends, and Decode checks the type and size.
+	w := &TwalkPkt{SFID: 1, NewFID: 2, Paths: []string{"a"}}
+	p := w.Encode([]byte("x"), 3)[1:]
+	if got := fmt.Sprint(w); got != `Twalk SFID 1 NewFID 2 Paths ["a"]` {
+		t.Errorf("Twalk String: got %q", got)
+	}
+	var c TclunkPkt
+	if _, err := c.Decode(p); err == nil {
+		t.Errorf("TclunkPkt.Decode of a Twalk: want error, got nil")
+	}
+	var w2 TwalkPkt
+	if _, err := w2.Decode(p[:len(p)-1]); err == nil {
+		t.Errorf("TwalkPkt.Decode of a short Twalk: want error, got nil")
+	}
+	if tag, err := w2.Decode(p); err != nil || tag != 3 || !reflect.DeepEqual(&w2, w) {
+		t.Errorf("TwalkPkt.Decode: want 3, %v, nil, got %v, %v, %v", w, tag, &w2, err)
+	}
+
+	// A 9P2000 Tattach is not a 9P2000.u one.
+	a := &TattachPkt{SFID: 1, AFID: NOFID, Uname: "u", Aname: "a"}
+	if _, err := a.DecodeDotu(a.Encode(nil, 1)); err == nil {
+		t.Errorf("TattachPkt.DecodeDotu of a 9P2000 Tattach: want error, got nil")
+	}
+
+	if _, _, err := ReadMsg(bytes.NewReader([]byte{7, 0, 0, 0, 0, 1, 0})); err == nil {
+		t.Errorf("ReadMsg of an unknown type: want error, got nil")
+	}
+}
+
+// dotuMsgs returns ms as Msgs.
+func dotuMsgs(ms []DotuMsg) []Msg {
+	var r []Msg
+	for _, m := range ms {
+		r = append(r, m)
+	}
+	return r
+}
+
 func TestTags(t *testing.T) {
 	c, err := NewClient()
 	if err != nil {
@@ -312,179 +760,1669 @@ func (e *echo) Rwrite(f FID, o Offset, b []byte) (Count, error) {
 	}
 	return -1, fmt.Errorf("Write: bad FID %v", f)
 }
-func TestTManyRPCs(t *testing.T) {
+func (e *echo) RattachDotu(fid FID, afid FID, uname string, aname string, nuname uint32) (QID, error) {
+	return e.Rattach(fid, afid, uname, aname)
+}
+
+func (e *echo) RcreateDotu(fid FID, name string, perm Perm, mode Mode, ext string) (QID, MaxSize, error) {
+	return e.Rcreate(fid, name, perm, mode)
+}
+
+// echoL is echo, speaking 9P2000.L. Only FID 2 exists.
+type echoL struct {
+	*echo
+}
+
+func (e *echoL) Rversion(msize MaxSize, version string) (MaxSize, string, error) {
+	return msize, Version9P2000L, nil
+}
+
+func (e *echoL) fid(f FID) error {
+	if f != 2 {
+		return eBADF
+	}
+	return nil
+}
+
+func (e *echoL) Rstatfs(f FID) (StatFS, error) {
+	return StatFS{BSize: 4096, NameLen: 255}, e.fid(f)
+}
+func (e *echoL) Rlopen(f FID, flags uint32) (QID, MaxSize, error) {
+	return QID{Path: 2}, 8192, e.fid(f)
+}
+func (e *echoL) Rlcreate(f FID, name string, flags uint32, mode uint32, gid uint32) (QID, MaxSize, error) {
+	return QID{Path: 3}, 8192, e.fid(f)
+}
+func (e *echoL) Rsymlink(f FID, name string, target string, gid uint32) (QID, error) {
+	return QID{Type: QTSYMLINK}, e.fid(f)
+}
+func (e *echoL) Rmknod(f FID, name string, mode uint32, major uint32, minor uint32, gid uint32) (QID, error) {
+	return QID{}, ePERM
+}
+func (e *echoL) Rrename(f FID, dfid FID, name string) error {
+	return e.fid(f)
+}
+func (e *echoL) Rreadlink(f FID) (string, error) {
+	return "target", e.fid(f)
+}
+func (e *echoL) Rgetattr(f FID, mask uint64) (Attr, error) {
+	return Attr{Valid: mask & GetattrBasic, QID: QID{Path: 2}, Mode: 0100644, Size: 2, MTimeNSec: 999999999}, e.fid(f)
+}
+func (e *echoL) Rsetattr(f FID, s SetAttr) error {
+	return e.fid(f)
+}
+func (e *echoL) Rxattrwalk(f FID, newfid FID, name string) (uint64, error) {
+	return 0, syscall.Errno(61) // ENODATA, on Linux
+}
+func (e *echoL) Rxattrcreate(f FID, name string, size uint64, flags uint32) error {
+	return syscall.Errno(95) // EOPNOTSUPP, on Linux
+}
+func (e *echoL) Rreaddir(f FID, o Offset, c Count) ([]byte, error) {
+	var b bytes.Buffer
+	if o == 0 {
+		MarshalDirent(&b, Dirent{QID: QID{Path: 2}, Offset: 1, Type: 8, Name: "hi"})
+	}
+	return b.Bytes(), e.fid(f)
+}
+func (e *echoL) Rfsync(f FID, datasync uint32) error {
+	return e.fid(f)
+}
+func (e *echoL) Rlock(f FID, typ uint8, flags uint32, start uint64, length uint64, procid uint32, clientid string) (uint8, error) {
+	return LockSuccess, e.fid(f)
+}
+func (e *echoL) Rgetlock(f FID, typ uint8, start uint64, length uint64, procid uint32, clientid string) (uint8, uint64, uint64, uint32, string, error) {
+	return LockTypeUnlck, start, length, procid, clientid, e.fid(f)
+}
+func (e *echoL) Rlink(dfid FID, f FID, name string) error {
+	return e.fid(f)
+}
+func (e *echoL) Rmkdir(dfid FID, name string, mode uint32, gid uint32) (QID, error) {
+	return QID{Type: QTDIR}, e.fid(dfid)
+}
+func (e *echoL) Rrenameat(olddfid FID, oldname string, newdfid FID, newname string) error {
+	return e.fid(olddfid)
+}
+func (e *echoL) Runlinkat(dfid FID, name string, flags uint32) error {
+	return e.fid(dfid)
+}
+
+// recorder is a net.Conn which keeps what is written to it, a message at
+// a time, as the Client writes them.
+type recorder struct {
+	net.Conn
+
+	// mu guards below
+	mu   sync.Mutex
+	msgs [][]byte
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	r.mu.Lock()
+	r.msgs = append(r.msgs, append([]byte(nil), b...))
+	r.mu.Unlock()
+	return r.Conn.Write(b)
+}
+
+func (r *recorder) sent() [][]byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.msgs
+}
+
+func TestTMessagesL(t *testing.T) {
 	p, p2 := net.Pipe()
+	rec := &recorder{Conn: p}
 
 	c, err := NewClient(func(c *Client) error {
-		c.FromNet, c.ToNet = p, p
+		c.FromNet, c.ToNet = p, rec
+		c.Msize = 8192
 		return nil
-	},
-		func(c *Client) error {
-			c.Msize = 8192
-			c.Trace = print // t.Logf
-			return nil
-		})
+	})
 	if err != nil {
 		t.Fatalf("%v", err)
 	}
-	t.Logf("Client is %v", c.String())
 
-	e := newEcho()
-	s, err := NewServer(e, func(s *Server) error {
-		s.Trace = print
-		return nil
-	})
+	s, err := NewServer(&echoL{newEcho()})
 	if err != nil {
 		t.Fatalf("NewServer: want nil, got %v", err)
 	}
-
 	if err := s.Accept(p2); err != nil {
 		t.Fatalf("Accept: want nil, got %v", err)
 	}
 
-	for i := 0; i < 256*1024; i++ {
-		_, _, err := c.CallTversion(8000, "9P2000")
-		if err != nil {
-			t.Fatalf("CallTversion: want nil, got %v", err)
+	if _, v, err := c.CallTversion(8000, "9P2000.L"); err != nil || v != "9P2000.L" {
+		t.Fatalf("CallTversion: want 9P2000.L, nil, got %v, %v", v, err)
+	}
+	if _, err := c.CallTattachDotu(0, NOFID, "", "", NOUID); err != nil {
+		t.Fatalf("CallTattachDotu: want nil, got %v", err)
+	}
+	// Attach sends the 9P2000.u Tattach, and the File methods which
+	// would send 9P2000 messages fail without sending them.
+	root, err := c.Attach(NOFID, "", "")
+	if err != nil {
+		t.Fatalf("Attach: want nil, got %v", err)
+	}
+	sent := rec.sent()
+	if m := sent[len(sent)-1]; MType(m[4]) != Tattach || len(m) != 4+1+2+4+4+2+2+4 {
+		t.Errorf("Attach: want the 9P2000.u Tattach, got %v of %d bytes", RPCNames[MType(m[4])], len(m))
+	}
+	for _, tt := range []struct {
+		op  string
+		err error
+	}{
+		{"Open", func() error { _, err := root.Open("x", OREAD); return err }()},
+		{"Create", func() error { _, err := root.Create("x", 0644, OREAD); return err }()},
+		{"Stat", func() error { _, err := root.Stat(); return err }()},
+		{"Wstat", root.Wstat(NullDir())},
+	} {
+		if !errors.Is(tt.err, eNOSYS) {
+			t.Errorf("%v in 9P2000.L: want ENOSYS, got %v", tt.op, tt.err)
 		}
 	}
+	if n := len(rec.sent()); n != len(sent) {
+		t.Errorf("File methods not in 9P2000.L: want nothing sent, got %d messages", n-len(sent))
+	}
+	if _, _, err := c.CallTlopen(2, DotlRdonly); err != nil {
+		t.Fatalf("CallTlopen(2, DotlRdonly): want nil, got %v", err)
+	}
+	// Errors come back as an Rlerror, which we turn into an errno.
+	if _, _, err := c.CallTlopen(1, DotlRdonly); err != eBADF {
+		t.Fatalf("CallTlopen(1, DotlRdonly): want EBADF, got %v", err)
+	}
+	a, err := c.CallTgetattr(2, GetattrAll)
+	if err != nil {
+		t.Fatalf("CallTgetattr(2, GetattrAll): want nil, got %v", err)
+	}
+	if a.Valid != GetattrBasic || a.Size != 2 || a.MTimeNSec != 999999999 {
+		t.Errorf("CallTgetattr(2, GetattrAll): got %+v", a)
+	}
+	fs, err := c.CallTstatfs(2)
+	if err != nil || fs.BSize != 4096 || fs.NameLen != 255 {
+		t.Errorf("CallTstatfs(2): want 4096 byte blocks, 255 byte names, nil, got %+v, %v", fs, err)
+	}
+	if l, err := c.CallTreadlink(2); err != nil || l != "target" {
+		t.Errorf("CallTreadlink(2): want target, nil, got %v, %v", l, err)
+	}
+	d, err := c.CallTreaddir(2, 0, 8192)
+	if err != nil {
+		t.Fatalf("CallTreaddir(2, 0, 8192): want nil, got %v", err)
+	}
+	if ent, err := UnmarshalDirent(bytes.NewBuffer(d)); err != nil || ent.Name != "hi" {
+		t.Errorf("UnmarshalDirent: want hi, nil, got %v, %v", ent, err)
+	}
+	typ, start, length, _, id, err := c.CallTgetlock(2, LockTypeWrlck, 1, 2, 3, "me")
+	if err != nil || typ != LockTypeUnlck || start != 1 || length != 2 || id != "me" {
+		t.Errorf("CallTgetlock: got %v %v %v %v %v", typ, start, length, id, err)
+	}
+	if _, err := c.CallTmknod(2, "dev", 020666, 1, 3, 0); err != ePERM {
+		t.Errorf("CallTmknod: want EPERM, got %v", err)
+	}
+	if err := c.CallTunlinkat(2, "x", AtRemoveDir); err != nil {
+		t.Errorf("CallTunlinkat: want nil, got %v", err)
+	}
+	// Tstat is not part of 9P2000.L.
+	if _, err := c.CallTstat(2); err != eNOSYS {
+		t.Errorf("CallTstat in 9P2000.L: want ENOSYS, got %v", err)
+	}
 }
 
-func TestTMessages(t *testing.T) {
+func TestAuth(t *testing.T) {
 	p, p2 := net.Pipe()
 
 	c, err := NewClient(func(c *Client) error {
 		c.FromNet, c.ToNet = p, p
+		c.Msize = 8192
 		return nil
-	},
-		func(c *Client) error {
-			c.Msize = 8192
-			c.Trace = print // t.Logf
-			return nil
-		})
+	})
 	if err != nil {
 		t.Fatalf("%v", err)
 	}
-	t.Logf("Client is %v", c.String())
 
-	e := newEcho()
-	s, err := NewServer(e, func(s *Server) error {
-		s.Trace = print // t.Logf
-		s.NS = e
+	secret := []byte("open sesame")
+	s, err := NewServer(newEcho(), func(s *Server) error {
+		s.Auth = NewSharedSecret(secret)
 		return nil
 	})
-
 	if err != nil {
 		t.Fatalf("NewServer: want nil, got %v", err)
 	}
-
 	if err := s.Accept(p2); err != nil {
 		t.Fatalf("Accept: want nil, got %v", err)
 	}
 
-	// If things really go to hell, change this to true.
-	if false {
-		m, v, err := c.CallTversion(8000, "9P2000")
-		if err != nil {
-			t.Fatalf("CallTversion: want nil, got %v", err)
-		}
-		t.Logf("CallTversion: msize %v version %v", m, v)
-		t.Fatalf("Quit early")
+	if _, _, err := c.CallTversion(8000, "9P2000"); err != nil {
+		t.Fatalf("CallTversion: want nil, got %v", err)
 	}
-
-	m, v, err := c.CallTversion(8000, "9p3000")
-	if err == nil {
-		t.Fatalf("CallTversion: want err, got nil")
+	if _, err := c.CallTattach(0, NOFID, "glenda", ""); err == nil {
+		t.Fatalf("CallTattach without auth: want err, got nil")
 	}
-	t.Logf("CallTversion: wanted an error and got %v", err)
 
-	m, v, err = c.CallTversion(8000, "9P2000")
+	q, err := c.CallTauth(1, "glenda", "")
 	if err != nil {
-		t.Fatalf("CallTversion: want nil, got %v", err)
+		t.Fatalf("CallTauth: want nil, got %v", err)
 	}
-	t.Logf("CallTversion: msize %v version %v", m, v)
-
-	t.Logf("Server is %v", s.String())
-	a, err := c.CallTattach(0, 0, "", "")
-	if err != nil {
-		t.Fatalf("CallTattach: want nil, got %v", err)
+	if q.Type != QTAUTH {
+		t.Errorf("CallTauth: want QTAUTH qid, got %v", q)
 	}
-	t.Logf("Attach is %v", a)
-	w, err := c.CallTwalk(0, 1, []string{"hi", "there"})
-	// There should never be an error. The indication of a failed walk is that
-	// the number of QIDS does not match.
-	if err != nil {
-		t.Fatalf("CallTwalk(0,1,[\"hi\", \"there\"]): want nil, got %v", err)
+	if _, err := c.CallTattach(0, 1, "glenda", ""); err == nil {
+		t.Fatalf("CallTattach before auth is done: want err, got nil")
 	}
-	if len(w) != 0 {
-		t.Fatalf("CallTwalk(0,1,[\"hi\", \"there\"]): want 0 QIDS, got  back %d", len(w))
+	if err := c.AuthSharedSecret(1, "glenda", "", secret); err != nil {
+		t.Fatalf("AuthSharedSecret: want nil, got %v", err)
+	}
+	if _, err := c.CallTattach(0, 1, "bootes", ""); err == nil {
+		t.Fatalf("CallTattach as another user: want err, got nil")
+	}
+	if _, err := c.CallTattach(0, 1, "glenda", ""); err != nil {
+		t.Fatalf("CallTattach: want nil, got %v", err)
 	}
-	t.Logf("Walk is %v", w)
+
+	// An auth fid and the NineServer's fids are kept apart.
+	if _, err := c.CallTattach(1, 1, "glenda", ""); err == nil {
+		t.Errorf("CallTattach to the auth fid: want err, got nil")
+	}
+	if _, err := c.CallTwalk(0, 1, []string{"null"}); err == nil {
+		t.Errorf("CallTwalk to the auth fid: want err, got nil")
+	}
+	for _, afid := range []FID{0, 1, NOFID} {
+		if _, err := c.CallTauth(afid, "glenda", ""); err == nil {
+			t.Errorf("CallTauth(%v): want err, got nil", afid)
+		}
+	}
+	if _, err := c.CallTwalk(0, 2, []string{"null"}); err != nil {
+		t.Fatalf("CallTwalk(0, 2): want nil, got %v", err)
+	}
+	if _, err := c.CallTauth(2, "glenda", ""); err == nil {
+		t.Errorf("CallTauth of walked fid 2: want err, got nil")
+	}
+	if err := c.CallTclunk(1); err != nil {
+		t.Fatalf("CallTclunk(1): want nil, got %v", err)
+	}
+
+	// The wrong secret gets nowhere.
+	if _, err := c.CallTauth(1, "glenda", ""); err != nil {
+		t.Fatalf("CallTauth: want nil, got %v", err)
+	}
+	if err := c.AuthSharedSecret(1, "glenda", "", []byte("open barley")); err == nil {
+		t.Fatalf("AuthSharedSecret with wrong secret: want err, got nil")
+	}
+	if _, err := c.CallTattach(0, 1, "glenda", ""); err == nil {
+		t.Fatalf("CallTattach after failed auth: want err, got nil")
+	}
+}
+
+// blocker is an echo whose reads of fid 1 wait for a read of fid 2.
+type blocker struct {
+	*echo
+	unblock chan struct{}
+}
+
+func (e *blocker) Rread(f FID, o Offset, c Count) ([]byte, error) {
+	switch f {
+	case 1:
+		<-e.unblock
+		return []byte("slow"), nil
+	case 2:
+		close(e.unblock)
+	}
+	return e.echo.Rread(f, o, c)
+}
+
+func TestConcurrentRequests(t *testing.T) {
+	p, p2 := net.Pipe()
+
+	c, err := NewClient(func(c *Client) error {
+		c.FromNet, c.ToNet = p, p
+		c.Msize = 8192
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	s, err := NewServer(&blocker{echo: newEcho(), unblock: make(chan struct{})}, func(s *Server) error {
+		s.MaxRequests = 2
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("NewServer: want nil, got %v", err)
+	}
+	if err := s.Accept(p2); err != nil {
+		t.Fatalf("Accept: want nil, got %v", err)
+	}
+
+	// A serial server would never see the read of fid 2.
+	var wg sync.WaitGroup
+	errs := make(chan error, 2)
+	for _, f := range []FID{1, 2} {
+		wg.Add(1)
+		go func(f FID) {
+			defer wg.Done()
+			if _, err := c.CallTread(f, 0, 10); err != nil {
+				errs <- fmt.Errorf("CallTread(%v, 0, 10): want nil, got %v", f, err)
+			}
+		}(f)
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Reads did not finish: requests are not concurrent")
+	}
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+// flushee is a NineServerContext whose reads wait to be flushed.
+type flushee struct {
+	NineServerContext
+	started  chan struct{}
+	finished chan error
+}
+
+func (e *flushee) Rread(ctx context.Context, f FID, o Offset, c Count) ([]byte, error) {
+	close(e.started)
+	<-ctx.Done()
+	e.finished <- ctx.Err()
+	return []byte("too late"), nil
+}
+
+func TestFlush(t *testing.T) {
+	p, p2 := net.Pipe()
+
+	e := &flushee{NineServerContext: WithContext(newEcho()), started: make(chan struct{}), finished: make(chan error, 1)}
+	s, err := NewServerContext(e)
+	if err != nil {
+		t.Fatalf("NewServerContext: want nil, got %v", err)
+	}
+	if err := s.Accept(p2); err != nil {
+		t.Fatalf("Accept: want nil, got %v", err)
+	}
+
+	var b bytes.Buffer
+	MarshalTreadPkt(&b, 5, 2, 0, 10)
+	if _, err := p.Write(b.Bytes()); err != nil {
+		t.Fatalf("Writing Tread: want nil, got %v", err)
+	}
+	<-e.started
+	MarshalTflushPkt(&b, 6, 5)
+	if _, err := p.Write(b.Bytes()); err != nil {
+		t.Fatalf("Writing Tflush: want nil, got %v", err)
+	}
+
+	// The Rread is dropped, and the Rflush comes once it is done.
+	r := make([]byte, 7)
+	if _, err := io.ReadFull(p, r); err != nil {
+		t.Fatalf("Reading reply: want nil, got %v", err)
+	}
+	if MType(r[4]) != Rflush || Tag(r[5])|Tag(r[6])<<8 != 6 {
+		t.Errorf("Reply: want Rflush with tag 6, got %v with tag %v", RPCNames[MType(r[4])], Tag(r[5])|Tag(r[6])<<8)
+	}
+	select {
+	case err := <-e.finished:
+		if err != context.Canceled {
+			t.Errorf("Flushed read: want context.Canceled, got %v", err)
+		}
+	default:
+		t.Errorf("Rflush came before the flushed read finished")
+	}
+}
+
+// flusheeL is a NineServerLContext whose readdirs wait to be flushed.
+type flusheeL struct {
+	NineServerLContext
+	started  chan struct{}
+	finished chan error
+}
+
+func (e *flusheeL) Rreaddir(ctx context.Context, f FID, o Offset, c Count) ([]byte, error) {
+	close(e.started)
+	<-ctx.Done()
+	e.finished <- ctx.Err()
+	return nil, ctx.Err()
+}
+
+func TestFlushL(t *testing.T) {
+	p, p2 := net.Pipe()
+	defer p.Close()
+
+	e := &flusheeL{NineServerLContext: WithContext(&echoL{newEcho()}).(NineServerLContext), started: make(chan struct{}), finished: make(chan error, 1)}
+	s, err := NewServerContext(e)
+	if err != nil {
+		t.Fatalf("NewServerContext: want nil, got %v", err)
+	}
+	if err := s.Accept(p2); err != nil {
+		t.Fatalf("Accept: want nil, got %v", err)
+	}
+
+	var b bytes.Buffer
+	MarshalTversionPkt(&b, NOTAG, 8192, Version9P2000L)
+	if _, err := p.Write(b.Bytes()); err != nil {
+		t.Fatalf("Writing Tversion: want nil, got %v", err)
+	}
+	r, err := NewFramer(p, nil).ReadFrame(8192)
+	if err != nil || MType(r[4]) != Rversion {
+		t.Fatalf("Reading Rversion: want Rversion, nil, got %v, %v", r, err)
+	}
+	MarshalTreaddirPkt(&b, 5, 2, 0, 100)
+	if _, err := p.Write(b.Bytes()); err != nil {
+		t.Fatalf("Writing Treaddir: want nil, got %v", err)
+	}
+	<-e.started
+	MarshalTflushPkt(&b, 6, 5)
+	if _, err := p.Write(b.Bytes()); err != nil {
+		t.Fatalf("Writing Tflush: want nil, got %v", err)
+	}
+
+	// A 9P2000.L request is cancelled by the flush, as a 9P2000 one is.
+	r = make([]byte, 7)
+	if _, err := io.ReadFull(p, r); err != nil {
+		t.Fatalf("Reading reply: want nil, got %v", err)
+	}
+	if MType(r[4]) != Rflush || Tag(r[5])|Tag(r[6])<<8 != 6 {
+		t.Errorf("Reply: want Rflush with tag 6, got %v with tag %v", RPCNames[MType(r[4])], Tag(r[5])|Tag(r[6])<<8)
+	}
+	select {
+	case err := <-e.finished:
+		if err != context.Canceled {
+			t.Errorf("Flushed readdir: want context.Canceled, got %v", err)
+		}
+	default:
+		t.Errorf("Rflush came before the flushed readdir finished")
+	}
+}
+
+func TestFlushSelf(t *testing.T) {
+	p, p2 := net.Pipe()
+	defer p.Close()
+	s, err := NewServer(newEcho())
+	if err != nil {
+		t.Fatalf("NewServer: want nil, got %v", err)
+	}
+	if err := s.Accept(p2); err != nil {
+		t.Fatalf("Accept: want nil, got %v", err)
+	}
+
+	// A Tflush of itself, or of nothing, is answered at once.
+	var b bytes.Buffer
+	for _, old := range []Tag{6, 9} {
+		MarshalTflushPkt(&b, 6, old)
+		if _, err := p.Write(b.Bytes()); err != nil {
+			t.Fatalf("Writing Tflush: want nil, got %v", err)
+		}
+		p.SetReadDeadline(time.Now().Add(10 * time.Second))
+		r := make([]byte, 7)
+		if _, err := io.ReadFull(p, r); err != nil {
+			t.Fatalf("Reading reply to Tflush of %v: want nil, got %v", old, err)
+		}
+		if MType(r[4]) != Rflush || Tag(r[5])|Tag(r[6])<<8 != 6 {
+			t.Errorf("Reply: want Rflush with tag 6, got %v with tag %v", RPCNames[MType(r[4])], Tag(r[5])|Tag(r[6])<<8)
+		}
+	}
+}
+
+// waiter is a NineServerContext whose reads wait to be flushed.
+type waiter struct {
+	NineServerContext
+}
+
+func (e *waiter) Rread(ctx context.Context, f FID, o Offset, c Count) ([]byte, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestFlushWaiting(t *testing.T) {
+	p, p2 := net.Pipe()
+	defer p.Close()
+	s, err := NewServerContext(&waiter{WithContext(newEcho())}, func(s *Server) error {
+		s.MaxRequests = 2
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("NewServerContext: want nil, got %v", err)
+	}
+	if err := s.Accept(p2); err != nil {
+		t.Fatalf("Accept: want nil, got %v", err)
+	}
+
+	// Reads 1 and 2 hold the tokens, and 3 waits for one; the Tflushes
+	// must still get through.
+	var b bytes.Buffer
+	for tag := Tag(1); tag <= 3; tag++ {
+		MarshalTreadPkt(&b, tag, 2, 0, 10)
+		if _, err := p.Write(b.Bytes()); err != nil {
+			t.Fatalf("Writing Tread %v: want nil, got %v", tag, err)
+		}
+	}
+	go func() {
+		var b bytes.Buffer
+		for tag := Tag(1); tag <= 3; tag++ {
+			MarshalTflushPkt(&b, 10+tag, tag)
+			if _, err := p.Write(b.Bytes()); err != nil {
+				return
+			}
+		}
+	}()
+	p.SetReadDeadline(time.Now().Add(10 * time.Second))
+	got := map[Tag]bool{}
+	for len(got) < 3 {
+		r := make([]byte, 7)
+		if _, err := io.ReadFull(p, r); err != nil {
+			t.Fatalf("Reading reply: want nil, got %v", err)
+		}
+		tag := Tag(r[5]) | Tag(r[6])<<8
+		if MType(r[4]) != Rflush || tag < 11 || tag > 13 {
+			t.Fatalf("Reply: want Rflush with tag 11 to 13, got %v with tag %v", RPCNames[MType(r[4])], tag)
+		}
+		got[tag] = true
+	}
+}
+
+func TestMaxRequests(t *testing.T) {
+	p, p2 := net.Pipe()
+	defer p.Close()
+	s, err := NewServerContext(&waiter{WithContext(newEcho())}, func(s *Server) error {
+		s.MaxRequests = 2
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("NewServerContext: want nil, got %v", err)
+	}
+	if err := s.Accept(p2); err != nil {
+		t.Fatalf("Accept: want nil, got %v", err)
+	}
+
+	// Reads 1 and 2 hold the tokens, 3 and 4 wait for them, and the
+	// reader holds 5 until one is free, so 6 is not read.
+	var b bytes.Buffer
+	for tag := Tag(1); tag <= 5; tag++ {
+		MarshalTreadPkt(&b, tag, 2, 0, 10)
+		p.SetWriteDeadline(time.Now().Add(10 * time.Second))
+		if _, err := p.Write(b.Bytes()); err != nil {
+			t.Fatalf("Writing Tread %v: want nil, got %v", tag, err)
+		}
+	}
+	MarshalTreadPkt(&b, 6, 2, 0, 10)
+	p.SetWriteDeadline(time.Now().Add(100 * time.Millisecond))
+	if n, err := p.Write(b.Bytes()); n != 0 || !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("Writing Tread 6: want 0 bytes and a timeout, got %d and %v", n, err)
+	}
+}
+
+// stuck is a NineServerContext whose reads don't stop when flushed, but
+// wait for release.
+type stuck struct {
+	NineServerContext
+	started chan struct{}
+	release chan struct{}
+}
+
+func (e *stuck) Rread(ctx context.Context, f FID, o Offset, c Count) ([]byte, error) {
+	e.started <- struct{}{}
+	<-e.release
+	return nil, ctx.Err()
+}
+
+func TestMaxFlushes(t *testing.T) {
+	p, p2 := net.Pipe()
+	defer p.Close()
+	e := &stuck{NineServerContext: WithContext(newEcho()), started: make(chan struct{}, 1), release: make(chan struct{})}
+	defer close(e.release)
+	s, err := NewServerContext(e, func(s *Server) error {
+		s.MaxRequests = 2
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("NewServerContext: want nil, got %v", err)
+	}
+	if err := s.Accept(p2); err != nil {
+		t.Fatalf("Accept: want nil, got %v", err)
+	}
+
+	// Tflushes 11 and 12 wait for read 1, and the reader holds 13
+	// until one of them is done, so 14 is not read.
+	var b bytes.Buffer
+	MarshalTreadPkt(&b, 1, 2, 0, 10)
+	if _, err := p.Write(b.Bytes()); err != nil {
+		t.Fatalf("Writing Tread 1: want nil, got %v", err)
+	}
+	<-e.started
+	for tag := Tag(11); tag <= 13; tag++ {
+		MarshalTflushPkt(&b, tag, 1)
+		p.SetWriteDeadline(time.Now().Add(10 * time.Second))
+		if _, err := p.Write(b.Bytes()); err != nil {
+			t.Fatalf("Writing Tflush %v: want nil, got %v", tag, err)
+		}
+	}
+	MarshalTflushPkt(&b, 14, 1)
+	p.SetWriteDeadline(time.Now().Add(100 * time.Millisecond))
+	if n, err := p.Write(b.Bytes()); n != 0 || !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("Writing Tflush 14: want 0 bytes and a timeout, got %d and %v", n, err)
+	}
+}
+
+func TestFlushPadded(t *testing.T) {
+	p, p2 := net.Pipe()
+	defer p.Close()
+	s, err := NewServerContext(&waiter{WithContext(newEcho())})
+	if err != nil {
+		t.Fatalf("NewServerContext: want nil, got %v", err)
+	}
+	if err := s.Accept(p2); err != nil {
+		t.Fatalf("Accept: want nil, got %v", err)
+	}
+
+	// A Tflush with more than the old tag in it is refused.
+	var b bytes.Buffer
+	MarshalTflushPkt(&b, 6, 5)
+	f := append(b.Bytes(), make([]byte, 1000)...)
+	binary.LittleEndian.PutUint32(f, uint32(len(f)))
+	p.SetDeadline(time.Now().Add(10 * time.Second))
+	if _, err := p.Write(f); err != nil {
+		t.Fatalf("Writing Tflush: want nil, got %v", err)
+	}
+	r := make([]byte, 7)
+	if _, err := io.ReadFull(p, r); err != nil {
+		t.Fatalf("Reading reply: want nil, got %v", err)
+	}
+	if MType(r[4]) != Rerror || Tag(r[5])|Tag(r[6])<<8 != 6 {
+		t.Errorf("Reply: want Rerror with tag 6, got %v with tag %v", RPCNames[MType(r[4])], Tag(r[5])|Tag(r[6])<<8)
+	}
+}
+
+// counter is an echo which records the count of the last read.
+type counter struct {
+	*echo
+	count Count
+}
+
+func (e *counter) Rread(f FID, o Offset, c Count) ([]byte, error) {
+	e.count = c
+	return e.echo.Rread(f, o, c)
+}
+
+// sessions is an echo which makes a new echo for each session.
+type sessions struct {
+	*echo
+	n int
+}
+
+func (e *sessions) NewSession() (NineServer, error) {
+	e.n++
+	return newEcho(), nil
+}
+
+func TestSessionServer(t *testing.T) {
+	e := &sessions{echo: newEcho()}
+	s, err := NewServer(e)
+	if err != nil {
+		t.Fatalf("NewServer: want nil, got %v", err)
+	}
+	p, p2 := net.Pipe()
+	defer p.Close()
+	if err := s.Accept(p2); err != nil || e.n != 1 {
+		t.Errorf("Accept: want nil and a new session, got %v, %d sessions", err, e.n)
+	}
+
+	// A NSContext of the user's would be dropped for the sessions'.
+	if _, err := NewServer(e, func(s *Server) error {
+		s.NSContext = WithContext(newEcho())
+		return nil
+	}); err == nil {
+		t.Errorf("NewServer of a SessionServer with a NSContext: want error, got nil")
+	}
+	if _, err := NewServerContext(WithContext(newEcho()), func(s *Server) error {
+		s.NS = e
+		return nil
+	}); err == nil {
+		t.Errorf("NewServerContext with a SessionServer NS: want error, got nil")
+	}
+	s = &Server{NS: e, NSContext: WithContext(newEcho()), D: Dispatch}
+	if err := s.Accept(p2); err == nil {
+		t.Errorf("Accept with a SessionServer NS and a NSContext: want error, got nil")
+	}
+}
+
+// anyVersion is a NineServerContext which agrees to any version.
+type anyVersion struct {
+	NineServerContext
+}
+
+func (e *anyVersion) Rversion(_ context.Context, msize MaxSize, version string) (MaxSize, string, error) {
+	return msize, version, nil
+}
+
+func TestVersionContext(t *testing.T) {
+	p, p2 := net.Pipe()
+	c, err := NewClient(func(c *Client) error {
+		c.FromNet, c.ToNet = p, p
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer c.Close()
+	s, err := NewServerContext(&anyVersion{WithContext(newEcho())})
+	if err != nil {
+		t.Fatalf("NewServerContext: want nil, got %v", err)
+	}
+	if err := s.Accept(p2); err != nil {
+		t.Fatalf("Accept: want nil, got %v", err)
+	}
+
+	// With no NS, there is nothing to serve 9P2000.u or 9P2000.L.
+	for _, v := range []string{Version9P2000u, Version9P2000L} {
+		if _, _, err := c.CallTversion(8192, v); err == nil || !strings.Contains(err.Error(), "only serves 9P2000") {
+			t.Errorf("CallTversion(8192, %q): want \"only serves 9P2000\" error, got %v", v, err)
+		}
+	}
+	if _, v, err := c.CallTversion(8192, Version9P2000); err != nil || v != Version9P2000 {
+		t.Errorf("CallTversion(8192, \"9P2000\"): want 9P2000, nil, got %v, %v", v, err)
+	}
+}
+
+func TestMsize(t *testing.T) {
+	p, p2 := net.Pipe()
+
+	c, err := NewClient(func(c *Client) error {
+		c.FromNet, c.ToNet = p, p
+		c.Msize = 8192
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	e := &counter{echo: newEcho()}
+	s, err := NewServer(e, func(s *Server) error {
+		s.Msize = 1024
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("NewServer: want nil, got %v", err)
+	}
+	if err := s.Accept(p2); err != nil {
+		t.Fatalf("Accept: want nil, got %v", err)
+	}
+
+	if _, _, err := c.CallTversion(100, "9P2000"); err == nil {
+		t.Errorf("CallTversion(100, \"9P2000\"): want err, got nil")
+	}
+	m, _, err := c.CallTversion(8192, "9P2000")
+	if err != nil || m != 1024 {
+		t.Fatalf("CallTversion(8192, \"9P2000\"): want 1024, nil, got %v, %v", m, err)
+	}
+	if c.Msize != 1024 {
+		t.Errorf("Client msize after CallTversion: want 1024, got %v", c.Msize)
+	}
+
+	if _, err := c.CallTread(2, 0, 1<<30); err != nil {
+		t.Fatalf("CallTread(2, 0, 1<<30): want nil, got %v", err)
+	}
+	if e.count != 1024-IOHDRSZ {
+		t.Errorf("CallTread(2, 0, 1<<30): want count %v at the server, got %v", 1024-IOHDRSZ, e.count)
+	}
+	if _, err := c.CallTwrite(2, 0, make([]byte, 1024)); err == nil {
+		t.Errorf("CallTwrite of 1024 bytes: want err, got nil")
+	}
+
+	// A message bigger than msize ends the connection.
+	p3, p4 := net.Pipe()
+	if err := s.Accept(p4); err != nil {
+		t.Fatalf("Accept: want nil, got %v", err)
+	}
+	var b bytes.Buffer
+	MarshalTwritePkt(&b, 1, 2, 0, make([]byte, 1024))
+	p3.Write(b.Bytes())
+	if _, err := p3.Read(make([]byte, 7)); err != io.EOF {
+		t.Errorf("Read after big Twrite: want EOF, got %v", err)
+	}
+}
+
+func TestTManyRPCs(t *testing.T) {
+	p, p2 := net.Pipe()
+
+	c, err := NewClient(func(c *Client) error {
+		c.FromNet, c.ToNet = p, p
+		return nil
+	},
+		func(c *Client) error {
+			c.Msize = 8192
+			c.Trace = print // t.Logf
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	t.Logf("Client is %v", c.String())
+
+	e := newEcho()
+	s, err := NewServer(e, func(s *Server) error {
+		s.Trace = print
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("NewServer: want nil, got %v", err)
+	}
+
+	if err := s.Accept(p2); err != nil {
+		t.Fatalf("Accept: want nil, got %v", err)
+	}
+
+	for i := 0; i < 256*1024; i++ {
+		_, _, err := c.CallTversion(8000, "9P2000")
+		if err != nil {
+			t.Fatalf("CallTversion: want nil, got %v", err)
+		}
+	}
+}
+
+func TestTMessages(t *testing.T) {
+	p, p2 := net.Pipe()
+
+	c, err := NewClient(func(c *Client) error {
+		c.FromNet, c.ToNet = p, p
+		return nil
+	},
+		func(c *Client) error {
+			c.Msize = 8192
+			c.Trace = print // t.Logf
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	t.Logf("Client is %v", c.String())
+
+	e := newEcho()
+	s, err := NewServer(e, func(s *Server) error {
+		s.Trace = print // t.Logf
+		s.NS = e
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("NewServer: want nil, got %v", err)
+	}
+
+	if err := s.Accept(p2); err != nil {
+		t.Fatalf("Accept: want nil, got %v", err)
+	}
+
+	// If things really go to hell, change this to true.
+	if false {
+		m, v, err := c.CallTversion(8000, "9P2000")
+		if err != nil {
+			t.Fatalf("CallTversion: want nil, got %v", err)
+		}
+		t.Logf("CallTversion: msize %v version %v", m, v)
+		t.Fatalf("Quit early")
+	}
+
+	m, v, err := c.CallTversion(8000, "9p3000")
+	if err == nil {
+		t.Fatalf("CallTversion: want err, got nil")
+	}
+	t.Logf("CallTversion: wanted an error and got %v", err)
+
+	// echo only speaks 9P2000.
+	if _, _, err = c.CallTversion(8000, "9P2000.u"); err == nil {
+		t.Fatalf("CallTversion(8000, \"9P2000.u\"): want err, got nil")
+	}
+
+	m, v, err = c.CallTversion(8000, "9P2000")
+	if err != nil {
+		t.Fatalf("CallTversion: want nil, got %v", err)
+	}
+	t.Logf("CallTversion: msize %v version %v", m, v)
+
+	t.Logf("Server is %v", s.String())
+	a, err := c.CallTattach(0, 0, "", "")
+	if err != nil {
+		t.Fatalf("CallTattach: want nil, got %v", err)
+	}
+	t.Logf("Attach is %v", a)
+	w, err := c.CallTwalk(0, 1, []string{"hi", "there"})
+	// There should never be an error. The indication of a failed walk is that
+	// the number of QIDS does not match.
+	if err != nil {
+		t.Fatalf("CallTwalk(0,1,[\"hi\", \"there\"]): want nil, got %v", err)
+	}
+	if len(w) != 0 {
+		t.Fatalf("CallTwalk(0,1,[\"hi\", \"there\"]): want 0 QIDS, got  back %d", len(w))
+	}
+	t.Logf("Walk is %v", w)
 
 	w, err = c.CallTwalk(0, 1, []string{"null"})
 	if err != nil {
-		t.Errorf("CallTwalk(0,1,\"null\"): want nil, got err %v", err)
+		t.Errorf("CallTwalk(0,1,\"null\"): want nil, got err %v", err)
+	}
+	if len(w) != 1 {
+		t.Errorf("CallTwalk(0,1,\"null\"): want 1 QIDs, got back %d", len(w))
+	}
+	t.Logf("Walk is %v", w)
+
+	q, iounit, err := c.CallTopen(1, 1)
+	if err != nil {
+		t.Fatalf("CallTopen: want nil, got %v", err)
+	}
+	t.Logf("Open is %v %v", q, iounit)
+
+	d, err := c.CallTread(FID(2), 0, 5)
+	if err != nil {
+		t.Fatalf("CallTread: want nil, got %v", err)
+	}
+	t.Logf("Read is %v", d)
+
+	_, err = c.CallTwrite(FID(2), 0, d)
+	if err != nil {
+		t.Fatalf("CallTread: want nil, got %v", err)
+	}
+	t.Logf("Read is %v", s)
+
+	if err := c.CallTclunk(FID(2)); err != nil {
+		t.Fatalf("CallTclunk: want nil, got %v", err)
+	}
+	if err := c.CallTremove(FID(1)); err == nil {
+		t.Fatalf("CallTremove: want err, got nil")
+	}
+	if err := c.CallTremove(FID(2)); err != nil {
+		t.Fatalf("CallTremove: want nil, got %v", err)
+	}
+	if err := c.CallTclunk(FID(2)); err == nil {
+		t.Fatalf("Callclunk on removed file: want err, got nil")
+	}
+	if err := c.CallTremove(FID(1)); err == nil {
+		t.Fatalf("CallTremove: want err, got nil")
+	}
+	st, err := c.CallTstat(FID(2))
+	if err != nil {
+		t.Fatalf("CallTstat: want nil, got %v", err)
+	}
+	t.Logf("Stat: Got %v", st)
+
+	if _, err := c.CallTstat(FID(1)); err == nil {
+		t.Fatalf("CallTstat: want err, got nil")
+	}
+	if err := c.CallTwstat(FID(2), []byte{}); err != nil {
+		t.Fatalf("CallTwstat: want nil, got %v", err)
+	}
+
+	if err := c.CallTwstat(FID(1), []byte{}); err == nil {
+		t.Fatalf("CallTwstat: want err, got nil")
+	}
+	if err := c.CallTflush(3); err != nil {
+		t.Fatalf("CallTflush: want nil, got %v", err)
+	}
+
+	// A Tflush never fails, even if there is nothing to flush.
+	if err := c.CallTflush(2); err != nil {
+		t.Fatalf("CallTflush: want nil, got %v", err)
+	}
+}
+
+func TestParseDialString(t *testing.T) {
+	for _, tt := range []struct {
+		s, network, address string
+	}{
+		{"tcp!host!564", "tcp", "host:564"},
+		{"tcp!host", "tcp", "host:564"},
+		{"net!host!9fs", "tcp", "host:564"},
+		{"tcp6!::1!5640", "tcp6", "[::1]:5640"},
+		{"tcp!*!5640", "tcp", ":5640"},
+		{"unix!/tmp/sock", "unix", "/tmp/sock"},
+		{"host:5640", "tcp4", "host:5640"},
+	} {
+		n, a, err := ParseDialString(tt.s, "tcp4")
+		if err != nil || n != tt.network || a != tt.address {
+			t.Errorf("ParseDialString(%q): want %v, %v, nil, got %v, %v, %v", tt.s, tt.network, tt.address, n, a, err)
+		}
+	}
+	for _, s := range []string{"udp!host!564", "unix!", "tcp!host!564!x"} {
+		if _, _, err := ParseDialString(s, "tcp"); err == nil {
+			t.Errorf("ParseDialString(%q): want err, got nil", s)
+		}
+	}
+}
+
+func TestDial(t *testing.T) {
+	e := newEcho()
+	s, err := NewServer(e)
+	if err != nil {
+		t.Fatalf("NewServer: want nil, got %v", err)
 	}
-	if len(w) != 1 {
-		t.Errorf("CallTwalk(0,1,\"null\"): want 1 QIDs, got back %d", len(w))
+	addr := "unix!" + t.TempDir() + "/sock"
+	network, a, err := ParseDialString(addr, "tcp")
+	if err != nil {
+		t.Fatal(err)
 	}
-	t.Logf("Walk is %v", w)
+	ln, err := net.Listen(network, a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go s.Serve(ln)
 
-	q, iounit, err := c.CallTopen(1, 1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	c, err := Dial(ctx, addr, func(c *Client) error {
+		c.Msize = 8192
+		return nil
+	}, WithAttach("me", ""))
 	if err != nil {
-		t.Fatalf("CallTopen: want nil, got %v", err)
+		t.Fatalf("Dial(%v): want nil, got %v", addr, err)
+	}
+	if c.Version() != Version9P2000 || c.Msize != 8192 {
+		t.Errorf("Dial(%v): want %v and msize 8192, got %v and %v", addr, Version9P2000, c.Version(), c.Msize)
+	}
+	if c.Root() == nil {
+		t.Fatalf("Dial(%v): want Root, got nil", addr)
+	}
+	if f, err := c.Root().Walk("null"); err != nil || f.QID().Path != 0xaa55 {
+		t.Errorf("Walk(null): want QID path 0xaa55, nil, got %v, %v", f, err)
 	}
-	t.Logf("Open is %v %v", q, iounit)
 
-	d, err := c.CallTread(FID(2), 0, 5)
+	if _, err := Dial(ctx, addr, WithVersion(Version9P2000u)); err == nil {
+		t.Errorf("Dial(%v) for %v: want err, got nil", addr, Version9P2000u)
+	}
+
+	// A server which never answers is given up on when ctx is done.
+	mute, err := net.Listen(network, a+".mute")
 	if err != nil {
-		t.Fatalf("CallTread: want nil, got %v", err)
+		t.Fatal(err)
 	}
-	t.Logf("Read is %v", d)
+	defer mute.Close()
+	go func() {
+		for {
+			c, err := mute.Accept()
+			if err != nil {
+				return
+			}
+			defer c.Close()
+			go io.Copy(io.Discard, c)
+		}
+	}()
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := Dial(ctx, addr+".mute"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Dial(%v) of a mute server: want %v, got %v", addr+".mute", context.DeadlineExceeded, err)
+	}
+}
 
-	_, err = c.CallTwrite(FID(2), 0, d)
+func TestClientClose(t *testing.T) {
+	p, p2 := net.Pipe()
+	c, err := NewClient(func(c *Client) error {
+		c.FromNet, c.ToNet = p, p
+		return nil
+	})
 	if err != nil {
-		t.Fatalf("CallTread: want nil, got %v", err)
+		t.Fatalf("%v", err)
 	}
-	t.Logf("Read is %v", s)
+	e := newEcho()
+	s, err := NewServer(e)
+	if err != nil {
+		t.Fatalf("NewServer: want nil, got %v", err)
+	}
+	if err := s.Accept(p2); err != nil {
+		t.Fatalf("Accept: want nil, got %v", err)
+	}
+	if _, _, err := c.CallTversion(8192, "9P2000"); err != nil {
+		t.Fatalf("CallTversion: want nil, got %v", err)
+	}
+	if c.Err() != nil {
+		t.Errorf("Err: want nil, got %v", c.Err())
+	}
+	if err := c.Close(); err != nil {
+		t.Errorf("Close: want nil, got %v", err)
+	}
+	select {
+	case <-c.Done():
+	default:
+		t.Errorf("Done: not closed after Close")
+	}
+	if !c.Dead {
+		t.Errorf("Dead: want true after Close, got false")
+	}
+	if _, err := c.CallTread(2, 0, 5); err != ErrClientClosed {
+		t.Errorf("CallTread after Close: want ErrClientClosed, got %v", err)
+	}
+	if err := c.Close(); err != nil {
+		t.Errorf("second Close: want nil, got %v", err)
+	}
+}
 
-	if err := c.CallTclunk(FID(2)); err != nil {
-		t.Fatalf("CallTclunk: want nil, got %v", err)
+func TestClientDies(t *testing.T) {
+	for _, kill := range []func(net.Conn) error{
+		// The connection goes away.
+		func(n net.Conn) error { return n.Close() },
+		// A reply with a tag nobody used.
+		func(n net.Conn) error {
+			var b bytes.Buffer
+			MarshalRclunkPkt(&b, 99)
+			_, err := n.Write(b.Bytes())
+			return err
+		},
+	} {
+		p, p2 := net.Pipe()
+		c, err := NewClient(func(c *Client) error {
+			c.FromNet, c.ToNet = p, p
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		errc := make(chan error)
+		go func() {
+			_, err := c.CallTread(2, 0, 5)
+			errc <- err
+		}()
+		// Wait for the Tread, so that the call is pending.
+		b := make([]byte, 7)
+		if _, err := io.ReadFull(p2, b); err != nil {
+			t.Fatalf("Reading Tread: %v", err)
+		}
+		io.CopyN(ioutil.Discard, p2, int64(b[0])-7)
+		if err := kill(p2); err != nil {
+			t.Fatalf("%v", err)
+		}
+		if err := <-errc; err == nil {
+			t.Errorf("pending CallTread: want err, got nil")
+		}
+		<-c.Done()
+		if c.Err() == nil {
+			t.Errorf("Err: want err, got nil")
+		}
+		if err := c.CallTclunk(2); err != c.Err() {
+			t.Errorf("CallTclunk on a dead client: want %v, got %v", c.Err(), err)
+		}
+		c.Close()
+		p2.Close()
 	}
-	if err := c.CallTremove(FID(1)); err == nil {
-		t.Fatalf("CallTremove: want err, got nil")
+}
+
+func TestClientContext(t *testing.T) {
+	p, p2 := net.Pipe()
+	c, err := NewClient(func(c *Client) error {
+		c.FromNet, c.ToNet = p, p
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
 	}
-	if err := c.CallTremove(FID(2)); err != nil {
-		t.Fatalf("CallTremove: want nil, got %v", err)
+	defer c.Close()
+	e := &flushee{NineServerContext: WithContext(newEcho()), started: make(chan struct{}), finished: make(chan error, 1)}
+	s, err := NewServerContext(e)
+	if err != nil {
+		t.Fatalf("NewServerContext: want nil, got %v", err)
 	}
-	if err := c.CallTclunk(FID(2)); err == nil {
-		t.Fatalf("Callclunk on removed file: want err, got nil")
+	if err := s.Accept(p2); err != nil {
+		t.Fatalf("Accept: want nil, got %v", err)
 	}
-	if err := c.CallTremove(FID(1)); err == nil {
-		t.Fatalf("CallTremove: want err, got nil")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-e.started
+		cancel()
+	}()
+	if _, err := c.CallTreadContext(ctx, 2, 0, 5); err != context.Canceled {
+		t.Errorf("CallTreadContext: want context.Canceled, got %v", err)
 	}
-	st, err := c.CallTstat(FID(2))
+	// The server saw the flush.
+	if err := <-e.finished; err != context.Canceled {
+		t.Errorf("Flushed read: want context.Canceled, got %v", err)
+	}
+	// Every tag is back.
+	if n := len(c.Tags); n != NumTags {
+		t.Errorf("Tags: want %d, got %d", NumTags, n)
+	}
+	if _, err := c.CallTstatContext(context.Background(), 2); err != nil {
+		t.Errorf("CallTstatContext after flush: want nil, got %v", err)
+	}
+	// A call whose context is done already isn't sent.
+	if _, err := c.CallTreadContext(ctx, 2, 0, 5); err != context.Canceled {
+		t.Errorf("CallTreadContext with done context: want context.Canceled, got %v", err)
+	}
+}
+
+func TestClientLateReply(t *testing.T) {
+	p, p2 := net.Pipe()
+	defer p2.Close()
+	c, err := NewClient(func(c *Client) error {
+		c.FromNet, c.ToNet = p, p
+		return nil
+	})
 	if err != nil {
-		t.Fatalf("CallTstat: want nil, got %v", err)
+		t.Fatalf("%v", err)
 	}
-	t.Logf("Stat: Got %v", st)
+	defer c.Close()
 
-	if _, err := c.CallTstat(FID(1)); err == nil {
-		t.Fatalf("CallTstat: want err, got nil")
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error)
+	go func() {
+		_, err := c.CallTreadContext(ctx, 2, 0, 5)
+		errc <- err
+	}()
+	f := NewFramer(p2, p2)
+	b, err := f.ReadFrame(8192)
+	if err != nil || MType(b[4]) != Tread {
+		t.Fatalf("Reading Tread: want Tread, nil, got %v, %v", b, err)
 	}
-	if err := c.CallTwstat(FID(2), []byte{}); err != nil {
-		t.Fatalf("CallTwstat: want nil, got %v", err)
+	tag := Tag(b[5]) | Tag(b[6])<<8
+	cancel()
+	b, err = f.ReadFrame(8192)
+	if err != nil || MType(b[4]) != Tflush {
+		t.Fatalf("Reading Tflush: want Tflush, nil, got %v, %v", b, err)
 	}
 
-	if err := c.CallTwstat(FID(1), []byte{}); err == nil {
-		t.Fatalf("CallTwstat: want err, got nil")
+	// The server answers the Tflush, then, wrongly, the Tread.
+	var r bytes.Buffer
+	MarshalRflushPkt(&r, Tag(b[5])|Tag(b[6])<<8)
+	if err := f.WriteFrame(r.Bytes()); err != nil {
+		t.Fatalf("Writing Rflush: %v", err)
 	}
-	if err := c.CallTflush(3); err != nil {
-		t.Fatalf("CallTflush: want nil, got %v", err)
+	if err := <-errc; err != context.Canceled {
+		t.Errorf("CallTreadContext: want context.Canceled, got %v", err)
+	}
+	MarshalRreadPkt(&r, tag, []byte("late"))
+	if err := f.WriteFrame(r.Bytes()); err != nil {
+		t.Fatalf("Writing Rread: %v", err)
+	}
+	// The client carries on.
+	go func() {
+		b, err := f.ReadFrame(8192)
+		if err != nil {
+			return
+		}
+		var r bytes.Buffer
+		MarshalRclunkPkt(&r, Tag(b[5])|Tag(b[6])<<8)
+		f.WriteFrame(r.Bytes())
+	}()
+	if err := c.CallTclunk(2); err != nil {
+		t.Errorf("CallTclunk after a late reply: want nil, got %v", err)
+	}
+	if err := c.Err(); err != nil {
+		t.Errorf("Err after a late reply: want nil, got %v", err)
+	}
+}
+
+func TestClientGo(t *testing.T) {
+	p, p2 := net.Pipe()
+	c, err := NewClient(func(c *Client) error {
+		c.FromNet, c.ToNet = p, p
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer c.Close()
+	e := newEcho()
+	s, err := NewServer(e)
+	if err != nil {
+		t.Fatalf("NewServer: want nil, got %v", err)
+	}
+	if err := s.Accept(p2); err != nil {
+		t.Fatalf("Accept: want nil, got %v", err)
+	}
+
+	// A batch, whose replies come back on one channel.
+	ctx := context.Background()
+	done := make(chan *Call, 3)
+	calls := map[*Call]string{
+		c.GoTwalk(ctx, 1, 3, []string{"null"}, done): "walk",
+		c.GoTread(ctx, 2, 0, 5, done):                "read",
+		c.GoTstat(ctx, 7, done):                      "stat",
+	}
+	for range calls {
+		call := <-done
+		switch calls[call] {
+		case "walk":
+			r, ok := call.Reply.(*TwalkReply)
+			if call.Error != nil || !ok || len(r.QIDs) != 1 || r.QIDs[0].Path != 0xaa55 {
+				t.Errorf("GoTwalk: want one QID with path 0xaa55, got %v, %v", call.Reply, call.Error)
+			}
+		case "read":
+			r, ok := call.Reply.(*TreadReply)
+			if call.Error != nil || !ok || string(r.Data) != "HI" {
+				t.Errorf("GoTread: want HI, got %v, %v", call.Reply, call.Error)
+			}
+		case "stat":
+			if call.Error == nil {
+				t.Errorf("GoTstat(7): want err, got nil")
+			}
+		default:
+			t.Fatalf("Unknown call %v", call)
+		}
+	}
+
+	// With no done channel, the Call makes its own.
+	call := <-c.GoTread(ctx, 2, 0, 5, nil).Done
+	if call.Error != nil || string(call.Reply.(*TreadReply).Data) != "HI" {
+		t.Errorf("GoTread: want HI, got %v, %v", call.Reply, call.Error)
+	}
+}
+
+// slowConn reads and writes a byte at a time, as a slow network might.
+type slowConn struct {
+	net.Conn
+}
+
+func (c slowConn) Read(b []byte) (int, error) {
+	if len(b) > 1 {
+		b = b[:1]
+	}
+	return c.Conn.Read(b)
+}
+
+func (c slowConn) Write(b []byte) (int, error) {
+	for i := range b {
+		if _, err := c.Conn.Write(b[i : i+1]); err != nil {
+			return i, err
+		}
+	}
+	return len(b), nil
+}
+
+func TestFramer(t *testing.T) {
+	var b bytes.Buffer
+	MarshalTclunkPkt(&b, 1, 2)
+	clunk := append([]byte(nil), b.Bytes()...)
+	MarshalTreadPkt(&b, 3, 4, 5, 6)
+	read := append([]byte(nil), b.Bytes()...)
+
+	// Frames come back whole, however they are split up.
+	var w bytes.Buffer
+	f := NewFramer(iotest.OneByteReader(&w), &w)
+	for _, m := range [][]byte{clunk, read} {
+		if err := f.WriteFrame(m); err != nil {
+			t.Fatalf("WriteFrame: want nil, got %v", err)
+		}
+	}
+	for _, want := range [][]byte{clunk, read} {
+		got, err := f.ReadFrame(8192)
+		if err != nil || !bytes.Equal(got, want) {
+			t.Errorf("ReadFrame: want %v, nil, got %v, %v", want, got, err)
+		}
+		f.Release(got)
+	}
+	if _, err := f.ReadFrame(8192); err != io.EOF {
+		t.Errorf("ReadFrame at EOF: want EOF, got %v", err)
+	}
+
+	for _, tt := range []struct {
+		name string
+		b    []byte
+		max  MaxSize
+	}{
+		{"too big", read, MaxSize(len(read) - 1)},
+		{"too small", []byte{6, 0, 0, 0, uint8(Tclunk), 1, 0}, 8192},
+		{"short", read[:len(read)-1], 8192},
+		{"short header", read[:5], 8192},
+	} {
+		f := NewFramer(bytes.NewReader(tt.b), nil)
+		if got, err := f.ReadFrame(tt.max); err == nil {
+			t.Errorf("ReadFrame of %v frame: want error, got %v", tt.name, got)
+		}
+	}
+
+	// Buffers are reused by size class.
+	for _, n := range []int{1, 7, 128, 129, 8192, MSIZE} {
+		b := getFrame(n)
+		if len(b) != n || cap(b) < n || cap(b) > 2*n && cap(b) > 1<<minFrameShift {
+			t.Errorf("getFrame(%d): got len %d, cap %d", n, len(b), cap(b))
+		}
+		putFrame(b)
+	}
+	if b := getFrame(1<<maxFrameShift + 1); len(b) != 1<<maxFrameShift+1 {
+		t.Errorf("getFrame of more than the largest class: got len %d", len(b))
+	}
+}
+
+func TestFragmented(t *testing.T) {
+	p, p2 := net.Pipe()
+	c, err := NewClient(func(c *Client) error {
+		c.FromNet, c.ToNet = slowConn{p}, slowConn{p}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer c.Close()
+	s, err := NewServer(newEcho())
+	if err != nil {
+		t.Fatalf("NewServer: want nil, got %v", err)
+	}
+	if err := s.Accept(p2); err != nil {
+		t.Fatalf("Accept: want nil, got %v", err)
+	}
+	if _, _, err := c.CallTversion(8192, Version9P2000); err != nil {
+		t.Fatalf("CallTversion: want nil, got %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		if b, err := c.CallTread(2, 0, 5); err != nil || string(b) != "HI" {
+			t.Fatalf("CallTread: want HI, nil, got %q, %v", b, err)
+		}
+	}
+}
+
+// readInto is an echo which reads into the Rread, filling it with the
+// low bytes of the offsets. Reads of fid 3 fail, and of fid 4 claim to have
+// read more than they were asked for.
+type readInto struct {
+	*echo
+}
+
+func (e *readInto) RreadInto(f FID, o Offset, b []byte) (int, error) {
+	switch f {
+	case 3:
+		return 0, os.ErrPermission
+	case 4:
+		return len(b) + 1, nil
+	}
+	for i := range b {
+		b[i] = uint8(o) + uint8(i)
+	}
+	return len(b) / 2, nil
+}
+
+func TestReadInto(t *testing.T) {
+	p, p2 := net.Pipe()
+	c, err := NewClient(func(c *Client) error {
+		c.FromNet, c.ToNet = p, p
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer c.Close()
+	s, err := NewServer(&readInto{echo: newEcho()}, func(s *Server) error {
+		s.Msize = 1024
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("NewServer: want nil, got %v", err)
+	}
+	if err := s.Accept(p2); err != nil {
+		t.Fatalf("Accept: want nil, got %v", err)
+	}
+	if _, _, err := c.CallTversion(8192, Version9P2000); err != nil {
+		t.Fatalf("CallTversion: want nil, got %v", err)
+	}
+
+	// The count is limited by msize before RreadInto sees it.
+	for _, tt := range []struct {
+		off   Offset
+		count Count
+		want  int
+	}{
+		{0, 0, 0},
+		{0, 1, 0},
+		{3, 100, 50},
+		{7, 1 << 30, (1024 - IOHDRSZ) / 2},
+	} {
+		b, err := c.CallTread(2, tt.off, tt.count)
+		if err != nil || len(b) != tt.want {
+			t.Errorf("CallTread(2, %d, %d): want %d bytes, nil, got %d, %v", tt.off, tt.count, tt.want, len(b), err)
+			continue
+		}
+		for i := range b {
+			if b[i] != uint8(tt.off)+uint8(i) {
+				t.Errorf("CallTread(2, %d, %d): byte %d is %d, want %d", tt.off, tt.count, i, b[i], uint8(tt.off)+uint8(i))
+				break
+			}
+		}
+	}
+	if _, err := c.CallTread(3, 0, 10); !errors.Is(err, os.ErrPermission) {
+		t.Errorf("CallTread(3, 0, 10): want %v, got %v", os.ErrPermission, err)
+	}
+	if b, err := c.CallTread(4, 0, 10); err == nil {
+		t.Errorf("CallTread(4, 0, 10) of too many bytes: want error, got %d bytes", len(b))
+	}
+}
+
+// readIntoContext is a NineServerContext whose RreadInto reads "ctx" if
+// it is given the request's context.
+type readIntoContext struct {
+	NineServerContext
+}
+
+func (e *readIntoContext) RreadInto(ctx context.Context, f FID, o Offset, b []byte) (int, error) {
+	if ctx.Value(sessionKey{}) == nil {
+		return 0, fmt.Errorf("RreadInto has no request context")
+	}
+	return copy(b, "ctx"), nil
+}
+
+func TestReadIntoContext(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		ns   NineServer
+		nsc  NineServerContext
+		want string
+	}{
+		// The NSContext's reads are used, not those of the NS.
+		{"WithContext of another NineServer", &readInto{echo: newEcho()}, WithContext(newEcho()), "HI"},
+		{"ReadIntoServerContext", &readInto{echo: newEcho()}, &readIntoContext{WithContext(newEcho())}, "ctx"},
+		{"NineServerContext only", nil, &readIntoContext{WithContext(newEcho())}, "ctx"},
+	} {
+		p, p2 := net.Pipe()
+		c, err := NewClient(func(c *Client) error {
+			c.FromNet, c.ToNet = p, p
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		defer c.Close()
+		s, err := NewServerContext(tt.nsc, func(s *Server) error {
+			s.NS = tt.ns
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("NewServerContext: want nil, got %v", err)
+		}
+		if err := s.Accept(p2); err != nil {
+			t.Fatalf("Accept: want nil, got %v", err)
+		}
+		if _, _, err := c.CallTversion(8192, Version9P2000); err != nil {
+			t.Fatalf("%v: CallTversion: want nil, got %v", tt.name, err)
+		}
+		if b, err := c.CallTread(2, 0, 10); err != nil || string(b) != tt.want {
+			t.Errorf("%v: CallTread(2, 0, 10): want %q, nil, got %q, %v", tt.name, tt.want, b, err)
+		}
+	}
+}
+
+// keepWrites is an echo which keeps the data of every Rwrite. writeFrom
+// is one which is a WriteFromServer, and keeps "from" for each RwriteFrom.
+type keepWrites struct {
+	*echo
+	mu     sync.Mutex
+	writes [][]byte
+}
+
+func (e *keepWrites) Rwrite(f FID, o Offset, b []byte) (Count, error) {
+	e.mu.Lock()
+	e.writes = append(e.writes, b)
+	e.mu.Unlock()
+	return e.echo.Rwrite(f, o, b)
+}
+
+func (e *keepWrites) kept() [][]byte {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.writes
+}
+
+type writeFrom struct {
+	keepWrites
+}
+
+func (e *writeFrom) RwriteFrom(f FID, o Offset, b []byte) (Count, error) {
+	if _, err := e.Rwrite(f, o, []byte("from")); err != nil {
+		return -1, err
 	}
+	return Count(len(b)), nil
+}
 
-	if err := c.CallTflush(2); err == nil {
-		t.Fatalf("CallTflush: want err, got nil")
+func TestWriteFrom(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		ns   interface {
+			NineServer
+			kept() [][]byte
+		}
+		from bool
+	}{
+		{"NineServer", &keepWrites{echo: newEcho()}, false},
+		{"WriteFromServer", &writeFrom{keepWrites{echo: newEcho()}}, true},
+	} {
+		p, p2 := net.Pipe()
+		c, err := NewClient(func(c *Client) error {
+			c.FromNet, c.ToNet = p, p
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		defer c.Close()
+		s, err := NewServer(tt.ns)
+		if err != nil {
+			t.Fatalf("NewServer: want nil, got %v", err)
+		}
+		if err := s.Accept(p2); err != nil {
+			t.Fatalf("Accept: want nil, got %v", err)
+		}
+		if _, _, err := c.CallTversion(8192, Version9P2000); err != nil {
+			t.Fatalf("%v: CallTversion: want nil, got %v", tt.name, err)
+		}
+		var want []string
+		for i := 0; i < 10; i++ {
+			w := strings.Repeat(string(rune('a'+i)), 100)
+			if n, err := c.CallTwrite(2, 0, []byte(w)); err != nil || n != 100 {
+				t.Fatalf("%v: CallTwrite: want 100, nil, got %v, %v", tt.name, n, err)
+			}
+			if tt.from {
+				w = "from"
+			}
+			want = append(want, w)
+		}
+		// A NineServer may keep what it was given, so later
+		// messages must not have been read into it.
+		got := tt.ns.kept()
+		if len(got) != len(want) {
+			t.Fatalf("%v: got %d writes, want %d", tt.name, len(got), len(want))
+		}
+		for i := range want {
+			if string(got[i]) != want[i] {
+				t.Errorf("%v: write %d is %q, want %q", tt.name, i, got[i], want[i])
+			}
+		}
 	}
 }
 
@@ -8,32 +8,65 @@ package protocol
 
 import (
 	"bytes"
+	"context"
+	"encoding/binary"
 	"fmt"
-	"io"
 	"net"
 	"sync"
 	"time"
 )
 
-const DefaultAddr = ":5640"
+const (
+	DefaultAddr = ":5640"
+	// DefaultMaxRequests is the default limit on the requests in
+	// progress on one connection.
+	DefaultMaxRequests = 64
+	// minMsize is the smallest msize we agree to. Anything less
+	// leaves too little room for data.
+	minMsize = 256
+)
 
 // Server is a 9p server.
-// For now it's extremely serial. But we will use a chan for replies to ensure that
-// we can go to a more concurrent one later.
+// Each connection has a goroutine which reads requests, and another which
+// writes replies. Each request is served in a goroutine of its own, so a
+// slow request does not hold up the others on its connection; the
+// NineServer must therefore be safe for concurrent use.
 type Server struct {
-	NS NineServer
-	D  Dispatcher
-
-	// TCP address to listen on, default is DefaultAddr
+	// NSContext serves the requests. NS, which NewServer makes it
+	// from, serves the messages of 9P2000.u and 9P2000.L if NSContext
+	// doesn't; see NineServerContext.
+	NS        NineServer
+	NSContext NineServerContext
+	D         Dispatcher
+
+	// Address to listen on, a Plan 9 dial string or TCP address;
+	// default is DefaultAddr
 	Addr string
 
 	// Trace function for logging
 	Trace Tracer
 
+	// Auth, if set, authenticates users before they may attach.
+	Auth Authenticator
+
+	// MaxRequests limits the requests in progress on each connection.
+	// As many again may wait for one of them to finish, and as many
+	// Tflushes may wait for what they flush; past that, the connection
+	// is not read until one does. If it is 0, DefaultMaxRequests is
+	// used.
+	MaxRequests int
+
+	// Msize is the largest msize the server agrees to. If it is 0,
+	// MSIZE is used.
+	Msize MaxSize
+
 	// mu guards below
 	mu sync.Mutex
 
 	listeners map[net.Listener]struct{}
+
+	// sess is the session for Dispatchers called without one.
+	sess *session
 }
 
 type conn struct {
@@ -43,16 +76,64 @@ type conn struct {
 	// rwc is the underlying network connection.
 	rwc net.Conn
 
+	// framer reads requests from rwc, and writes replies to it.
+	framer *Framer
+
 	// remoteAddr is rwc.RemoteAddr().String(). See note in net/http/server.go.
 	remoteAddr string
 
-	// replies
+	// sess is the connection's session.
+	sess *session
+
+	// ctx is the parent of the requests' contexts, and carries the
+	// session. cancel cancels it when the connection goes away.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// replies are written to rwc, in order, by writeReplies.
 	replies chan RPCReply
 
-	// dead is set to true when we finish reading packets.
+	// requests holds a token for each request in progress, which
+	// limits how many there are.
+	requests chan struct{}
+
+	// waiting holds a token for each request waiting for one of
+	// requests'. The reader takes it, so a client can't have more
+	// requests, and their frames, queued than that.
+	waiting chan struct{}
+
+	// flushes holds a token for each Tflush in progress. Tflushes
+	// don't take one of requests', but there may be no more of them
+	// than this.
+	flushes chan struct{}
+
+	// wg counts the requests in progress.
+	wg sync.WaitGroup
+
+	// mu guards below
+	mu sync.Mutex
+
+	// tags are the requests in progress.
+	tags map[Tag]*request
+
+	// dead is set to true when we finish reading packets, or fail to
+	// write one.
 	dead bool
 }
 
+// request is a request in progress.
+type request struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// done is closed once the request has finished, and its reply, if
+	// any, is queued.
+	done chan struct{}
+
+	// flushed is set, under conn.mu, when the request must not reply.
+	flushed bool
+}
+
 func NewServer(ns NineServer, opts ...ServerOpt) (*Server, error) {
 	s := &Server{}
 	s.NS = ns
@@ -62,15 +143,50 @@ func NewServer(ns NineServer, opts ...ServerOpt) (*Server, error) {
 			return nil, err
 		}
 	}
+	if s.NSContext == nil && s.NS != nil {
+		s.NSContext = WithContext(s.NS)
+	}
+	if err := s.checkSessions(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// NewServerContext returns a Server for a NineServerContext. It speaks
+// 9P2000.u and 9P2000.L if ns is a NineServerDotuContext or
+// NineServerLContext, or an option sets a NS which serves them.
+func NewServerContext(ns NineServerContext, opts ...ServerOpt) (*Server, error) {
+	s := &Server{}
+	s.NSContext = ns
+	s.D = Dispatch
+	for _, o := range opts {
+		if err := o(s); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.checkSessions(); err != nil {
+		return nil, err
+	}
 	return s, nil
 }
 
-func (s *Server) newConn(rwc net.Conn) *conn {
+func (s *Server) newConn(rwc net.Conn, ss *session) *conn {
+	n := s.MaxRequests
+	if n <= 0 {
+		n = DefaultMaxRequests
+	}
 	c := &conn{
-		server:  s,
-		rwc:     rwc,
-		replies: make(chan RPCReply, NumTags),
+		server:   s,
+		rwc:      rwc,
+		framer:   NewFramer(rwc, rwc),
+		sess:     ss,
+		replies:  make(chan RPCReply, n),
+		requests: make(chan struct{}, n),
+		waiting:  make(chan struct{}, n),
+		flushes:  make(chan struct{}, n),
+		tags:     make(map[Tag]*request),
 	}
+	c.ctx, c.cancel = context.WithCancel(context.WithValue(context.Background(), sessionKey{}, ss))
 
 	return c
 }
@@ -103,14 +219,19 @@ func (s *Server) closeListenersLocked() error {
 	return err
 }
 
-// ListenAndServe starts a new Listener on e.Addr and then calls serve.
+// ListenAndServe starts a new Listener on e.Addr, which is a Plan 9 dial
+// string or a TCP address, and then calls serve.
 func (s *Server) ListenAndServe() error {
 	addr := s.Addr
 	if addr == "" {
 		addr = DefaultAddr
 	}
 
-	ln, err := net.Listen("tcp", addr)
+	network, addr, err := ParseDialString(addr, "tcp")
+	if err != nil {
+		return err
+	}
+	ln, err := net.Listen(network, addr)
 	if err != nil {
 		return err
 	}
@@ -150,7 +271,8 @@ func (s *Server) Serve(ln net.Listener) error {
 		tempDelay = 0
 
 		if err := s.Accept(conn); err != nil {
-			return err
+			s.logf("ufs: Accept error: %v", err)
+			conn.Close()
 		}
 	}
 }
@@ -158,7 +280,11 @@ func (s *Server) Serve(ln net.Listener) error {
 // Accept a new connection, typically called via Serve but may be called
 // directly if there's a connection from an exotic listener.
 func (s *Server) Accept(conn net.Conn) error {
-	c := s.newConn(conn)
+	ss, err := s.newSession()
+	if err != nil {
+		return err
+	}
+	c := s.newConn(conn, ss)
 
 	go c.serve()
 	return nil
@@ -185,7 +311,9 @@ func (s *Server) logf(format string, args ...interface{}) {
 }
 
 func (c *conn) String() string {
-	return fmt.Sprintf("Dead %v %d replies pending", c.dead, len(c.replies))
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return fmt.Sprintf("Dead %v %d requests in progress", c.dead, len(c.tags))
 }
 
 func (c *conn) logf(format string, args ...interface{}) {
@@ -193,48 +321,252 @@ func (c *conn) logf(format string, args ...interface{}) {
 	c.server.logf("[%v] "+format, append([]interface{}{c.remoteAddr}, args...)...)
 }
 
+func (c *conn) isDead() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.dead
+}
+
+func (c *conn) setDead() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.dead = true
+}
+
+// start records that the request with tag t is in progress. It returns
+// nil if there is one with that tag already.
+func (c *conn) start(t Tag) *request {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.tags[t]; ok {
+		return nil
+	}
+	r := &request{done: make(chan struct{})}
+	r.ctx, r.cancel = context.WithCancel(c.ctx)
+	c.tags[t] = r
+	return r
+}
+
+// flush flushes the request with tag t, if there is one, and returns a
+// chan which is closed when it has finished.
+func (c *conn) flush(t Tag) <-chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	r, ok := c.tags[t]
+	if !ok {
+		return nil
+	}
+	r.flushed = true
+	r.cancel()
+	return r.done
+}
+
+// flushAll flushes all the requests in progress.
+func (c *conn) flushAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, r := range c.tags {
+		r.flushed = true
+		r.cancel()
+	}
+}
+
 func (c *conn) serve() {
 	if c.rwc == nil {
-		c.dead = true
+		c.setDead()
 		return
 	}
 
 	c.remoteAddr = c.rwc.RemoteAddr().String()
 
-	defer c.rwc.Close()
+	done := make(chan struct{})
+	go c.writeReplies(done)
+	defer func() {
+		// There is probably nobody to reply to, so give up on the
+		// requests in progress.
+		c.cancel()
+		c.wg.Wait()
+		close(c.replies)
+		<-done
+		c.rwc.Close()
+		if err := c.server.closeSession(c.sess); err != nil {
+			c.logf("Closing session: %v", err)
+		}
+	}()
 
 	c.logf("Starting readNetPackets")
 
-	for !c.dead {
-		l := make([]byte, 7)
-		if n, err := c.rwc.Read(l); err != nil || n < 7 {
-			c.logf("readNetPackets: short read: %v", err)
-			c.dead = true
+	for !c.isDead() {
+		// We can't tell where the next message starts if we don't
+		// read this one, so there's no recovering from a bad size.
+		f, err := c.framer.ReadFrame(c.server.maxMsize(c.ctx))
+		if err != nil {
+			c.logf("readNetPackets: %v", err)
+			c.setDead()
 			return
 		}
-		sz := int64(l[0]) + int64(l[1])<<8 + int64(l[2])<<16 + int64(l[3])<<24
-		t := MType(l[4])
-		b := bytes.NewBuffer(l[5:])
-		r := io.LimitReader(c.rwc, sz-7)
-		if _, err := io.Copy(b, r); err != nil {
-			c.logf("readNetPackets: short read: %v", err)
-			c.dead = true
+		t := MType(f[4])
+		tag := Tag(f[5]) | Tag(f[6])<<8
+		// The reply is marshaled into the same buffer, which goes back
+		// to the pool once it is written.
+		b := bytes.NewBuffer(f[5:])
+		c.logf("readNetPackets: got %v, len %d, sending to IO", RPCNames[t], len(f))
+
+		// Tversion starts a new session, so it aborts the old one's
+		// requests and waits for them, and nothing else starts until
+		// it is done.
+		if t == Tversion {
+			c.flushAll()
+			c.wg.Wait()
+			c.dispatch(c.ctx, f, b, t, tag, nil)
+			continue
+		}
+		// A Tflush has nothing in it but the old tag, so there is no
+		// need to keep a frame padded past that.
+		if t == Tflush && len(f) != 9 {
+			c.server.rerror(c.ctx, b, tag, fmt.Errorf("Tflush is %d bytes, not 9", len(f)))
+			c.replies <- RPCReply{b: b.Bytes(), frame: f}
+			continue
+		}
+		req := c.start(tag)
+		if req == nil {
+			c.server.rerror(c.ctx, b, tag, fmt.Errorf("duplicate tag %v", tag))
+			c.replies <- RPCReply{b: b.Bytes(), frame: f}
+			continue
+		}
+		// A Tflush waits for the request it flushes, which may hold
+		// the last token, so it takes one of flushes' instead. A
+		// Tflush of itself, or of a request which is not in progress,
+		// is answered at once.
+		if t == Tflush {
+			select {
+			case c.flushes <- struct{}{}:
+			case <-c.ctx.Done():
+				c.finish(tag, req)
+				c.framer.Release(f)
+				return
+			}
+			var old <-chan struct{}
+			if p := b.Bytes(); len(p) >= 4 {
+				if o := Tag(p[2]) | Tag(p[3])<<8; o != tag {
+					old = c.flush(o)
+				}
+			}
+			c.wg.Add(1)
+			go func() {
+				defer c.wg.Done()
+				defer func() { <-c.flushes }()
+				if old != nil {
+					select {
+					case <-old:
+					case <-c.ctx.Done():
+					}
+				}
+				c.dispatch(req.ctx, f, b, t, tag, req)
+			}()
+			continue
+		}
+		// The request waits for a token, rather than the reader, so
+		// that a Tflush of a request holding one can still be read.
+		// But only so many may wait; after that the reader waits too.
+		select {
+		case c.waiting <- struct{}{}:
+		case <-c.ctx.Done():
+			c.finish(tag, req)
+			c.framer.Release(f)
 			return
 		}
-		c.logf("readNetPackets: got %v, len %d, sending to IO", RPCNames[MType(l[4])], b.Len())
-		//panic(fmt.Sprintf("packet is %v", b.Bytes()[:]))
-		//panic(fmt.Sprintf("s is %v", s))
-		if err := c.server.D(c.server, b, t); err != nil {
-			c.logf("%v: %v", RPCNames[MType(l[4])], err)
+		c.wg.Add(1)
+		go func() {
+			defer c.wg.Done()
+			select {
+			case c.requests <- struct{}{}:
+				<-c.waiting
+				c.dispatch(req.ctx, f, b, t, tag, req)
+				<-c.requests
+			case <-req.ctx.Done():
+				// Flushed, or the connection is gone, before it
+				// started.
+				<-c.waiting
+				c.finish(tag, req)
+				c.framer.Release(f)
+			}
+		}()
+	}
+}
+
+// dispatch serves the request in b, with tag tag, and queues the reply,
+// unless the request has been flushed. b is in the frame f, which goes
+// back to the pool once the reply is written, or dropped.
+func (c *conn) dispatch(ctx context.Context, f []byte, b *bytes.Buffer, t MType, tag Tag, r *request) {
+	if err := c.server.D(ctx, c.server, b, t); err != nil {
+		c.logf("%v: %v", RPCNames[t], err)
+	}
+	if m := c.server.maxMsize(ctx); b.Len() > int(m) {
+		c.server.rerror(ctx, b, tag, fmt.Errorf("%v is %d bytes; msize is %d", RPCNames[t+1], b.Len(), m))
+	}
+	if r == nil {
+		c.replies <- RPCReply{b: b.Bytes(), frame: f}
+		return
+	}
+	// The client may reuse the tag as soon as it has the reply, so we
+	// are done with it before replying.
+	c.mu.Lock()
+	delete(c.tags, tag)
+	flushed := r.flushed
+	c.mu.Unlock()
+	if !flushed {
+		c.replies <- RPCReply{b: b.Bytes(), frame: f}
+	} else {
+		c.release(RPCReply{b: b.Bytes(), frame: f})
+	}
+	r.cancel()
+	close(r.done)
+}
+
+// finish ends the request with tag t without replying.
+func (c *conn) finish(t Tag, r *request) {
+	c.mu.Lock()
+	delete(c.tags, t)
+	c.mu.Unlock()
+	r.cancel()
+	close(r.done)
+}
+
+// release gives the buffers of a reply back to the pool. The reply is
+// usually marshaled into the request's frame, but may have outgrown it,
+// or, for an Rread, be in a frame of its own; either way, nothing else
+// refers to it.
+func (c *conn) release(r RPCReply) {
+	if len(r.b) > 0 && (len(r.frame) <= 5 || &r.b[0] != &r.frame[5]) {
+		c.framer.Release(r.b)
+	}
+	c.framer.Release(r.frame)
+}
+
+// writeReplies writes the replies to the connection, in the order they
+// are done, until replies is closed. If a write fails, the connection is
+// closed, which stops serve too.
+func (c *conn) writeReplies(done chan struct{}) {
+	defer close(done)
+	var failed bool
+	for r := range c.replies {
+		if failed {
+			continue
 		}
-		c.logf("readNetPackets: Write %v back", b)
-		amt, err := c.rwc.Write(b.Bytes())
+		c.logf("readNetPackets: Write %v back", r.b)
+		err := c.framer.WriteFrame(r.b)
+		c.release(r)
 		if err != nil {
 			c.logf("readNetPackets: write error: %v", err)
-			c.dead = true
-			return
+			c.setDead()
+			c.rwc.Close()
+			// Nobody will see the replies, so the requests may as
+			// well stop, which also frees a reader waiting on them.
+			c.cancel()
+			failed = true
+			continue
 		}
-		c.logf("Returned %v amt %v", b, amt)
 	}
 }
 
@@ -242,39 +574,340 @@ func (s *Server) NineServer() NineServer {
 	return s.NS
 }
 
+// SrvRversion is written by hand, not generated, as the server needs to
+// know which protocol was negotiated.
+func (s *Server) SrvRversion(ctx context.Context, b *bytes.Buffer) (err error) {
+	TMsize, TVersion, t, err := UnmarshalTversionPkt(b)
+	//if err != nil {
+	//}
+	if TMsize < minMsize {
+		MarshalRerrorPkt(b, t, fmt.Sprintf("msize %d is too small", TMsize))
+		return nil
+	}
+	// The msize is the least of what the client asks for, what we
+	// allow, and what the NineServer allows.
+	ss := s.session(ctx)
+	ss.mu.Lock()
+	ss.msize = 0
+	ss.mu.Unlock()
+	if m := s.maxMsize(ctx); TMsize > m {
+		TMsize = m
+	}
+	RMsize, RVersion, err := ss.nsc.Rversion(ctx, TMsize, TVersion)
+	if RMsize > TMsize {
+		RMsize = TMsize
+	}
+	if err == nil && RMsize < minMsize {
+		err = fmt.Errorf("msize %d is too small", RMsize)
+	}
+	if err == nil {
+		ok := true
+		switch RVersion {
+		case Version9P2000u:
+			ok = ss.dotu != nil
+		case Version9P2000L:
+			ok = ss.dotl != nil
+		}
+		switch {
+		case !ok && ss.ns == nil:
+			err = fmt.Errorf("%v not supported: %T only serves 9P2000", RVersion, ss.nsc)
+		case !ok:
+			err = fmt.Errorf("%v not supported by %T", RVersion, ss.ns)
+		}
+	}
+	if err != nil {
+		// Nothing is negotiated until we answer, so this is always 9P2000.
+		MarshalRerrorPkt(b, t, fmt.Sprintf("%v", err))
+		return nil
+	}
+	ss.mu.Lock()
+	ss.version = RVersion
+	ss.msize = RMsize
+	// A Tversion starts afresh, with no fids.
+	ss.afids = nil
+	ss.fids = nil
+	ss.mu.Unlock()
+	MarshalRversionPkt(b, t, RMsize, RVersion)
+	return nil
+}
+
+// SrvRflush is written by hand, not generated. By the time it is called,
+// the request being flushed has finished, so all that is left to do is
+// reply.
+func (s *Server) SrvRflush(ctx context.Context, b *bytes.Buffer) error {
+	_, t, err := UnmarshalTflushPkt(b)
+	if err != nil {
+		return err
+	}
+	MarshalRflushPkt(b, t)
+	return nil
+}
+
+// SrvRread is written by hand, not generated, so that a ReadIntoServer can
+// read into a frame of its own, which then becomes the reply.
+func (s *Server) SrvRread(ctx context.Context, b *bytes.Buffer) error {
+	fid, off, count, t, err := UnmarshalTreadPkt(b)
+	if err != nil {
+		return err
+	}
+	ss := s.session(ctx)
+	// A NineServer's RreadInto is only used when it is served by
+	// WithContext; a NineServerContext of the user's gets the reads.
+	var r func(FID, Offset, []byte) (int, error)
+	if nsc, ok := ss.nsc.(ReadIntoServerContext); ok {
+		r = func(fid FID, off Offset, dst []byte) (int, error) {
+			return nsc.RreadInto(ctx, fid, off, dst)
+		}
+	} else if ns, ok := served(ss.nsc); ok {
+		if ri, ok := ns.(ReadIntoServer); ok {
+			r = ri.RreadInto
+		}
+	}
+	if r == nil {
+		data, err := ss.nsc.Rread(ctx, fid, off, count)
+		if err != nil {
+			s.rerror(ctx, b, t, err)
+			return nil
+		}
+		MarshalRreadPkt(b, t, data)
+		return nil
+	}
+	if count < 0 {
+		count = 0
+	}
+	// The reply is size[4] Rread tag[2] count[4] data[count].
+	const hdr = 4 + 1 + 2 + 4
+	p := getFrame(hdr + int(count))
+	n, err := r(fid, off, p[hdr:])
+	if err == nil && (n < 0 || n > int(count)) {
+		err = fmt.Errorf("RreadInto read %d bytes of %d", n, count)
+	}
+	if err != nil {
+		putFrame(p)
+		s.rerror(ctx, b, t, err)
+		return nil
+	}
+	p = p[:hdr+n]
+	binary.LittleEndian.PutUint32(p, uint32(len(p)))
+	p[4] = uint8(Rread)
+	binary.LittleEndian.PutUint16(p[5:], uint16(t))
+	binary.LittleEndian.PutUint32(p[7:], uint32(n))
+	*b = *bytes.NewBuffer(p)
+	return nil
+}
+
+// SrvRwrite is written by hand, not generated, so that the data is only
+// passed in the frame it was read into to a WriteFromServer; everything
+// else gets a copy, which it may keep.
+func (s *Server) SrvRwrite(ctx context.Context, b *bytes.Buffer) error {
+	fid, off, data, t, err := UnmarshalTwritePkt(b)
+	if err != nil {
+		return err
+	}
+	ss := s.session(ctx)
+	// As with RreadInto, a NineServer's RwriteFrom is only used when it
+	// is served by WithContext.
+	var w func(FID, Offset, []byte) (Count, error)
+	if nsc, ok := ss.nsc.(WriteFromServerContext); ok {
+		w = func(fid FID, off Offset, src []byte) (Count, error) {
+			return nsc.RwriteFrom(ctx, fid, off, src)
+		}
+	} else if ns, ok := served(ss.nsc); ok {
+		if wf, ok := ns.(WriteFromServer); ok {
+			w = wf.RwriteFrom
+		}
+	}
+	if w == nil {
+		w = func(fid FID, off Offset, src []byte) (Count, error) {
+			return ss.nsc.Rwrite(ctx, fid, off, append([]byte(nil), src...))
+		}
+	}
+	n, err := w(fid, off, data)
+	if err != nil {
+		s.rerror(ctx, b, t, err)
+		return nil
+	}
+	MarshalRwritePkt(b, t, n)
+	return nil
+}
+
+// rerror marshals err into b as the reply to tag t. 9P2000.u adds an errno
+// to the reply, and 9P2000.L replaces it with an Rlerror holding only that.
+// The error is sent as ToError makes it, except that 9P2000, which has
+// only the message, sends the Plan 9 message for the errno, if the error
+// has one, so that the client can tell what it is. The errno is sent as
+// its Linux number, or as EIO if it has none.
+func (s *Server) rerror(ctx context.Context, b *bytes.Buffer, t Tag, err error) {
+	e, known := toError(err)
+	l, ok := linuxErrno(e.Errno)
+	if !ok {
+		l, _ = linuxErrno(eIO)
+	}
+	switch s.version(ctx) {
+	case Version9P2000u:
+		MarshalRerrorDotuPkt(b, t, e.Err, l)
+	case Version9P2000L:
+		MarshalRlerrorPkt(b, t, l)
+	default:
+		m := e.Err
+		if p, ok := plan9Message(e.Errno); ok && known {
+			m = p
+		}
+		MarshalRerrorPkt(b, t, m)
+	}
+}
+
 // Dispatch dispatches request to different functions.
 // It's also the the first place we try to establish server semantics.
 // We could do this with interface assertions and such a la rsc/fuse
 // but most people I talked do disliked that. So we don't. If you want
 // to make things optional, just define the ones you want to implement in this case.
-func Dispatch(s *Server, b *bytes.Buffer, t MType) error {
+func Dispatch(ctx context.Context, s *Server, b *bytes.Buffer, t MType) error {
+	switch t {
+	case Tread, Treaddir:
+		// The count follows the tag, fid and offset.
+		s.limitCount(ctx, b, 14)
+	}
+	if fid, a := s.authFID(ctx, b, t); a != nil {
+		return s.srvAuthFID(ctx, b, t, fid, a)
+	}
+	nf, err := s.checkFID(ctx, b, t)
+	if err != nil {
+		p := b.Bytes()
+		s.rerror(ctx, b, Tag(p[0])|Tag(p[1])<<8, err)
+		return nil
+	}
+	err = dispatch(ctx, s, b, t)
+	if nf != (newFID{}) {
+		s.madeFID(ctx, b, nf)
+	}
+	return err
+}
+
+func dispatch(ctx context.Context, s *Server, b *bytes.Buffer, t MType) error {
+	v := s.version(ctx)
+	if v == Version9P2000L {
+		switch t {
+		case Tauth:
+			return s.SrvRauthDotu(ctx, b)
+		case Tattach:
+			return s.srvAttach(ctx, b, true)
+		case Tstatfs:
+			return s.SrvRstatfs(ctx, b)
+		case Tlopen:
+			return s.SrvRlopen(ctx, b)
+		case Tlcreate:
+			return s.SrvRlcreate(ctx, b)
+		case Tsymlink:
+			return s.SrvRsymlink(ctx, b)
+		case Tmknod:
+			return s.SrvRmknod(ctx, b)
+		case Trename:
+			return s.SrvRrename(ctx, b)
+		case Treadlink:
+			return s.SrvRreadlink(ctx, b)
+		case Tgetattr:
+			return s.SrvRgetattr(ctx, b)
+		case Tsetattr:
+			return s.SrvRsetattr(ctx, b)
+		case Txattrwalk:
+			return s.SrvRxattrwalk(ctx, b)
+		case Txattrcreate:
+			return s.SrvRxattrcreate(ctx, b)
+		case Treaddir:
+			return s.SrvRreaddir(ctx, b)
+		case Tfsync:
+			return s.SrvRfsync(ctx, b)
+		case Tlock:
+			return s.SrvRlock(ctx, b)
+		case Tgetlock:
+			return s.SrvRgetlock(ctx, b)
+		case Tlink:
+			return s.SrvRlink(ctx, b)
+		case Tmkdir:
+			return s.SrvRmkdir(ctx, b)
+		case Trenameat:
+			return s.SrvRrenameat(ctx, b)
+		case Tunlinkat:
+			return s.SrvRunlinkat(ctx, b)
+		case Topen, Tcreate, Tstat, Twstat:
+			return s.unsupported(ctx, b, t)
+		}
+	}
 	switch t {
 	case Tversion:
-		return s.SrvRversion(b)
+		return s.SrvRversion(ctx, b)
+	case Tauth:
+		if v == Version9P2000u {
+			return s.SrvRauthDotu(ctx, b)
+		}
+		return s.SrvRauth(ctx, b)
 	case Tattach:
-		return s.SrvRattach(b)
+		return s.srvAttach(ctx, b, v == Version9P2000u)
 	case Tflush:
-		return s.SrvRflush(b)
+		return s.SrvRflush(ctx, b)
 	case Twalk:
-		return s.SrvRwalk(b)
+		return s.SrvRwalk(ctx, b)
 	case Topen:
-		return s.SrvRopen(b)
+		return s.SrvRopen(ctx, b)
 	case Tcreate:
-		return s.SrvRcreate(b)
+		if v == Version9P2000u {
+			return s.SrvRcreateDotu(ctx, b)
+		}
+		return s.SrvRcreate(ctx, b)
 	case Tclunk:
-		return s.SrvRclunk(b)
+		return s.SrvRclunk(ctx, b)
 	case Tstat:
-		return s.SrvRstat(b)
+		return s.SrvRstat(ctx, b)
 	case Twstat:
-		return s.SrvRwstat(b)
+		return s.SrvRwstat(ctx, b)
 	case Tremove:
-		return s.SrvRremove(b)
+		return s.SrvRremove(ctx, b)
 	case Tread:
-		return s.SrvRread(b)
+		return s.SrvRread(ctx, b)
 	case Twrite:
-		return s.SrvRwrite(b)
+		return s.SrvRwrite(ctx, b)
 	}
 	// This has been tested by removing Attach from the switch.
+	return s.unsupported(ctx, b, t)
+}
+
+// limitCount lowers the count at off in the request in b, if need be, so
+// that the data fits in an msize reply. We change it in place, so that
+// the NineServer never sees a count it can't honor.
+func (s *Server) limitCount(ctx context.Context, b *bytes.Buffer, off int) {
+	p := b.Bytes()
+	if len(p) < off+4 {
+		return
+	}
+	m := uint32(s.maxMsize(ctx)) - IOHDRSZ
+	if binary.LittleEndian.Uint32(p[off:]) > m {
+		binary.LittleEndian.PutUint32(p[off:], m)
+	}
+}
+
+// srvAttach checks the authentication for a Tattach before passing it on.
+func (s *Server) srvAttach(ctx context.Context, b *bytes.Buffer, dotu bool) error {
+	if t, err := s.checkAttach(ctx, b, dotu); err != nil {
+		s.rerror(ctx, b, t, err)
+		return nil
+	}
+	if dotu {
+		return s.SrvRattachDotu(ctx, b)
+	}
+	return s.SrvRattach(ctx, b)
+}
+
+func (s *Server) unsupported(ctx context.Context, b *bytes.Buffer, t MType) error {
+	if s.version(ctx) == Version9P2000L {
+		// 9P2000.L clients can't read an Rerror.
+		var u [2]byte
+		if _, err := b.Read(u[:]); err != nil {
+			return nil
+		}
+		MarshalRlerrorPkt(b, Tag(u[0])|Tag(u[1])<<8, lENOSYS)
+		return nil
+	}
 	ServerError(b, fmt.Sprintf("Dispatch: %v not supported", RPCNames[t]))
 	return nil
 }
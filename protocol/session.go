@@ -0,0 +1,139 @@
+// Copyright 2018 The Ninep Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protocol
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// session is the state of one connection: the protocol negotiated on it,
+// its auth fids, and the servers which serve it.
+type session struct {
+	ns  NineServer
+	nsc NineServerContext
+	// dotu and dotl serve the messages of 9P2000.u and 9P2000.L, if
+	// anything does.
+	dotu NineServerDotuContext
+	dotl NineServerLContext
+
+	// mu guards below
+	mu sync.Mutex
+
+	// version is the negotiated protocol version.
+	version string
+
+	// msize is the negotiated msize, or 0 if there is none yet.
+	msize MaxSize
+
+	// afids are the auth fids.
+	afids map[FID]*authState
+
+	// fids are the fids the NineServer has, as far as the Server can
+	// tell, which may not be used as auth fids.
+	fids map[FID]struct{}
+}
+
+type sessionKey struct{}
+
+// newSession starts a session for a new connection. If NS is a
+// SessionServer, the session gets a NineServer of its own.
+func (s *Server) newSession() (*session, error) {
+	n, ok := s.NS.(SessionServer)
+	if !ok {
+		return serving(s.NS, s.NSContext), nil
+	}
+	if err := s.checkSessions(); err != nil {
+		return nil, err
+	}
+	ns, err := n.NewSession()
+	if err != nil {
+		return nil, err
+	}
+	return serving(ns, WithContext(ns)), nil
+}
+
+// serving returns a session served by ns and nsc. The messages of
+// 9P2000.u and 9P2000.L go to nsc, if it serves them, or else to ns.
+func serving(ns NineServer, nsc NineServerContext) *session {
+	ss := &session{ns: ns, nsc: nsc}
+	w := WithContext(ns)
+	if u, ok := nsc.(NineServerDotuContext); ok {
+		ss.dotu = u
+	} else if u, ok := w.(NineServerDotuContext); ok {
+		ss.dotu = u
+	}
+	if l, ok := nsc.(NineServerLContext); ok {
+		ss.dotl = l
+	} else if l, ok := w.(NineServerLContext); ok {
+		ss.dotl = l
+	}
+	return ss
+}
+
+// checkSessions checks that a SessionServer NS is not given with a
+// NSContext other than WithContext's for it. The session's NineServer is
+// served with WithContext, so such a NSContext would never be used.
+func (s *Server) checkSessions() error {
+	if _, ok := s.NS.(SessionServer); !ok || s.NSContext == nil {
+		return nil
+	}
+	if ns, ok := served(s.NSContext); ok && ns == s.NS {
+		return nil
+	}
+	return fmt.Errorf("NS %T is a SessionServer, so NSContext %T would not be used", s.NS, s.NSContext)
+}
+
+// close ends the session.
+func (s *Server) closeSession(ss *session) error {
+	if ss.ns == s.NS {
+		return nil
+	}
+	if c, ok := ss.ns.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// session returns the session a request belongs to. A Dispatcher called
+// other than by the Server, with a context which has no session, gets one
+// shared by all such calls.
+func (s *Server) session(ctx context.Context) *session {
+	if ss, ok := ctx.Value(sessionKey{}).(*session); ok {
+		return ss
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sess == nil {
+		s.sess = serving(s.NS, s.NSContext)
+	}
+	return s.sess
+}
+
+// version returns the protocol version negotiated for the request's
+// connection, or "" if there is none yet.
+func (s *Server) version(ctx context.Context) string {
+	ss := s.session(ctx)
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	return ss.version
+}
+
+// maxMsize returns the msize negotiated for the request's connection or,
+// if there is none yet, the largest the server will agree to.
+func (s *Server) maxMsize(ctx context.Context) MaxSize {
+	ss := s.session(ctx)
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	if ss.msize != 0 {
+		return ss.msize
+	}
+	if s.Msize != 0 {
+		return s.Msize
+	}
+	return MSIZE
+}
@@ -0,0 +1,237 @@
+// Copyright 2018 The Ninep Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protocol
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+)
+
+// DefaultWindow is how many requests a Reader or Writer keeps outstanding
+// if it is not told.
+const DefaultWindow = 8
+
+// pending is an outstanding Tread or Twrite of n bytes at off. A Twrite
+// keeps its data, in case it has to be written again.
+type pending struct {
+	call *Call
+	off  int64
+	n    int
+	data []byte
+}
+
+// A Reader reads a File sequentially, keeping a window of Treads, at
+// increasing offsets, outstanding, so that a read does not wait for a
+// round trip to the server. It returns the data in order. A Reader is not
+// safe for concurrent use.
+type Reader struct {
+	f      *File
+	window int
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// off is where the next Tread goes.
+	off     int64
+	pending []pending
+	// buf is data which has come but not been read.
+	buf []byte
+	err error
+}
+
+// NewReader returns a Reader for f starting at off, which keeps window
+// Treads outstanding, or DefaultWindow if window is not positive. The
+// Reader does not use or change f's offset. f must be open for reading.
+func (f *File) NewReader(off int64, window int) *Reader {
+	if window <= 0 {
+		window = DefaultWindow
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Reader{f: f, window: window, ctx: ctx, cancel: cancel, off: off}
+}
+
+// Read reads the next data from the File. Once it fails, it goes on
+// failing with the same error, which is io.EOF at the end of the file.
+func (r *Reader) Read(b []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		r.fill()
+		p := r.pending[0]
+		r.pending = r.pending[1:]
+		call := <-p.call.Done
+		if call.Error != nil {
+			r.fail(fmt.Errorf("Tread at %d: %w", p.off, call.Error))
+			continue
+		}
+		d := call.Reply.(*TreadReply).Data
+		switch {
+		case len(d) == 0:
+			r.fail(io.EOF)
+		case len(d) < p.n:
+			// The Treads after this one are for the wrong offsets.
+			// They are left to finish by themselves, and we start
+			// again after the data we got.
+			r.pending = nil
+			r.off = p.off + int64(len(d))
+		}
+		r.buf = d
+	}
+	n := copy(b, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// fill sends Treads until the window is full.
+func (r *Reader) fill() {
+	m := r.f.maxIO()
+	for len(r.pending) < r.window {
+		call := r.f.c.GoTread(r.ctx, r.f.fid, Offset(r.off), Count(m), nil)
+		r.pending = append(r.pending, pending{call: call, off: r.off, n: m})
+		r.off += int64(m)
+	}
+}
+
+// fail stops the Reader with err, and flushes its outstanding Treads.
+func (r *Reader) fail(err error) {
+	r.err = err
+	r.pending = nil
+	r.cancel()
+}
+
+// Close stops the Reader, and flushes its outstanding Treads. It does not
+// close the File.
+func (r *Reader) Close() error {
+	if r.err == nil {
+		r.fail(os.ErrClosed)
+	}
+	return nil
+}
+
+// A Writer writes a File sequentially, keeping a window of Twrites, at
+// increasing offsets, outstanding, so that a write does not wait for a
+// round trip to the server. It buffers what it is given until it has a
+// Twrite's worth, so it must be closed, or flushed, when done. A Writer is
+// not safe for concurrent use.
+type Writer struct {
+	f      *File
+	window int
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// off is where the next Twrite goes.
+	off     int64
+	pending []pending
+	buf     []byte
+	// free are buffers whose Twrites have been answered.
+	free [][]byte
+	err  error
+}
+
+// NewWriter returns a Writer for f starting at off, which keeps window
+// Twrites outstanding, or DefaultWindow if window is not positive. The
+// Writer does not use or change f's offset. f must be open for writing.
+func (f *File) NewWriter(off int64, window int) *Writer {
+	if window <= 0 {
+		window = DefaultWindow
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Writer{f: f, window: window, ctx: ctx, cancel: cancel, off: off, buf: make([]byte, 0, f.maxIO())}
+}
+
+// Write writes b to the File. As the Twrites are answered later, an
+// error may be for an earlier Write; once the Writer fails, it goes on
+// failing with the same error.
+func (w *Writer) Write(b []byte) (int, error) {
+	var tot int
+	for len(b) > 0 {
+		if w.err != nil {
+			return tot, w.err
+		}
+		n := copy(w.buf[len(w.buf):cap(w.buf)], b)
+		w.buf = w.buf[:len(w.buf)+n]
+		b = b[n:]
+		tot += n
+		if len(w.buf) == cap(w.buf) {
+			w.send()
+		}
+	}
+	return tot, w.err
+}
+
+// send sends the buffer as a Twrite, first waiting for the oldest if the
+// window is full.
+func (w *Writer) send() {
+	if len(w.pending) == w.window {
+		w.wait()
+	}
+	if w.err != nil {
+		return
+	}
+	call := w.f.c.GoTwrite(w.ctx, w.f.fid, Offset(w.off), w.buf, nil)
+	w.pending = append(w.pending, pending{call: call, off: w.off, n: len(w.buf), data: w.buf})
+	w.off += int64(len(w.buf))
+	if n := len(w.free); n > 0 {
+		w.buf, w.free = w.free[n-1][:0], w.free[:n-1]
+	} else {
+		w.buf = make([]byte, 0, cap(w.buf))
+	}
+}
+
+// wait waits for the oldest Twrite.
+func (w *Writer) wait() {
+	p := w.pending[0]
+	w.pending = w.pending[1:]
+	call := <-p.call.Done
+	w.free = append(w.free, p.data)
+	if w.err != nil {
+		return
+	}
+	if call.Error != nil {
+		w.fail(fmt.Errorf("Twrite at %d: %w", p.off, call.Error))
+		return
+	}
+	n := int(call.Reply.(*TwriteReply).RLen)
+	if n > p.n {
+		w.fail(fmt.Errorf("Twrite at %d: Rwrite count %d > %d bytes written", p.off, n, p.n))
+		return
+	}
+	// The later Twrites are for the right offsets, so a short write
+	// only needs the rest written.
+	if n < p.n {
+		if _, err := w.f.WriteAt(p.data[n:p.n], p.off+int64(n)); err != nil {
+			w.fail(fmt.Errorf("Twrite at %d: %w", p.off+int64(n), err))
+		}
+	}
+}
+
+// fail stops the Writer with err, and flushes its outstanding Twrites.
+func (w *Writer) fail(err error) {
+	w.err = err
+	w.cancel()
+}
+
+// Flush writes any buffered data, and waits for all the Twrites to be
+// answered.
+func (w *Writer) Flush() error {
+	if w.err == nil && len(w.buf) > 0 {
+		w.send()
+	}
+	for len(w.pending) > 0 {
+		w.wait()
+	}
+	return w.err
+}
+
+// Close flushes the Writer. It does not close the File.
+func (w *Writer) Close() error {
+	err := w.Flush()
+	if w.err == nil {
+		w.fail(os.ErrClosed)
+	}
+	return err
+}
@@ -5,16 +5,6 @@
 package protocol
 
 
-// A File is defined by a QID. File Servers never see a FID.
-// There are two channels. The first is for normal requests.
-// The second is for Flushes. File server code always
-// checks the flush channel first. At the same time, server code
-// puts the flush into both channels, so the server code has some
-// idea when the flush entered the queue. This is very similar
-// to how MSI-X works on PCIe ...
-type File struct {
-}
-
 /*
 // A FileOp is a function to call, an abort channel, and a reply channel
 type FileOp struct {